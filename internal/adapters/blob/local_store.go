@@ -0,0 +1,77 @@
+package blob
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/blob"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+type localStore struct {
+	dir string
+}
+
+// LocalConfig holds local blob store configuration
+type LocalConfig struct {
+	Dir string
+}
+
+// NewLocalStore creates a blob store that keeps files under a directory on disk
+func NewLocalStore(config LocalConfig) blob.Store {
+	return &localStore{dir: config.Dir}
+}
+
+func (s *localStore) Put(key, contentType string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		log.Printf("ERROR: Failed to create blob directory. Key: %s, Error: %v", key, err)
+		return apperrors.ErrStorageError
+	}
+
+	// Write to a temporary file first so readers never see half a file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		log.Printf("ERROR: Failed to write blob. Key: %s, Error: %v", key, err)
+		return apperrors.ErrStorageError
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		log.Printf("ERROR: Failed to write blob. Key: %s, Error: %v", key, err)
+		return apperrors.ErrStorageError
+	}
+
+	return nil
+}
+
+func (s *localStore) Get(key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, blob.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read blob. Key: %s, Error: %v", key, err)
+		return nil, apperrors.ErrStorageError
+	}
+
+	return data, nil
+}
+
+// path maps a key to a file under the store directory, refusing keys that
+// would escape it
+func (s *localStore) path(key string) (string, error) {
+	if !filepath.IsLocal(key) {
+		return "", blob.ErrNotFound
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
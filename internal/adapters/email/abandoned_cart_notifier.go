@@ -0,0 +1,41 @@
+package email
+
+import (
+	"fmt"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/auth"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/cart"
+)
+
+type abandonedCartNotifier struct {
+	sender auth.EmailSender
+}
+
+// NewAbandonedCartNotifier creates a notifier that emails abandoned cart
+// reminders to the cart owner
+func NewAbandonedCartNotifier(sender auth.EmailSender) cart.ReminderNotifier {
+	return &abandonedCartNotifier{
+		sender: sender,
+	}
+}
+
+func (n *abandonedCartNotifier) NotifyAbandoned(notice cart.ReminderNotice) error {
+	subject := "You left something in your cart"
+	if notice.Sequence > 1 {
+		subject = "Your cart is still waiting for you"
+	}
+
+	items := "1 item"
+	if notice.Items != 1 {
+		items = fmt.Sprintf("%d items", notice.Items)
+	}
+
+	text := fmt.Sprintf("Your cart still holds %s worth %s %s.", items, notice.Total.String(), notice.Total.Currency)
+	email := auth.Email{
+		To:      notice.To,
+		Subject: subject,
+		Text:    text + " Pick up where you left off: " + notice.RestoreURL,
+		HTML:    "<p>" + text + "</p><p><a href=\"" + notice.RestoreURL + "\">Return to your cart</a></p>",
+	}
+	return n.sender.Send(email)
+}
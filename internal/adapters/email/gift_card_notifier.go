@@ -0,0 +1,30 @@
+package email
+
+import (
+	"fmt"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/auth"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/giftcard"
+)
+
+type giftCardNotifier struct {
+	sender auth.EmailSender
+}
+
+// NewGiftCardNotifier creates a notifier that emails gift card codes
+func NewGiftCardNotifier(sender auth.EmailSender) giftcard.Notifier {
+	return &giftCardNotifier{
+		sender: sender,
+	}
+}
+
+func (n *giftCardNotifier) NotifyIssued(notice giftcard.IssuedNotice) error {
+	text := fmt.Sprintf("Your gift card is worth %s %s.", notice.Balance.String(), notice.Balance.Currency)
+	email := auth.Email{
+		To:      notice.To,
+		Subject: "Your gift card",
+		Text:    text + " Enter the code " + notice.Code + " at checkout to use it.",
+		HTML:    "<p>" + text + "</p><p>Enter the code <strong>" + notice.Code + "</strong> at checkout to use it.</p>",
+	}
+	return n.sender.Send(email)
+}
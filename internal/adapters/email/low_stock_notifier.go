@@ -0,0 +1,37 @@
+package email
+
+import (
+	"fmt"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/auth"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/inventory"
+)
+
+type lowStockNotifier struct {
+	sender auth.EmailSender
+	to     string
+}
+
+// NewLowStockNotifier creates a low-stock notifier that emails the given address
+func NewLowStockNotifier(sender auth.EmailSender, to string) inventory.LowStockNotifier {
+	return &lowStockNotifier{
+		sender: sender,
+		to:     to,
+	}
+}
+
+func (n *lowStockNotifier) NotifyLowStock(alert inventory.LowStockAlert) error {
+	item := "product " + alert.ProductID
+	if alert.VariantID != "" {
+		item += " (variant " + alert.VariantID + ")"
+	}
+
+	text := fmt.Sprintf("Stock for %s is down to %d (threshold %d).", item, alert.Stock, alert.Threshold)
+	email := auth.Email{
+		To:      n.to,
+		Subject: "Low stock alert",
+		Text:    text,
+		HTML:    "<p>" + text + "</p>",
+	}
+	return n.sender.Send(email)
+}
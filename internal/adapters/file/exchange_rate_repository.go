@@ -0,0 +1,138 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/pricing"
+)
+
+type exchangeRateRepository struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	rates   map[string]*pricing.ExchangeRate // by "BASE/QUOTE"
+}
+
+// rateEntry is one rate in the file, e.g. {"base": "USD", "quote": "EUR", "rate": "0.92"}
+type rateEntry struct {
+	Base  string `json:"base"`
+	Quote string `json:"quote"`
+	Rate  string `json:"rate"`
+}
+
+// NewExchangeRateRepository creates a pricing.RateRepository that reads rates
+// from a JSON file holding a list of rate entries. The file is read again
+// when it changes, so a feed job can replace it while the store runs; rates
+// cannot be set through the store. It fails when the file cannot be loaded.
+func NewExchangeRateRepository(path string) (pricing.RateRepository, error) {
+	r := &exchangeRateRepository{path: path}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *exchangeRateRepository) GetRate(base, quote string) (*pricing.ExchangeRate, error) {
+	rates := r.current()
+
+	rate, ok := rates[base+"/"+quote]
+	if !ok {
+		return nil, pricing.ErrRateNotFound
+	}
+
+	return rate, nil
+}
+
+func (r *exchangeRateRepository) SetRate(rate *pricing.ExchangeRate) error {
+	return pricing.ErrRatesReadOnly
+}
+
+func (r *exchangeRateRepository) ListRates() ([]*pricing.ExchangeRate, error) {
+	rates := r.current()
+
+	list := make([]*pricing.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		list = append(list, rate)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Base != list[j].Base {
+			return list[i].Base < list[j].Base
+		}
+		return list[i].Quote < list[j].Quote
+	})
+
+	return list, nil
+}
+
+// current returns the rates, reading the file again first if it changed. A
+// file that no longer loads keeps the last good rates in use.
+func (r *exchangeRateRepository) current() map[string]*pricing.ExchangeRate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, err := os.Stat(r.path)
+	if err == nil && !info.ModTime().Equal(r.modTime) {
+		if err := r.load(); err != nil {
+			log.Printf("ERROR: Failed to reload exchange rates, keeping the previous ones. Path: %s, Error: %v", r.path, err)
+			// Not retried until the file changes again
+			r.modTime = info.ModTime()
+		}
+	}
+
+	return r.rates
+}
+
+func (r *exchangeRateRepository) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.load()
+}
+
+// load replaces the rates with the file contents; the caller holds r.mu
+func (r *exchangeRateRepository) load() error {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return fmt.Errorf("exchange rates file: %w", err)
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("exchange rates file: %w", err)
+	}
+
+	var entries []rateEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("exchange rates file %s: %w", r.path, err)
+	}
+
+	rates := make(map[string]*pricing.ExchangeRate, len(entries))
+	for _, entry := range entries {
+		base, quote := strings.ToUpper(entry.Base), strings.ToUpper(entry.Quote)
+		rate, ok := new(big.Rat).SetString(entry.Rate)
+		if len(base) != 3 || len(quote) != 3 || base == quote || !ok || rate.Sign() <= 0 {
+			return fmt.Errorf("exchange rates file %s: invalid rate %s/%s %q", r.path, entry.Base, entry.Quote, entry.Rate)
+		}
+
+		rates[base+"/"+quote] = &pricing.ExchangeRate{
+			Base:      base,
+			Quote:     quote,
+			Rate:      rate,
+			UpdatedAt: info.ModTime(),
+		}
+	}
+
+	r.rates = rates
+	r.modTime = info.ModTime()
+	return nil
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/idempotency"
+)
+
+type idempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+}
+
+// NewIdempotencyStore creates an idempotency store that keeps records in
+// process memory. Records are lost on restart and not shared between
+// instances, so it suits development and single-instance deployments.
+func NewIdempotencyStore() idempotency.Store {
+	return &idempotencyStore{
+		records: make(map[string]idempotency.Record),
+	}
+}
+
+func (s *idempotencyStore) Begin(record *idempotency.Record) (*idempotency.Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if held, ok := s.records[record.Key]; ok && held.ExpiresAt.After(time.Now()) {
+		return &held, false, nil
+	}
+
+	s.records[record.Key] = *record
+	return nil, true, nil
+}
+
+func (s *idempotencyStore) Extend(key string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if held, ok := s.records[key]; ok && held.InFlight() {
+		held.ExpiresAt = expiresAt
+		s.records[key] = held
+	}
+	return nil
+}
+
+func (s *idempotencyStore) Complete(record *idempotency.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[record.Key] = *record
+	return nil
+}
+
+func (s *idempotencyStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if held, ok := s.records[key]; ok && held.InFlight() {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func (s *idempotencyStore) DeleteExpired(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, record := range s.records {
+		if record.ExpiresAt.Before(before) {
+			delete(s.records, key)
+		}
+	}
+	return nil
+}
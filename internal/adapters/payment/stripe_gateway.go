@@ -0,0 +1,236 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/payment"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+const stripeAPIURL = "https://api.stripe.com/v1"
+
+type stripeGateway struct {
+	secretKey       string
+	webhookSecret   string
+	client          *http.Client
+	signatureMaxAge time.Duration
+}
+
+// StripeConfig holds Stripe configuration
+type StripeConfig struct {
+	SecretKey     string
+	WebhookSecret string
+}
+
+// NewStripeGateway creates a new Stripe payment gateway
+func NewStripeGateway(config StripeConfig) payment.Gateway {
+	return &stripeGateway{
+		secretKey:       config.SecretKey,
+		webhookSecret:   config.WebhookSecret,
+		client:          &http.Client{Timeout: 10 * time.Second},
+		signatureMaxAge: 5 * time.Minute,
+	}
+}
+
+type stripePaymentIntent struct {
+	ID           string `json:"id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+func (g *stripeGateway) CreateIntent(orderID string, amount money.Money) (*payment.Intent, error) {
+	// Stripe expects the amount in the currency's smallest unit, as we store it
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(amount.Amount, 10))
+	form.Set("currency", strings.ToLower(amount.Currency))
+	form.Set("metadata[order_id]", orderID)
+	form.Set("automatic_payment_methods[enabled]", "true")
+
+	req, err := http.NewRequest(http.MethodPost, stripeAPIURL+"/payment_intents", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, apperrors.ErrPaymentFailed
+	}
+	req.SetBasicAuth(g.secretKey, "")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// Retried checkouts for the same order must not create a second intent
+	req.Header.Set("Idempotency-Key", "order-"+orderID)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		log.Printf("ERROR: Failed to reach Stripe. OrderID: %s, Error: %v", orderID, err)
+		return nil, apperrors.ErrPaymentFailed
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("ERROR: Stripe rejected payment intent. OrderID: %s, Status: %d", orderID, resp.StatusCode)
+		return nil, apperrors.ErrPaymentFailed
+	}
+
+	var intent stripePaymentIntent
+	if err := json.NewDecoder(resp.Body).Decode(&intent); err != nil {
+		return nil, apperrors.ErrPaymentFailed
+	}
+
+	return &payment.Intent{
+		ID:           intent.ID,
+		ClientSecret: intent.ClientSecret,
+		Amount:       amount,
+	}, nil
+}
+
+func (g *stripeGateway) CancelIntent(paymentID string) error {
+	req, err := http.NewRequest(http.MethodPost, stripeAPIURL+"/payment_intents/"+url.PathEscape(paymentID)+"/cancel", nil)
+	if err != nil {
+		return apperrors.ErrPaymentFailed
+	}
+	req.SetBasicAuth(g.secretKey, "")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		log.Printf("ERROR: Failed to reach Stripe. PaymentID: %s, Error: %v", paymentID, err)
+		return apperrors.ErrPaymentFailed
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("ERROR: Stripe rejected payment intent cancellation. PaymentID: %s, Status: %d", paymentID, resp.StatusCode)
+		return apperrors.ErrPaymentFailed
+	}
+
+	return nil
+}
+
+type stripeRefund struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (g *stripeGateway) Refund(paymentID string, amount money.Money, idempotencyKey string) (*payment.RefundResult, error) {
+	form := url.Values{}
+	form.Set("payment_intent", paymentID)
+	form.Set("amount", strconv.FormatInt(amount.Amount, 10))
+
+	req, err := http.NewRequest(http.MethodPost, stripeAPIURL+"/refunds", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, apperrors.ErrRefundFailed
+	}
+	req.SetBasicAuth(g.secretKey, "")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		log.Printf("ERROR: Failed to reach Stripe. PaymentID: %s, Error: %v", paymentID, err)
+		return nil, apperrors.ErrRefundFailed
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("ERROR: Stripe rejected refund. PaymentID: %s, Status: %d", paymentID, resp.StatusCode)
+		return nil, apperrors.ErrRefundFailed
+	}
+
+	var refund stripeRefund
+	if err := json.NewDecoder(resp.Body).Decode(&refund); err != nil {
+		return nil, apperrors.ErrRefundFailed
+	}
+
+	// Card refunds are usually pending until the bank settles them; only an
+	// outright failure is treated as a rejected refund
+	if refund.Status == "failed" || refund.Status == "canceled" {
+		log.Printf("ERROR: Stripe refund did not go through. PaymentID: %s, RefundID: %s, Status: %s", paymentID, refund.ID, refund.Status)
+		return nil, apperrors.ErrRefundFailed
+	}
+
+	return &payment.RefundResult{ID: refund.ID}, nil
+}
+
+type stripeEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object struct {
+			ID             string            `json:"id"`
+			AmountReceived int64             `json:"amount_received"`
+			Currency       string            `json:"currency"`
+			Metadata       map[string]string `json:"metadata"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+func (g *stripeGateway) ParseWebhook(payload []byte, signature string) (*payment.Event, error) {
+	if err := g.verifySignature(payload, signature); err != nil {
+		return nil, err
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, apperrors.ErrRequestInvalidBody
+	}
+
+	result := &payment.Event{
+		ID:        event.ID,
+		Type:      payment.EventIgnored,
+		PaymentID: event.Data.Object.ID,
+		OrderID:   event.Data.Object.Metadata["order_id"],
+		Amount:    money.New(event.Data.Object.AmountReceived, event.Data.Object.Currency),
+	}
+
+	switch event.Type {
+	case "payment_intent.succeeded":
+		result.Type = payment.EventSucceeded
+	case "payment_intent.payment_failed", "payment_intent.canceled":
+		result.Type = payment.EventFailed
+	}
+
+	return result, nil
+}
+
+// verifySignature checks the Stripe-Signature header ("t=<unix>,v1=<hex hmac>")
+func (g *stripeGateway) verifySignature(payload []byte, header string) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return apperrors.ErrInvalidWebhookSignature
+	}
+	if time.Since(time.Unix(ts, 0)) > g.signatureMaxAge {
+		return apperrors.ErrInvalidWebhookSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(g.webhookSecret))
+	mac.Write([]byte(fmt.Sprintf("%s.%s", timestamp, payload)))
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return apperrors.ErrInvalidWebhookSignature
+}
@@ -0,0 +1,202 @@
+package pdf
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/invoice"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+const dateLayout = "2006-01-02"
+
+type templateRenderer struct {
+	templates *template.Template
+}
+
+// NewTemplateRenderer creates a document renderer from the built-in text
+// templates, one per document type
+func NewTemplateRenderer() invoice.Renderer {
+	templates := template.Must(template.New("documents").
+		Funcs(template.FuncMap{"left": left, "right": right, "rule": rule}).
+		ParseFS(templateFS, "templates/*.tmpl"))
+
+	return &templateRenderer{templates: templates}
+}
+
+func (r *templateRenderer) Render(content invoice.Content) ([]byte, error) {
+	var out bytes.Buffer
+	name := string(content.Document.Type) + ".tmpl"
+	if err := r.templates.ExecuteTemplate(&out, name, newDocumentView(content)); err != nil {
+		log.Printf("ERROR: Failed to render document. Number: %s, Error: %v", content.Document.Number, err)
+		return nil, invoice.ErrRenderFailed
+	}
+
+	return writePDF(strings.Split(strings.TrimRight(out.String(), "\n"), "\n")), nil
+}
+
+type documentView struct {
+	Title         string
+	Number        string
+	IssuedAt      string
+	OrderID       string
+	OrderDate     string
+	InvoiceNumber string // credit notes only
+	Currency      string
+	TaxInclusive  bool
+	Seller        invoice.Party
+	Addresses     []addressRow
+	Lines         []lineView
+	Description   string // credit notes only
+	Credited      string // credit notes only
+	Totals        []totalRow
+}
+
+// addressRow prints the billing and shipping addresses side by side
+type addressRow struct {
+	Billing  string
+	Shipping string
+}
+
+type lineView struct {
+	Name      string
+	SKU       string
+	Quantity  int
+	UnitPrice string
+	Discount  string
+	TaxRate   string
+	Amount    string
+}
+
+type totalRow struct {
+	Label  string
+	Amount string
+}
+
+func newDocumentView(c invoice.Content) documentView {
+	o := c.Order
+	v := documentView{
+		Number:       c.Document.Number,
+		IssuedAt:     c.Document.IssuedAt.Format(dateLayout),
+		OrderID:      o.ID,
+		OrderDate:    o.CreatedAt.Format(dateLayout),
+		Currency:     o.Total.Currency,
+		TaxInclusive: o.TaxInclusive,
+		Seller:       c.Seller,
+		Addresses:    addressRows(o.BillingAddress, o.ShippingAddress),
+	}
+
+	if c.Document.Type == invoice.TypeCreditNote {
+		v.Title = "CREDIT NOTE"
+		if c.Invoice != nil {
+			v.InvoiceNumber = c.Invoice.Number
+		}
+		v.Description = "Refund"
+		if c.Refund != nil && c.Refund.Reason != "" {
+			v.Description = "Refund: " + c.Refund.Reason
+		}
+		v.Credited = c.Document.Amount.String()
+		v.Totals = []totalRow{
+			{Label: "Of which tax", Amount: invoice.CreditedTax(o, c.Document.Amount).String()},
+			{Label: "Total credited", Amount: c.Document.Amount.String()},
+		}
+		return v
+	}
+
+	v.Title = "INVOICE"
+	for _, l := range o.Lines {
+		v.Lines = append(v.Lines, lineView{
+			Name:      l.Name,
+			SKU:       l.SKU,
+			Quantity:  l.Quantity,
+			UnitPrice: l.UnitPrice.String(),
+			Discount:  l.Discount.String(),
+			TaxRate:   l.TaxRate,
+			Amount:    l.LineTotal().String(),
+		})
+	}
+
+	v.Totals = append(v.Totals, totalRow{Label: "Subtotal", Amount: o.Subtotal.String()})
+	if !o.Discount.IsZero() {
+		v.Totals = append(v.Totals, totalRow{Label: "Discounts", Amount: "-" + o.Discount.String()})
+	}
+	if o.Shipping.Currency != "" {
+		label := "Shipping"
+		if o.ShippingMethod != "" {
+			label += " (" + o.ShippingMethod + ")"
+		}
+		v.Totals = append(v.Totals, totalRow{Label: label, Amount: o.Shipping.String()})
+	}
+	taxLabel := "Tax"
+	if o.TaxInclusive {
+		taxLabel = "Included tax"
+	}
+	v.Totals = append(v.Totals,
+		totalRow{Label: taxLabel, Amount: o.Tax.String()},
+		totalRow{Label: "Total", Amount: o.Total.String()},
+	)
+	return v
+}
+
+func addressRows(billing, shipping order.Address) []addressRow {
+	b, s := addressLines(billing), addressLines(shipping)
+	rows := make([]addressRow, max(len(b), len(s)))
+	for i := range rows {
+		if i < len(b) {
+			rows[i].Billing = b[i]
+		}
+		if i < len(s) {
+			rows[i].Shipping = s[i]
+		}
+	}
+	return rows
+}
+
+func addressLines(a order.Address) []string {
+	var lines []string
+	for _, line := range []string{
+		a.Name,
+		a.Line1,
+		a.Line2,
+		strings.TrimSpace(a.PostalCode + " " + a.City),
+		a.Region,
+		a.Country,
+	} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// left pads the value with spaces to the width, cutting it if it is longer
+// and leaving at least one space before the next column
+func left(width int, v interface{}) string {
+	s := fitWidth(fmt.Sprint(v), width-1)
+	return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+}
+
+// right aligns the value to the end of the width
+func right(width int, v interface{}) string {
+	s := fitWidth(fmt.Sprint(v), width-1)
+	return strings.Repeat(" ", width-utf8.RuneCountInString(s)) + s
+}
+
+func rule(width int) string {
+	return strings.Repeat("-", width)
+}
+
+func fitWidth(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:width])
+}
@@ -0,0 +1,179 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/address"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+type addressRepository struct {
+	db *gorm.DB
+}
+
+// NewAddressRepository creates a new GORM implementation of address.Repository
+func NewAddressRepository(db *gorm.DB) address.Repository {
+	return &addressRepository{db: db}
+}
+
+func (r *addressRepository) ListAddresses(userID string) ([]*address.Address, error) {
+	var models []*AddressModel
+	err := r.db.Where("user_id = ?", userID).
+		Order("default_shipping DESC").
+		Order("default_billing DESC").
+		Order("created_at").
+		Find(&models).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to list addresses in database. UserID: %s, Error: %v", userID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	addresses := make([]*address.Address, len(models))
+	for i, model := range models {
+		addresses[i] = toAddressBookDomain(model)
+	}
+	return addresses, nil
+}
+
+func (r *addressRepository) GetAddress(id string) (*address.Address, error) {
+	var model AddressModel
+	if err := r.db.First(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, address.ErrAddressNotFound
+		}
+		log.Printf("ERROR: Failed to read address in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toAddressBookDomain(&model), nil
+}
+
+func (r *addressRepository) CreateAddress(a *address.Address) error {
+	model := toAddressBookModel(a)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		return clearOtherDefaults(tx, model)
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to create address in database. UserID: %s, Error: %v", a.UserID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*a = *toAddressBookDomain(model)
+	return nil
+}
+
+func (r *addressRepository) UpdateAddress(a *address.Address) error {
+	model := toAddressBookModel(a)
+	var rows int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(model).
+			Select("name", "line1", "line2", "city", "region", "postal_code", "country", "phone",
+				"default_shipping", "default_billing").
+			Updates(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		rows = result.RowsAffected
+		return clearOtherDefaults(tx, model)
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to update address in database. ID: %s, Error: %v", a.ID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	if rows == 0 {
+		return address.ErrAddressNotFound
+	}
+
+	updated, err := r.GetAddress(a.ID)
+	if err != nil {
+		return err
+	}
+
+	*a = *updated
+	return nil
+}
+
+// clearOtherDefaults keeps a single default shipping and billing address per user
+func clearOtherDefaults(tx *gorm.DB, model *AddressModel) error {
+	if model.DefaultShipping {
+		err := tx.Model(&AddressModel{}).
+			Where("user_id = ? AND id <> ? AND default_shipping", model.UserID, model.ID).
+			Update("default_shipping", false).Error
+		if err != nil {
+			return err
+		}
+	}
+	if model.DefaultBilling {
+		err := tx.Model(&AddressModel{}).
+			Where("user_id = ? AND id <> ? AND default_billing", model.UserID, model.ID).
+			Update("default_billing", false).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *addressRepository) DeleteAddress(id string) error {
+	result := r.db.Delete(&AddressModel{}, "id = ?", id)
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to delete address in database. ID: %s, Error: %v", id, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return address.ErrAddressNotFound
+	}
+
+	return nil
+}
+
+// Mapping functions
+
+func toAddressBookModel(a *address.Address) *AddressModel {
+	return &AddressModel{
+		Base: Base{
+			ID:        a.ID,
+			CreatedAt: a.CreatedAt,
+			UpdatedAt: a.UpdatedAt,
+		},
+		UserID: a.UserID,
+		AddressColumns: AddressColumns{
+			Name:       a.Name,
+			Line1:      a.Line1,
+			Line2:      a.Line2,
+			City:       a.City,
+			Region:     a.Region,
+			PostalCode: a.PostalCode,
+			Country:    a.Country,
+			Phone:      a.Phone,
+		},
+		DefaultShipping: a.DefaultShipping,
+		DefaultBilling:  a.DefaultBilling,
+	}
+}
+
+func toAddressBookDomain(m *AddressModel) *address.Address {
+	return &address.Address{
+		ID:              m.ID,
+		UserID:          m.UserID,
+		Name:            m.Name,
+		Line1:           m.Line1,
+		Line2:           m.Line2,
+		City:            m.City,
+		Region:          m.Region,
+		PostalCode:      m.PostalCode,
+		Country:         m.Country,
+		Phone:           m.Phone,
+		DefaultShipping: m.DefaultShipping,
+		DefaultBilling:  m.DefaultBilling,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
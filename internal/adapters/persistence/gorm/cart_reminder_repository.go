@@ -0,0 +1,161 @@
+package gorm
+
+import (
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/cart"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+type cartReminderRepository struct {
+	db *gorm.DB
+}
+
+// NewCartReminderRepository creates a new GORM implementation of cart.ReminderRepository
+func NewCartReminderRepository(db *gorm.DB) cart.ReminderRepository {
+	return &cartReminderRepository{db: db}
+}
+
+// abandonedQuery finds customer carts with items that have been idle since
+// the cutoff, counting the reminders sent since each cart last changed
+const abandonedQuery = `
+SELECT carts.id AS cart_id, carts.user_id, users.email, COUNT(r.id) AS reminders
+FROM carts
+JOIN users ON users.id = carts.user_id AND users.deleted_at IS NULL
+LEFT JOIN cart_reminders r ON r.cart_id = carts.id AND r.sent_at > carts.updated_at
+WHERE carts.deleted_at IS NULL AND carts.updated_at < ?
+	AND EXISTS (SELECT 1 FROM cart_items ci WHERE ci.cart_id = carts.id)
+GROUP BY carts.id, users.email
+HAVING COUNT(r.id) < ? AND COALESCE(MAX(r.sent_at), carts.updated_at) < ?
+ORDER BY carts.updated_at
+LIMIT ?`
+
+func (r *cartReminderRepository) ListAbandoned(idleSince time.Time, maxReminders, limit int) ([]*cart.Abandoned, error) {
+	var abandoned []*cart.Abandoned
+	err := r.db.Raw(abandonedQuery, idleSince, maxReminders, idleSince, limit).Scan(&abandoned).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to list abandoned carts in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+	return abandoned, nil
+}
+
+func (r *cartReminderRepository) CreateReminder(reminder *cart.Reminder) error {
+	model := toCartReminderModel(reminder)
+	if err := r.db.Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to create cart reminder in database. CartID: %s, Error: %v", reminder.CartID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	reminder.ID = model.ID
+	return nil
+}
+
+func (r *cartReminderRepository) MarkRecovered(cartID, orderID string) error {
+	latest := r.db.Model(&CartReminderModel{}).
+		Select("id").
+		Where("cart_id = ? AND order_id IS NULL", cartID).
+		Order("sent_at DESC").
+		Limit(1)
+	err := r.db.Model(&CartReminderModel{}).
+		Where("id = (?)", latest).
+		Update("order_id", orderID).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to mark cart reminders recovered in database. CartID: %s, Error: %v", cartID, err)
+		return apperrors.ErrDatabaseError
+	}
+	return nil
+}
+
+// reportQuery sums, per period and currency, the carts whose first reminder
+// was sent in the period and the paid orders they were checked out into.
+// Reminders start over at sequence 1 whenever a cart changes, so a cart is
+// counted once per period, at the value of its last abandonment in it. An
+// order is linked to the last reminder before checkout and is credited to
+// the abandonment that reminder belongs to, so it is counted once.
+const reportQuery = `
+WITH abandoned AS (
+	SELECT DISTINCT ON (date_trunc(@interval, r.sent_at), r.cart_id)
+		date_trunc(@interval, r.sent_at) AS period, r.cart_id, r.currency, r.value
+	FROM cart_reminders r
+	WHERE r.sequence = 1 AND r.sent_at >= @from AND r.sent_at < @to
+	ORDER BY date_trunc(@interval, r.sent_at), r.cart_id, r.sent_at DESC
+), recovered AS (
+	SELECT DISTINCT ON (o.id) f.sent_at, f.cart_id, f.currency, o.total
+	FROM cart_reminders r
+	JOIN orders o ON o.id = r.order_id AND o.deleted_at IS NULL AND o.status IN @paid
+	JOIN cart_reminders f ON f.cart_id = r.cart_id AND f.sequence = 1 AND f.sent_at <= r.sent_at
+	WHERE r.sent_at >= @from
+	ORDER BY o.id, f.sent_at DESC
+)
+SELECT a.period, a.currency, a.carts, a.value,
+	COALESCE(rc.carts, 0) AS recovered_carts, COALESCE(rc.value, 0) AS recovered_value
+FROM (
+	SELECT period, currency, COUNT(*) AS carts, SUM(value) AS value
+	FROM abandoned
+	GROUP BY period, currency
+) a
+LEFT JOIN (
+	SELECT date_trunc(@interval, sent_at) AS period, currency,
+		COUNT(DISTINCT cart_id) AS carts, SUM(total) AS value
+	FROM recovered
+	WHERE sent_at >= @from AND sent_at < @to
+	GROUP BY 1, 2
+) rc ON rc.period = a.period AND rc.currency = a.currency
+ORDER BY a.period, a.currency`
+
+type reportRow struct {
+	Period         time.Time
+	Currency       string
+	Carts          int
+	Value          int64
+	RecoveredCarts int
+	RecoveredValue int64
+}
+
+func (r *cartReminderRepository) Report(filters cart.ReportFilters) ([]cart.ReportRow, error) {
+	var rows []reportRow
+	err := r.db.Raw(reportQuery, map[string]interface{}{
+		"interval": string(filters.Interval),
+		"paid":     order.PaidStatuses,
+		"from":     filters.From,
+		"to":       filters.To,
+	}).Scan(&rows).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to report abandoned carts in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	report := make([]cart.ReportRow, len(rows))
+	for i, row := range rows {
+		report[i] = cart.ReportRow{
+			Period:         row.Period,
+			Currency:       row.Currency,
+			Carts:          row.Carts,
+			Value:          money.New(row.Value, row.Currency),
+			RecoveredCarts: row.RecoveredCarts,
+			RecoveredValue: money.New(row.RecoveredValue, row.Currency),
+		}
+	}
+	return report, nil
+}
+
+// Mapping functions
+
+func toCartReminderModel(r *cart.Reminder) *CartReminderModel {
+	return &CartReminderModel{
+		ID:       r.ID,
+		CartID:   r.CartID,
+		UserID:   r.UserID,
+		Email:    r.Email,
+		Sequence: r.Sequence,
+		Value:    r.Value.Amount,
+		Currency: r.Value.Currency,
+		OrderID:  nullableID(r.OrderID),
+		SentAt:   r.SentAt,
+	}
+}
@@ -0,0 +1,259 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/cart"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+type cartRepository struct {
+	db *gorm.DB
+}
+
+// NewCartRepository creates a new GORM implementation of cart.Repository
+func NewCartRepository(db *gorm.DB) cart.Repository {
+	return &cartRepository{db: db}
+}
+
+func (r *cartRepository) GetCart(id string) (*cart.Cart, error) {
+	var model CartModel
+	err := r.db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		First(&model, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read cart in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toCartDomain(&model), nil
+}
+
+func (r *cartRepository) GetCartByUserID(userID string) (*cart.Cart, error) {
+	var model CartModel
+	err := r.db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		Where("user_id = ?", userID).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read cart in database. UserID: %s, Error: %v", userID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toCartDomain(&model), nil
+}
+
+func (r *cartRepository) CreateCart(c *cart.Cart) error {
+	model := toCartModel(c)
+	if err := r.db.Create(model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return apperrors.ErrDuplicateEntry
+		}
+		log.Printf("ERROR: Failed to create cart in database. UserID: %s, Error: %v", c.UserID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	c.ID = model.ID
+	c.CreatedAt = model.CreatedAt
+	c.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *cartRepository) SaveItem(item *cart.Item) error {
+	model := toCartItemModel(item)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(model).Error; err != nil {
+			return err
+		}
+		return touchCart(tx, item.CartID)
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to save cart item in database. CartID: %s, Error: %v", item.CartID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	item.ID = model.ID
+	item.CreatedAt = model.CreatedAt
+	item.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *cartRepository) RemoveItem(cartID, productID, variantID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("cart_id = ? AND product_id = ?", cartID, productID)
+		if variantID == "" {
+			query = query.Where("variant_id IS NULL")
+		} else {
+			query = query.Where("variant_id = ?", variantID)
+		}
+
+		result := query.Delete(&CartItemModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.ErrNotFound
+		}
+
+		return touchCart(tx, cartID)
+	})
+	if err != nil {
+		if err == apperrors.ErrNotFound {
+			return err
+		}
+		log.Printf("ERROR: Failed to remove cart item in database. CartID: %s, Error: %v", cartID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+func (r *cartRepository) ClearCart(cartID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cart_id = ?", cartID).Delete(&CartItemModel{}).Error; err != nil {
+			return err
+		}
+		return touchCart(tx, cartID)
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to clear cart in database. CartID: %s, Error: %v", cartID, err)
+		return apperrors.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *cartRepository) SetDiscountCode(cartID, code string) error {
+	result := r.db.Model(&CartModel{}).Where("id = ?", cartID).Update("discount_code", code)
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to set cart discount code in database. CartID: %s, Error: %v", cartID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *cartRepository) MergeItems(cartID string, items []cart.Item, discountCode, fromID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Deleting the merged cart first locks it, so a concurrent merge of
+		// the same cart waits and then finds it gone
+		if fromID != "" {
+			if err := tx.Where("cart_id = ?", fromID).Delete(&CartItemModel{}).Error; err != nil {
+				return err
+			}
+			result := tx.Delete(&CartModel{}, "id = ?", fromID)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return apperrors.ErrNotFound
+			}
+		}
+
+		for i := range items {
+			if err := tx.Save(toCartItemModel(&items[i])).Error; err != nil {
+				return err
+			}
+		}
+
+		if discountCode != "" {
+			err := tx.Model(&CartModel{}).
+				Where("id = ? AND discount_code = ''", cartID).
+				Update("discount_code", discountCode).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return touchCart(tx, cartID)
+	})
+	if err != nil {
+		if err == apperrors.ErrNotFound {
+			return err
+		}
+		log.Printf("ERROR: Failed to merge cart items in database. CartID: %s, Error: %v", cartID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+// touchCart records a change to the cart's items, which restarts the wait
+// before the cart counts as abandoned
+func touchCart(tx *gorm.DB, cartID string) error {
+	return tx.Model(&CartModel{}).Where("id = ?", cartID).Update("updated_at", time.Now()).Error
+}
+
+// Mapping functions
+
+func toCartModel(c *cart.Cart) *CartModel {
+	items := make([]CartItemModel, len(c.Items))
+	for i := range c.Items {
+		items[i] = *toCartItemModel(&c.Items[i])
+	}
+
+	return &CartModel{
+		Base: Base{
+			ID:        c.ID,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		},
+		UserID:       nullableID(c.UserID),
+		Currency:     c.Currency,
+		DiscountCode: c.DiscountCode,
+		Items:        items,
+	}
+}
+
+func toCartDomain(m *CartModel) *cart.Cart {
+	items := make([]cart.Item, len(m.Items))
+	for i := range m.Items {
+		items[i] = *toCartItemDomain(&m.Items[i])
+	}
+
+	return &cart.Cart{
+		ID:           m.ID,
+		UserID:       stringValue(m.UserID),
+		Currency:     m.Currency,
+		DiscountCode: m.DiscountCode,
+		Items:        items,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func toCartItemModel(item *cart.Item) *CartItemModel {
+	return &CartItemModel{
+		ID:        item.ID,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+		CartID:    item.CartID,
+		ProductID: item.ProductID,
+		VariantID: nullableID(item.VariantID),
+		Quantity:  item.Quantity,
+	}
+}
+
+func toCartItemDomain(m *CartItemModel) *cart.Item {
+	return &cart.Item{
+		ID:        m.ID,
+		CartID:    m.CartID,
+		ProductID: m.ProductID,
+		VariantID: stringValue(m.VariantID),
+		Quantity:  m.Quantity,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package gorm
 
 import (
+	"encoding/json"
 	"errors"
 	"log"
 
@@ -20,7 +21,7 @@ func NewCategoryRepository(db *gorm.DB) category.Repository {
 
 func (r *categoryRepository) ListCategories() ([]*category.Category, error) {
 	var models []*CategoryModel
-	if err := r.db.Find(&models).Error; err != nil {
+	if err := r.db.Order("name").Find(&models).Error; err != nil {
 		log.Printf("ERROR: Failed to read categories in database. Error: %v", err)
 		return nil, apperrors.ErrDatabaseError
 	}
@@ -32,6 +33,33 @@ func (r *categoryRepository) ListCategories() ([]*category.Category, error) {
 	return categories, nil
 }
 
+// categoryCountRow is a category with its number of directly assigned products
+type categoryCountRow struct {
+	CategoryModel
+	ProductCount int64
+}
+
+func (r *categoryRepository) ListCategoriesWithCounts() ([]*category.Category, error) {
+	var rows []*categoryCountRow
+	err := r.db.Model(&CategoryModel{}).
+		Select("categories.*, COUNT(products.id) AS product_count").
+		Joins("LEFT JOIN products ON products.category_id = categories.id AND products.disabled = false AND products.deleted_at IS NULL").
+		Group("categories.id").
+		Order("categories.name").
+		Scan(&rows).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to read category counts in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	categories := make([]*category.Category, len(rows))
+	for i, row := range rows {
+		categories[i] = toCategoryDomain(&row.CategoryModel)
+		categories[i].ProductCount = row.ProductCount
+	}
+	return categories, nil
+}
+
 func (r *categoryRepository) GetCategoryByID(id string) (*category.Category, error) {
 	var model CategoryModel
 	if err := r.db.Where("id = ?", id).First(&model).Error; err != nil {
@@ -44,11 +72,53 @@ func (r *categoryRepository) GetCategoryByID(id string) (*category.Category, err
 	return toCategoryDomain(&model), nil
 }
 
+func (r *categoryRepository) GetCategoryBySlug(slug string) (*category.Category, error) {
+	var model CategoryModel
+	if err := r.db.Where("slug = ?", slug).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read category in database. Slug: %s, Error: %v", slug, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+	return toCategoryDomain(&model), nil
+}
+
+// ancestorsQuery walks parent links upwards from a category. The level guard
+// stops the walk on rows that were linked into a cycle outside the service.
+const ancestorsQuery = `
+WITH RECURSIVE path AS (
+	SELECT categories.*, 1 AS level FROM categories WHERE id = ? AND deleted_at IS NULL
+	UNION ALL
+	SELECT c.*, path.level + 1 FROM categories c
+	JOIN path ON c.id = path.parent_id
+	WHERE c.deleted_at IS NULL AND path.level < ?
+)
+SELECT * FROM path ORDER BY level DESC`
+
+func (r *categoryRepository) Ancestors(id string) ([]*category.Category, error) {
+	var models []*CategoryModel
+	if err := r.db.Raw(ancestorsQuery, id, category.MaxDepth).Scan(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to read category ancestors in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	if len(models) == 0 {
+		return nil, apperrors.ErrNotFound
+	}
+
+	categories := make([]*category.Category, len(models))
+	for i, model := range models {
+		categories[i] = toCategoryDomain(model)
+	}
+	return categories, nil
+}
+
 func (r *categoryRepository) CreateCategory(c *category.Category) error {
 	model := toCategoryModel(c)
 	err := r.db.Create(model).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrDuplicatedKey) {
+		if isDuplicateKeyError(err) {
 			return apperrors.ErrDuplicateEntry
 		}
 		log.Printf("ERROR: Failed to create category in database. Name: %s, Error: %v", c.Name, err)
@@ -63,9 +133,10 @@ func (r *categoryRepository) CreateCategory(c *category.Category) error {
 func (r *categoryRepository) UpdateCategory(id string, c *category.Category) error {
 	c.ID = id
 	model := toCategoryModel(c)
-	result := r.db.Model(&CategoryModel{}).Where("id = ?", id).Updates(model)
+	// parent_id is selected explicitly so a category can be moved back to the root
+	result := r.db.Model(&CategoryModel{}).Where("id = ?", id).Select("parent_id", "name", "slug", "meta_title", "meta_description", "canonical_url").Updates(model)
 	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		if isDuplicateKeyError(result.Error) {
 			return apperrors.ErrDuplicateEntry
 		}
 		log.Printf("ERROR: Failed to update category in database. Name: %s, Error: %v", c.Name, result.Error)
@@ -79,13 +150,141 @@ func (r *categoryRepository) UpdateCategory(id string, c *category.Category) err
 	return nil
 }
 
-func (r *categoryRepository) DeleteCategory(id string) error {
-	result := r.db.Delete(&CategoryModel{}, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+func (r *categoryRepository) DeleteCategory(id, reassignTo string) (*category.DeleteResult, error) {
+	result := &category.DeleteResult{CategoryID: id, ReassignedTo: reassignTo}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the category so products cannot be moved into it while it is
+		// emptied, together with the target. Rows are locked in id order so
+		// deletions reassigning to each other cannot deadlock.
+		ids := []string{id}
+		if reassignTo != "" {
+			ids = append(ids, reassignTo)
+		}
+		var locked []CategoryModel
+		if err := tx.Clauses(lockForUpdate()).Where("id IN ?", ids).Order("id").Find(&locked).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+
+		var model, target *CategoryModel
+		for i := range locked {
+			switch locked[i].ID {
+			case id:
+				model = &locked[i]
+			case reassignTo:
+				target = &locked[i]
+			}
+		}
+		if model == nil {
 			return apperrors.ErrNotFound
 		}
-		log.Printf("ERROR: Failed to delete category in database. ID: %s, Error: %v", id, result.Error)
+
+		var productCount int64
+		if err := tx.Model(&ProductModel{}).Where("category_id = ?", id).Count(&productCount).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+
+		if productCount > 0 {
+			if reassignTo == "" {
+				return apperrors.ErrCategoryNotEmpty
+			}
+
+			if target == nil {
+				return apperrors.ErrInvalidReassignTarget
+			}
+
+			// Specifications are only validated against the schema of the
+			// product's category, so values of attributes the target does not
+			// have would be kept unchecked forever
+			var path []CategoryModel
+			if err := tx.Raw(ancestorsQuery, reassignTo, category.MaxDepth).Scan(&path).Error; err != nil {
+				return apperrors.ErrDatabaseError
+			}
+			pathIDs := make([]string, len(path))
+			for i := range path {
+				pathIDs[i] = path[i].ID
+			}
+			dropped := tx.
+				Where("product_id IN (?)", tx.Model(&ProductModel{}).Select("id").Where("category_id = ?", id)).
+				Where("attribute_id NOT IN (?)", tx.Model(&CategoryAttributeModel{}).Select("id").Where("category_id IN ?", pathIDs)).
+				Delete(&ProductSpecModel{})
+			if dropped.Error != nil {
+				return apperrors.ErrDatabaseError
+			}
+			result.SpecsDropped += dropped.RowsAffected
+
+			moved := tx.Model(&ProductModel{}).Where("category_id = ?", id).Update("category_id", reassignTo)
+			if moved.Error != nil {
+				return apperrors.ErrDatabaseError
+			}
+			result.ProductsReassigned = moved.RowsAffected
+		}
+
+		// Products of child categories inherited the category's attributes
+		dropped := tx.
+			Where("attribute_id IN (?)", tx.Model(&CategoryAttributeModel{}).Select("id").Where("category_id = ?", id)).
+			Delete(&ProductSpecModel{})
+		if dropped.Error != nil {
+			return apperrors.ErrDatabaseError
+		}
+		result.SpecsDropped += dropped.RowsAffected
+
+		reparented := tx.Model(&CategoryModel{}).Where("parent_id = ?", id).Update("parent_id", model.ParentID)
+		if reparented.Error != nil {
+			return apperrors.ErrDatabaseError
+		}
+		result.ChildrenReparented = reparented.RowsAffected
+
+		if err := tx.Delete(model).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to delete category in database. ID: %s, Error: %v", id, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *categoryRepository) ListAttributes(categoryIDs []string) ([]*category.Attribute, error) {
+	var models []*CategoryAttributeModel
+	if len(categoryIDs) == 0 {
+		return nil, nil
+	}
+
+	if err := r.db.Where("category_id IN ?", categoryIDs).Order("position, created_at").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to read category attributes in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	attributes := make([]*category.Attribute, len(models))
+	for i, model := range models {
+		attributes[i] = toAttributeDomain(model)
+	}
+	return attributes, nil
+}
+
+func (r *categoryRepository) CreateAttribute(a *category.Attribute) error {
+	model := toAttributeModel(a)
+	if err := r.db.Create(model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return apperrors.ErrDuplicateEntry
+		}
+		log.Printf("ERROR: Failed to create category attribute in database. Key: %s, Error: %v", a.Key, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*a = *toAttributeDomain(model)
+	return nil
+}
+
+func (r *categoryRepository) DeleteAttribute(categoryID, attributeID string) error {
+	// Product values go with the attribute through the cascading foreign key
+	result := r.db.Where("id = ? AND category_id = ?", attributeID, categoryID).Delete(&CategoryAttributeModel{})
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to delete category attribute in database. ID: %s, Error: %v", attributeID, result.Error)
 		return apperrors.ErrDatabaseError
 	}
 
@@ -105,15 +304,64 @@ func toCategoryModel(c *category.Category) *CategoryModel {
 			CreatedAt: c.CreatedAt,
 			UpdatedAt: c.UpdatedAt,
 		},
-		Name: c.Name,
+		ParentID: nullableID(c.ParentID),
+		Name:     c.Name,
+		Slug:     c.Slug,
+		SEO:      toSEOColumns(c.SEO),
 	}
 }
 
 func toCategoryDomain(m *CategoryModel) *category.Category {
 	return &category.Category{
 		ID:        m.ID,
+		ParentID:  stringValue(m.ParentID),
 		Name:      m.Name,
+		Slug:      m.Slug,
+		SEO:       toSEOMetadata(m.SEO),
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
 	}
 }
+
+func toAttributeModel(a *category.Attribute) *CategoryAttributeModel {
+	options := a.Options
+	if options == nil {
+		options = []string{}
+	}
+	encoded, _ := json.Marshal(options)
+
+	return &CategoryAttributeModel{
+		ID:         a.ID,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+		CategoryID: a.CategoryID,
+		Key:        a.Key,
+		Label:      a.Label,
+		Type:       string(a.Type),
+		Unit:       a.Unit,
+		Options:    string(encoded),
+		Required:   a.Required,
+		Position:   a.Position,
+	}
+}
+
+func toAttributeDomain(m *CategoryAttributeModel) *category.Attribute {
+	var options []string
+	if err := json.Unmarshal([]byte(m.Options), &options); err != nil {
+		log.Printf("ERROR: Invalid options on category attribute. ID: %s, Error: %v", m.ID, err)
+	}
+
+	return &category.Attribute{
+		ID:         m.ID,
+		CategoryID: m.CategoryID,
+		Key:        m.Key,
+		Label:      m.Label,
+		Type:       category.AttributeType(m.Type),
+		Unit:       m.Unit,
+		Options:    options,
+		Required:   m.Required,
+		Position:   m.Position,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
@@ -0,0 +1,97 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+	"math/big"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/pricing"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type exchangeRateRepository struct {
+	db *gorm.DB
+}
+
+// NewExchangeRateRepository creates a database-backed pricing.RateRepository
+func NewExchangeRateRepository(db *gorm.DB) pricing.RateRepository {
+	return &exchangeRateRepository{db: db}
+}
+
+func (r *exchangeRateRepository) GetRate(base, quote string) (*pricing.ExchangeRate, error) {
+	var model ExchangeRateModel
+	err := r.db.
+		Where("base_currency = ? AND quote_currency = ?", base, quote).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, pricing.ErrRateNotFound
+		}
+		log.Printf("ERROR: Failed to read exchange rate in database. Pair: %s/%s, Error: %v", base, quote, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toExchangeRateDomain(&model)
+}
+
+// SetRate creates or replaces the rate for the currency pair
+func (r *exchangeRateRepository) SetRate(rate *pricing.ExchangeRate) error {
+	model := toExchangeRateModel(rate)
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to set exchange rate in database. Pair: %s/%s, Error: %v", rate.Base, rate.Quote, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	rate.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *exchangeRateRepository) ListRates() ([]*pricing.ExchangeRate, error) {
+	var models []ExchangeRateModel
+	if err := r.db.Order("base_currency").Order("quote_currency").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list exchange rates in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	rates := make([]*pricing.ExchangeRate, 0, len(models))
+	for i := range models {
+		rate, err := toExchangeRateDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+// Mapping functions
+
+func toExchangeRateModel(rate *pricing.ExchangeRate) *ExchangeRateModel {
+	return &ExchangeRateModel{
+		BaseCurrency:  rate.Base,
+		QuoteCurrency: rate.Quote,
+		Rate:          rate.Rate.FloatString(10),
+	}
+}
+
+func toExchangeRateDomain(m *ExchangeRateModel) (*pricing.ExchangeRate, error) {
+	rate, ok := new(big.Rat).SetString(m.Rate)
+	if !ok {
+		log.Printf("ERROR: Invalid exchange rate stored in database. Pair: %s/%s, Rate: %s", m.BaseCurrency, m.QuoteCurrency, m.Rate)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return &pricing.ExchangeRate{
+		Base:      m.BaseCurrency,
+		Quote:     m.QuoteCurrency,
+		Rate:      rate,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,532 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/giftcard"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type giftCardRepository struct {
+	db *gorm.DB
+}
+
+// NewGiftCardRepository creates a new GORM implementation of giftcard.Repository
+func NewGiftCardRepository(db *gorm.DB) giftcard.Repository {
+	return &giftCardRepository{db: db}
+}
+
+func (r *giftCardRepository) CreateGiftCard(card *giftcard.GiftCard, actorID string) error {
+	model := toGiftCardModel(card)
+	model.Balance = model.Initial
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&BalanceEntryModel{
+			GiftCardID:   &model.ID,
+			Type:         string(giftcard.EntryIssue),
+			Amount:       model.Initial,
+			BalanceAfter: model.Balance,
+			Currency:     model.Currency,
+			OrderID:      model.OrderID,
+			ActorID:      nullableID(actorID),
+		}).Error
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return apperrors.ErrDuplicateEntry
+		}
+		log.Printf("ERROR: Failed to create gift card in database. OrderID: %s, Error: %v", card.OrderID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*card = *toGiftCardDomain(model)
+	return nil
+}
+
+func (r *giftCardRepository) GetGiftCard(id string) (*giftcard.GiftCard, error) {
+	return r.getGiftCard("id = ?", id)
+}
+
+func (r *giftCardRepository) GetGiftCardByCode(code string) (*giftcard.GiftCard, error) {
+	return r.getGiftCard("code = ?", code)
+}
+
+func (r *giftCardRepository) getGiftCard(query string, arg string) (*giftcard.GiftCard, error) {
+	var model GiftCardModel
+	if err := r.db.First(&model, query, arg).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read gift card in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toGiftCardDomain(&model), nil
+}
+
+func (r *giftCardRepository) ListOrderGiftCards(orderID string) ([]*giftcard.GiftCard, error) {
+	var models []*GiftCardModel
+	if err := r.db.Where("order_id = ?", orderID).Order("created_at").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list order gift cards in database. OrderID: %s, Error: %v", orderID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	cards := make([]*giftcard.GiftCard, len(models))
+	for i, model := range models {
+		cards[i] = toGiftCardDomain(model)
+	}
+	return cards, nil
+}
+
+func (r *giftCardRepository) ListStoreCredit(userID string) ([]*giftcard.StoreCredit, error) {
+	var models []*StoreCreditModel
+	if err := r.db.Where("user_id = ?", userID).Order("currency").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list store credit in database. UserID: %s, Error: %v", userID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	credits := make([]*giftcard.StoreCredit, len(models))
+	for i, model := range models {
+		credits[i] = toStoreCreditDomain(model)
+	}
+	return credits, nil
+}
+
+func (r *giftCardRepository) ListEntries(filters giftcard.EntryFilters) ([]*giftcard.Entry, error) {
+	query := r.db.Model(&BalanceEntryModel{})
+	if filters.GiftCardID != "" {
+		query = query.Where("gift_card_id = ?", filters.GiftCardID)
+	}
+	if filters.UserID != "" {
+		query = query.Where("store_credit_id IN (?)",
+			r.db.Model(&StoreCreditModel{}).Select("id").Where("user_id = ?", filters.UserID))
+	}
+
+	var models []*BalanceEntryModel
+	if err := query.Order("created_at DESC, id DESC").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list balance entries in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	entries := make([]*giftcard.Entry, len(models))
+	for i, model := range models {
+		entries[i] = toBalanceEntryDomain(model)
+	}
+	return entries, nil
+}
+
+func (r *giftCardRepository) Redeem(orderID string, giftCardIDs []string, userID string, amount money.Money) (money.Money, error) {
+	remaining := amount.Amount
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Orders are locked before balances, here and in Release, so the two
+		// cannot deadlock
+		if err := lockOrder(tx, orderID); err != nil {
+			return err
+		}
+
+		if len(giftCardIDs) > 0 {
+			var cards []*GiftCardModel
+			err := tx.Clauses(lockForUpdate()).
+				Where("id IN ? AND currency = ?", giftCardIDs, amount.Currency).
+				Order("id").
+				Find(&cards).Error
+			if err != nil {
+				return err
+			}
+
+			byID := make(map[string]*GiftCardModel, len(cards))
+			for _, card := range cards {
+				byID[card.ID] = card
+			}
+			for _, id := range giftCardIDs {
+				card, ok := byID[id]
+				if !ok || remaining == 0 {
+					continue
+				}
+				take := min(card.Balance, remaining)
+				if err := spend(tx, &GiftCardModel{}, card.ID, "gift_card_id", take, card.Currency, orderID); err != nil {
+					return err
+				}
+				remaining -= take
+			}
+		}
+
+		if userID != "" && remaining > 0 {
+			var credit StoreCreditModel
+			err := tx.Clauses(lockForUpdate()).
+				First(&credit, "user_id = ? AND currency = ?", userID, amount.Currency).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err == nil {
+				take := min(credit.Balance, remaining)
+				if err := spend(tx, &StoreCreditModel{}, credit.ID, "store_credit_id", take, credit.Currency, orderID); err != nil {
+					return err
+				}
+				remaining -= take
+			}
+		}
+
+		redeemed := amount.Amount - remaining
+		if redeemed == 0 {
+			return nil
+		}
+		return tx.Model(&OrderModel{}).Where("id = ?", orderID).
+			Update("redeemed", gorm.Expr("redeemed + ?", redeemed)).Error
+	})
+	if err != nil {
+		if err == giftcard.ErrEmpty {
+			return money.Money{}, err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return money.Money{}, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to redeem balances in database. OrderID: %s, Error: %v", orderID, err)
+		return money.Money{}, apperrors.ErrDatabaseError
+	}
+
+	return money.New(amount.Amount-remaining, amount.Currency), nil
+}
+
+// spend takes an amount off a locked balance row and records the redemption
+// in the ledger
+func spend(tx *gorm.DB, model interface{}, id, column string, amount int64, currency, orderID string) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	balance, err := adjustBalance(tx, model, id, -amount)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(ledgerEntry(column, id, giftcard.EntryRedeem, -amount, balance, currency, &orderID, nil, nil)).Error
+}
+
+// adjustBalance adds delta to a gift card or store credit balance and
+// returns the new balance. The guard keeps the balance from going negative
+// even if the row was not locked.
+func adjustBalance(tx *gorm.DB, model interface{}, id string, delta int64) (int64, error) {
+	result := tx.Model(model).
+		Where("id = ? AND balance + ? >= 0", id, delta).
+		Update("balance", gorm.Expr("balance + ?", delta))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, giftcard.ErrEmpty
+	}
+
+	var balance int64
+	if err := tx.Model(model).Where("id = ?", id).Select("balance").Scan(&balance).Error; err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+// releaseQuery sums, per balance, what the order spent and has not been given back
+const releaseQuery = `
+SELECT gift_card_id, store_credit_id, currency, -SUM(amount) AS amount
+FROM balance_entries
+WHERE order_id = ? AND type IN ?
+GROUP BY gift_card_id, store_credit_id, currency
+HAVING SUM(amount) < 0`
+
+type releaseRow struct {
+	GiftCardID    *string
+	StoreCreditID *string
+	Currency      string
+	Amount        int64
+}
+
+func (r *giftCardRepository) Release(orderID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockOrder(tx, orderID); err != nil {
+			return err
+		}
+
+		var rows []releaseRow
+		types := []string{string(giftcard.EntryRedeem), string(giftcard.EntryRelease)}
+		if err := tx.Raw(releaseQuery, orderID, types).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			var model interface{} = &GiftCardModel{}
+			column, id := "gift_card_id", stringValue(row.GiftCardID)
+			if row.StoreCreditID != nil {
+				model = &StoreCreditModel{}
+				column, id = "store_credit_id", *row.StoreCreditID
+			}
+
+			balance, err := adjustBalance(tx, model, id, row.Amount)
+			if err != nil {
+				return err
+			}
+
+			entry := ledgerEntry(column, id, giftcard.EntryRelease, row.Amount, balance, row.Currency, &orderID, nil, nil)
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&OrderModel{}).Where("id = ?", orderID).Update("redeemed", 0).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to release balances in database. OrderID: %s, Error: %v", orderID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+func (r *giftCardRepository) Void(orderID string, giftCardIDs []string, refundID, actorID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Locking the order first, as Redeem and Release do
+		if err := lockOrder(tx, orderID); err != nil {
+			return err
+		}
+
+		var cards []*GiftCardModel
+		err := tx.Clauses(lockForUpdate()).
+			Where("id IN ? AND order_id = ?", giftCardIDs, orderID).
+			Order("id").
+			Find(&cards).Error
+		if err != nil {
+			return err
+		}
+		if len(cards) != len(giftCardIDs) {
+			return gorm.ErrRecordNotFound
+		}
+
+		now := time.Now()
+		for _, card := range cards {
+			if card.VoidedAt != nil || card.Balance != card.Initial {
+				return giftcard.ErrNotRefundable
+			}
+
+			balance, err := adjustBalance(tx, &GiftCardModel{}, card.ID, -card.Balance)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&GiftCardModel{}).Where("id = ?", card.ID).Update("voided_at", now).Error; err != nil {
+				return err
+			}
+
+			entry := ledgerEntry("gift_card_id", card.ID, giftcard.EntryVoid, -card.Balance, balance, card.Currency,
+				&orderID, &refundID, nullableID(actorID))
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if err == giftcard.ErrNotRefundable {
+			return err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to void gift cards in database. OrderID: %s, RefundID: %s, Error: %v", orderID, refundID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+func (r *giftCardRepository) Restore(refundID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var voids []*BalanceEntryModel
+		err := tx.Where("refund_id = ? AND type = ?", refundID, string(giftcard.EntryVoid)).
+			Order("gift_card_id").
+			Find(&voids).Error
+		if err != nil {
+			return err
+		}
+
+		for _, void := range voids {
+			id := stringValue(void.GiftCardID)
+
+			// The lock on the card serialises restores, so a card already
+			// restored for the refund is seen here
+			var card GiftCardModel
+			if err := tx.Clauses(lockForUpdate()).First(&card, "id = ?", id).Error; err != nil {
+				return err
+			}
+			var count int64
+			err := tx.Model(&BalanceEntryModel{}).
+				Where("refund_id = ? AND gift_card_id = ? AND type = ?", refundID, id, string(giftcard.EntryRestore)).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+
+			balance, err := adjustBalance(tx, &GiftCardModel{}, id, -void.Amount)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&GiftCardModel{}).Where("id = ?", id).Update("voided_at", nil).Error; err != nil {
+				return err
+			}
+
+			entry := ledgerEntry("gift_card_id", id, giftcard.EntryRestore, -void.Amount, balance, void.Currency,
+				void.OrderID, &refundID, nil)
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to restore voided gift cards in database. RefundID: %s, Error: %v", refundID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+func (r *giftCardRepository) Credit(userID string, amount money.Money, refundID, actorID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// The first credit in a currency opens the balance
+		opened := &StoreCreditModel{UserID: userID, Currency: amount.Currency}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(opened).Error; err != nil {
+			return err
+		}
+
+		var credit StoreCreditModel
+		if err := tx.Clauses(lockForUpdate()).First(&credit, "user_id = ? AND currency = ?", userID, amount.Currency).Error; err != nil {
+			return err
+		}
+
+		// The lock on the balance serialises credits, so the refund is seen
+		// here if it was already credited
+		var count int64
+		err := tx.Model(&BalanceEntryModel{}).
+			Where("refund_id = ? AND store_credit_id IS NOT NULL", refundID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		balance, err := adjustBalance(tx, &StoreCreditModel{}, credit.ID, amount.Amount)
+		if err != nil {
+			return err
+		}
+
+		entry := ledgerEntry("store_credit_id", credit.ID, giftcard.EntryRefund, amount.Amount, balance, amount.Currency,
+			nil, &refundID, nullableID(actorID))
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to credit store credit in database. UserID: %s, RefundID: %s, Error: %v", userID, refundID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+// lockOrder locks the order row for the rest of the transaction
+func lockOrder(tx *gorm.DB, orderID string) error {
+	var o OrderModel
+	return tx.Select("id").Clauses(lockForUpdate()).First(&o, "id = ?", orderID).Error
+}
+
+// ledgerEntry builds the entry of a change to the gift card or store credit
+// with the ID, as named by column
+func ledgerEntry(column, id string, entryType giftcard.EntryType, amount, balance int64, currency string, orderID, refundID, actorID *string) *BalanceEntryModel {
+	entry := &BalanceEntryModel{
+		Type:         string(entryType),
+		Amount:       amount,
+		BalanceAfter: balance,
+		Currency:     currency,
+		OrderID:      orderID,
+		RefundID:     refundID,
+		ActorID:      actorID,
+	}
+	if column == "store_credit_id" {
+		entry.StoreCreditID = &id
+	} else {
+		entry.GiftCardID = &id
+	}
+	return entry
+}
+
+// Mapping functions
+
+func toGiftCardModel(c *giftcard.GiftCard) *GiftCardModel {
+	return &GiftCardModel{
+		Base: Base{
+			ID:        c.ID,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		},
+		Code:        c.Code,
+		Initial:     c.Initial.Amount,
+		Balance:     c.Balance.Amount,
+		Currency:    c.Initial.Currency,
+		OrderID:     nullableID(c.OrderID),
+		OrderLineID: nullableID(c.OrderLineID),
+		Email:       c.Email,
+		VoidedAt:    c.VoidedAt,
+	}
+}
+
+func toGiftCardDomain(m *GiftCardModel) *giftcard.GiftCard {
+	return &giftcard.GiftCard{
+		ID:          m.ID,
+		Code:        m.Code,
+		Initial:     money.New(m.Initial, m.Currency),
+		Balance:     money.New(m.Balance, m.Currency),
+		OrderID:     stringValue(m.OrderID),
+		OrderLineID: stringValue(m.OrderLineID),
+		Email:       m.Email,
+		VoidedAt:    m.VoidedAt,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func toStoreCreditDomain(m *StoreCreditModel) *giftcard.StoreCredit {
+	return &giftcard.StoreCredit{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Balance:   money.New(m.Balance, m.Currency),
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func toBalanceEntryDomain(m *BalanceEntryModel) *giftcard.Entry {
+	return &giftcard.Entry{
+		ID:            m.ID,
+		GiftCardID:    stringValue(m.GiftCardID),
+		StoreCreditID: stringValue(m.StoreCreditID),
+		Type:          giftcard.EntryType(m.Type),
+		Amount:        money.New(m.Amount, m.Currency),
+		Balance:       money.New(m.BalanceAfter, m.Currency),
+		OrderID:       stringValue(m.OrderID),
+		RefundID:      stringValue(m.RefundID),
+		ActorID:       stringValue(m.ActorID),
+		CreatedAt:     m.CreatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package gorm
+
+import (
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
+	"gorm.io/gorm/clause"
+)
+
+// nullableID maps an empty domain ID to a NULL column value
+func nullableID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+// stringValue maps a nullable column value to its domain representation
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// lockForUpdate returns a SELECT ... FOR UPDATE row lock clause
+func lockForUpdate() clause.Locking {
+	return clause.Locking{Strength: "UPDATE"}
+}
+
+func toSEOColumns(m seo.Metadata) SEOColumns {
+	return SEOColumns{
+		MetaTitle:       m.MetaTitle,
+		MetaDescription: m.MetaDescription,
+		CanonicalURL:    m.CanonicalURL,
+	}
+}
+
+func toSEOMetadata(c SEOColumns) seo.Metadata {
+	return seo.Metadata{
+		MetaTitle:       c.MetaTitle,
+		MetaDescription: c.MetaDescription,
+		CanonicalURL:    c.CanonicalURL,
+	}
+}
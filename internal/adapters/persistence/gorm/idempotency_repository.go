@@ -0,0 +1,140 @@
+package gorm
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/idempotency"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository creates a new GORM implementation of idempotency.Store
+func NewIdempotencyRepository(db *gorm.DB) idempotency.Store {
+	return &idempotencyRepository{db: db}
+}
+
+func (r *idempotencyRepository) Begin(record *idempotency.Record) (*idempotency.Record, bool, error) {
+	model := toIdempotencyModel(record)
+
+	var existing *idempotency.Record
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil
+		}
+
+		// The key is taken; claim it only when the holder has expired
+		var held IdempotencyKeyModel
+		if err := tx.Clauses(lockForUpdate()).First(&held, "key = ?", record.Key).Error; err != nil {
+			return err
+		}
+		if held.ExpiresAt.After(time.Now()) {
+			existing = toIdempotencyDomain(&held)
+			return nil
+		}
+
+		return tx.Model(&IdempotencyKeyModel{}).Where("key = ?", record.Key).
+			Select("fingerprint", "status", "header", "body", "expires_at", "created_at").
+			Updates(model).Error
+	})
+	if err != nil {
+		// The holder released the key between the insert and the read; the
+		// client can retry
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, apperrors.ErrIdempotencyKeyInFlight
+		}
+		log.Printf("ERROR: Failed to claim idempotency key in database. Error: %v", err)
+		return nil, false, apperrors.ErrDatabaseError
+	}
+
+	if existing != nil {
+		return existing, false, nil
+	}
+	return nil, true, nil
+}
+
+func (r *idempotencyRepository) Extend(key string, expiresAt time.Time) error {
+	err := r.db.Model(&IdempotencyKeyModel{}).Where("key = ? AND status = 0", key).
+		Update("expires_at", expiresAt).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to extend idempotency key in database. Error: %v", err)
+		return apperrors.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) Complete(record *idempotency.Record) error {
+	model := toIdempotencyModel(record)
+	err := r.db.Model(&IdempotencyKeyModel{}).Where("key = ?", record.Key).
+		Select("status", "header", "body", "expires_at").
+		Updates(model).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to store idempotent response in database. Error: %v", err)
+		return apperrors.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) Release(key string) error {
+	if err := r.db.Where("key = ? AND status = 0", key).Delete(&IdempotencyKeyModel{}).Error; err != nil {
+		log.Printf("ERROR: Failed to release idempotency key in database. Error: %v", err)
+		return apperrors.ErrDatabaseError
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpired(before time.Time) error {
+	if err := r.db.Where("expires_at < ?", before).Delete(&IdempotencyKeyModel{}).Error; err != nil {
+		log.Printf("ERROR: Failed to delete expired idempotency keys in database. Error: %v", err)
+		return apperrors.ErrDatabaseError
+	}
+	return nil
+}
+
+// Mapping functions
+
+func toIdempotencyModel(r *idempotency.Record) *IdempotencyKeyModel {
+	header := r.Header
+	if header == nil {
+		header = map[string][]string{}
+	}
+	encoded, _ := json.Marshal(header)
+
+	return &IdempotencyKeyModel{
+		Key:         r.Key,
+		Fingerprint: r.Fingerprint,
+		Status:      r.Status,
+		Header:      string(encoded),
+		Body:        r.Body,
+		ExpiresAt:   r.ExpiresAt,
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+func toIdempotencyDomain(m *IdempotencyKeyModel) *idempotency.Record {
+	var header map[string][]string
+	if err := json.Unmarshal([]byte(m.Header), &header); err != nil {
+		log.Printf("ERROR: Invalid headers on idempotency key. Error: %v", err)
+	}
+
+	return &idempotency.Record{
+		Key:         m.Key,
+		Fingerprint: m.Fingerprint,
+		Status:      m.Status,
+		Header:      header,
+		Body:        m.Body,
+		ExpiresAt:   m.ExpiresAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
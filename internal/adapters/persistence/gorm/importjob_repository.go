@@ -0,0 +1,167 @@
+package gorm
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/importjob"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+// NewImportJobRepository creates a new GORM implementation of importjob.Repository
+func NewImportJobRepository(db *gorm.DB) importjob.Repository {
+	return &importJobRepository{db: db}
+}
+
+func (r *importJobRepository) CreateJob(job *importjob.Job, payload []byte) error {
+	model := toImportJobModel(job)
+	model.Payload = payload
+	if err := r.db.Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to create import job in database. Error: %v", err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*job = *toImportJobDomain(model)
+	return nil
+}
+
+func (r *importJobRepository) GetJob(id string) (*importjob.Job, error) {
+	var model ImportJobModel
+	// The payload can be large and is never returned
+	if err := r.db.Omit("payload").First(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read import job in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toImportJobDomain(&model), nil
+}
+
+func (r *importJobRepository) ClaimNext(staleBefore time.Time) (*importjob.Job, []byte, error) {
+	var model ImportJobModel
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets several workers claim different jobs concurrently
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", string(importjob.StatusPending), string(importjob.StatusRunning), staleBefore).
+			Order("created_at").
+			First(&model).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrNotFound
+			}
+			return apperrors.ErrDatabaseError
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{"status": string(importjob.StatusRunning)}
+		if model.StartedAt == nil {
+			updates["started_at"] = now
+			model.StartedAt = &now
+		}
+		if err := tx.Model(&model).Updates(updates).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+		model.Status = string(importjob.StatusRunning)
+		return nil
+	})
+	if err != nil {
+		if err != apperrors.ErrNotFound {
+			log.Printf("ERROR: Failed to claim import job in database. Error: %v", err)
+		}
+		return nil, nil, err
+	}
+
+	return toImportJobDomain(&model), model.Payload, nil
+}
+
+func (r *importJobRepository) SaveProgress(job *importjob.Job) error {
+	model := toImportJobModel(job)
+	columns := []string{
+		"status", "total_rows", "processed_rows", "created", "updated", "failed",
+		"errors", "message", "started_at", "finished_at",
+	}
+	if job.Finished() {
+		columns = append(columns, "payload")
+	}
+
+	// Saving also refreshes updated_at, the heartbeat ClaimNext uses to spot stalled jobs
+	result := r.db.Model(&ImportJobModel{}).Where("id = ?", job.ID).Select(columns).Updates(model)
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to save import job progress in database. ID: %s, Error: %v", job.ID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Mapping functions
+
+func toImportJobModel(j *importjob.Job) *ImportJobModel {
+	rowErrors := j.Errors
+	if rowErrors == nil {
+		rowErrors = []importjob.RowError{}
+	}
+	encoded, _ := json.Marshal(rowErrors)
+
+	return &ImportJobModel{
+		Base: Base{
+			ID:        j.ID,
+			CreatedAt: j.CreatedAt,
+			UpdatedAt: j.UpdatedAt,
+		},
+		Format:        string(j.Format),
+		DryRun:        j.DryRun,
+		Status:        string(j.Status),
+		TotalRows:     j.TotalRows,
+		ProcessedRows: j.ProcessedRows,
+		Created:       j.Created,
+		Updated:       j.Updated,
+		Failed:        j.Failed,
+		Errors:        string(encoded),
+		Message:       j.Message,
+		CreatedBy:     nullableID(j.CreatedBy),
+		StartedAt:     j.StartedAt,
+		FinishedAt:    j.FinishedAt,
+	}
+}
+
+func toImportJobDomain(m *ImportJobModel) *importjob.Job {
+	var rowErrors []importjob.RowError
+	if err := json.Unmarshal([]byte(m.Errors), &rowErrors); err != nil {
+		log.Printf("ERROR: Invalid row errors on import job. ID: %s, Error: %v", m.ID, err)
+	}
+
+	return &importjob.Job{
+		ID:            m.ID,
+		Format:        importjob.Format(m.Format),
+		DryRun:        m.DryRun,
+		Status:        importjob.Status(m.Status),
+		TotalRows:     m.TotalRows,
+		ProcessedRows: m.ProcessedRows,
+		Created:       m.Created,
+		Updated:       m.Updated,
+		Failed:        m.Failed,
+		Errors:        rowErrors,
+		Message:       m.Message,
+		CreatedBy:     stringValue(m.CreatedBy),
+		StartedAt:     m.StartedAt,
+		FinishedAt:    m.FinishedAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
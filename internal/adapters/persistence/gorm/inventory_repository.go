@@ -0,0 +1,250 @@
+package gorm
+
+import (
+	"log"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/inventory"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+type inventoryRepository struct {
+	db *gorm.DB
+}
+
+// NewInventoryRepository creates a new GORM implementation of inventory.Repository
+func NewInventoryRepository(db *gorm.DB) inventory.Repository {
+	return &inventoryRepository{db: db}
+}
+
+func (r *inventoryRepository) RecordMovement(m *inventory.Movement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return recordMovement(tx, m)
+	})
+}
+
+// recordMovement applies a movement inside an existing transaction so other
+// repositories (checkout, returns) can combine it with their own writes
+func recordMovement(tx *gorm.DB, m *inventory.Movement) error {
+	// Guarded increment: the row is only updated if stock stays non-negative,
+	// and decrements must also leave the stock held by pending checkouts, or
+	// converting their reservations would fail after the customer has paid
+	query := stockTarget(tx, m.ProductID, m.VariantID)
+	if m.Quantity < 0 {
+		query = query.Where("stock + ? >= 0 AND stock + ? >= reserved", m.Quantity, m.Quantity)
+	} else {
+		query = query.Where("stock + ? >= 0", m.Quantity)
+	}
+	result := query.Update("stock", gorm.Expr("stock + ?", m.Quantity))
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to apply stock movement in database. ProductID: %s, Error: %v", m.ProductID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		var levels []struct {
+			Stock    int
+			Reserved int
+		}
+		if err := stockTarget(tx, m.ProductID, m.VariantID).Select("stock", "reserved").Scan(&levels).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+		if len(levels) == 0 {
+			return apperrors.ErrNotFound
+		}
+		if levels[0].Stock+m.Quantity < 0 {
+			return apperrors.ErrInsufficientStock
+		}
+		return apperrors.ErrStockReserved
+	}
+
+	var stock int
+	if err := stockTarget(tx, m.ProductID, m.VariantID).Select("stock").Scan(&stock).Error; err != nil {
+		return apperrors.ErrDatabaseError
+	}
+	m.StockAfter = stock
+
+	model := toStockMovementModel(m)
+	if err := tx.Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to record stock movement in database. ProductID: %s, Error: %v", m.ProductID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	m.ID = model.ID
+	m.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *inventoryRepository) ListMovements(params pagination.Params, filters inventory.Filters) ([]*inventory.Movement, int64, error) {
+	var models []*StockMovementModel
+	var totalCount int64
+
+	query := r.db.Model(&StockMovementModel{})
+	if filters.ProductID != "" {
+		query = query.Where("product_id = ?", filters.ProductID)
+	}
+	if filters.VariantID != "" {
+		query = query.Where("variant_id = ?", filters.VariantID)
+	}
+	if filters.Type != "" {
+		query = query.Where("type = ?", filters.Type)
+	}
+
+	if params.NeedsTotal() {
+		if err := query.Count(&totalCount).Error; err != nil {
+			return nil, 0, apperrors.ErrDatabaseError
+		}
+	}
+
+	if err := paginate(query, params, "stock_movements").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to read stock movements in database. Error: %v", err)
+		return nil, 0, apperrors.ErrDatabaseError
+	}
+
+	movements := make([]*inventory.Movement, len(models))
+	for i, model := range models {
+		movements[i] = toStockMovementDomain(model)
+	}
+
+	return movements, totalCount, nil
+}
+
+func (r *inventoryRepository) Reconcile(productID, variantID string) (*inventory.ReconcileResult, error) {
+	result := &inventory.ReconcileResult{ProductID: productID, VariantID: variantID}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the stock row so concurrent movements cannot interleave
+		var before []int
+		if err := stockTarget(tx, productID, variantID).
+			Clauses(lockForUpdate()).
+			Pluck("stock", &before).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+		if len(before) == 0 {
+			return apperrors.ErrNotFound
+		}
+		result.Before = before[0]
+
+		ledger := tx.Model(&StockMovementModel{}).
+			Where("product_id = ? AND type NOT IN ?", productID, inventory.HoldTypes)
+		if variantID == "" {
+			ledger = ledger.Where("variant_id IS NULL")
+		} else {
+			ledger = ledger.Where("variant_id = ?", variantID)
+		}
+
+		var sum int
+		if err := ledger.Select("COALESCE(SUM(quantity), 0)").Scan(&sum).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+		result.After = sum
+
+		if err := stockTarget(tx, productID, variantID).Update("stock", sum).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to reconcile stock in database. ProductID: %s, Error: %v", productID, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *inventoryRepository) SetLowStockThreshold(productID, variantID string, threshold *int) error {
+	result := stockTarget(r.db, productID, variantID).Update("low_stock_threshold", threshold)
+	if result.Error != nil {
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *inventoryRepository) GetLowStockThreshold(productID, variantID string) (*int, error) {
+	var thresholds []*int
+	if err := stockTarget(r.db, productID, variantID).Pluck("low_stock_threshold", &thresholds).Error; err != nil {
+		return nil, apperrors.ErrDatabaseError
+	}
+	if len(thresholds) == 0 {
+		return nil, apperrors.ErrNotFound
+	}
+
+	return thresholds[0], nil
+}
+
+func (r *inventoryRepository) ListLowStock(defaultThreshold int) ([]*inventory.LowStockItem, error) {
+	var items []*inventory.LowStockItem
+
+	// Products sold without variants
+	err := r.db.Model(&ProductModel{}).
+		Select("products.id AS product_id, products.name, products.stock, COALESCE(products.low_stock_threshold, ?) AS threshold", defaultThreshold).
+		Where("NOT EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = products.id AND pv.deleted_at IS NULL)").
+		Where("products.stock <= COALESCE(products.low_stock_threshold, ?)", defaultThreshold).
+		Order("products.stock").
+		Scan(&items).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to read low stock products in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	var variants []*inventory.LowStockItem
+	err = r.db.Model(&ProductVariantModel{}).
+		Joins("JOIN products ON products.id = product_variants.product_id AND products.deleted_at IS NULL").
+		Select("product_variants.product_id, product_variants.id AS variant_id, products.name, product_variants.sku, product_variants.stock, COALESCE(product_variants.low_stock_threshold, products.low_stock_threshold, ?) AS threshold", defaultThreshold).
+		Where("product_variants.stock <= COALESCE(product_variants.low_stock_threshold, products.low_stock_threshold, ?)", defaultThreshold).
+		Order("product_variants.stock").
+		Scan(&variants).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to read low stock variants in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return append(items, variants...), nil
+}
+
+// stockTarget scopes a query to the row holding on-hand stock: the variant when
+// given (and owned by the product), otherwise the product itself
+func stockTarget(db *gorm.DB, productID, variantID string) *gorm.DB {
+	if variantID != "" {
+		return db.Model(&ProductVariantModel{}).Where("id = ? AND product_id = ?", variantID, productID)
+	}
+	return db.Model(&ProductModel{}).Where("id = ?", productID)
+}
+
+// Mapping functions
+
+func toStockMovementModel(m *inventory.Movement) *StockMovementModel {
+	return &StockMovementModel{
+		ID:         m.ID,
+		CreatedAt:  m.CreatedAt,
+		ProductID:  m.ProductID,
+		VariantID:  nullableID(m.VariantID),
+		Type:       string(m.Type),
+		Quantity:   m.Quantity,
+		StockAfter: m.StockAfter,
+		Reason:     m.Reason,
+		ActorID:    nullableID(m.ActorID),
+		Reference:  m.Reference,
+	}
+}
+
+func toStockMovementDomain(m *StockMovementModel) *inventory.Movement {
+	return &inventory.Movement{
+		ID:         m.ID,
+		ProductID:  m.ProductID,
+		VariantID:  stringValue(m.VariantID),
+		Type:       inventory.MovementType(m.Type),
+		Quantity:   m.Quantity,
+		StockAfter: m.StockAfter,
+		Reason:     m.Reason,
+		ActorID:    stringValue(m.ActorID),
+		Reference:  m.Reference,
+		CreatedAt:  m.CreatedAt,
+	}
+}
@@ -0,0 +1,168 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/invoice"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type invoiceRepository struct {
+	db *gorm.DB
+}
+
+// NewInvoiceRepository creates a new GORM implementation of invoice.Repository
+func NewInvoiceRepository(db *gorm.DB) invoice.Repository {
+	return &invoiceRepository{db: db}
+}
+
+func (r *invoiceRepository) Issue(doc *invoice.Document) (bool, error) {
+	model := toInvoiceModel(doc)
+	issued := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Locking the order makes concurrent issuers for it wait and then
+		// find the document the first one wrote
+		var o OrderModel
+		if err := tx.Select("id").Clauses(lockForUpdate()).First(&o, "id = ?", doc.OrderID).Error; err != nil {
+			return err
+		}
+
+		query := tx.Where("order_id = ? AND type = ?", doc.OrderID, string(doc.Type))
+		if doc.RefundID != "" {
+			query = query.Where("refund_id = ?", doc.RefundID)
+		}
+		var existing []*InvoiceModel
+		if err := query.Limit(1).Find(&existing).Error; err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			model = existing[0]
+			return nil
+		}
+
+		// The series row stays locked until commit, so numbers are handed out
+		// one at a time and a rolled back issue gives its number back
+		series := invoice.Series(doc.Type, doc.IssuedAt)
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&InvoiceSequenceModel{Series: series}).Error
+		if err != nil {
+			return err
+		}
+		var sequence InvoiceSequenceModel
+		if err := tx.Clauses(lockForUpdate()).First(&sequence, "series = ?", series).Error; err != nil {
+			return err
+		}
+		sequence.LastValue++
+		err = tx.Model(&InvoiceSequenceModel{}).Where("series = ?", series).
+			Update("last_value", sequence.LastValue).Error
+		if err != nil {
+			return err
+		}
+
+		model.Number = invoice.FormatNumber(series, sequence.LastValue)
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		issued = true
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to issue document in database. OrderID: %s, Type: %s, Error: %v", doc.OrderID, doc.Type, err)
+		return false, apperrors.ErrDatabaseError
+	}
+
+	*doc = *toInvoiceDomain(model)
+	return issued, nil
+}
+
+func (r *invoiceRepository) GetDocument(id string) (*invoice.Document, error) {
+	return r.findDocument(r.db.Where("id = ?", id))
+}
+
+func (r *invoiceRepository) GetInvoice(orderID string) (*invoice.Document, error) {
+	return r.findDocument(r.db.Where("order_id = ? AND type = ?", orderID, string(invoice.TypeInvoice)))
+}
+
+func (r *invoiceRepository) findDocument(query *gorm.DB) (*invoice.Document, error) {
+	var model InvoiceModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, invoice.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read document in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toInvoiceDomain(&model), nil
+}
+
+func (r *invoiceRepository) ListDocuments(orderID string) ([]*invoice.Document, error) {
+	var models []*InvoiceModel
+	if err := r.db.Where("order_id = ?", orderID).Order("issued_at").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list documents in database. OrderID: %s, Error: %v", orderID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	docs := make([]*invoice.Document, len(models))
+	for i, model := range models {
+		docs[i] = toInvoiceDomain(model)
+	}
+	return docs, nil
+}
+
+func (r *invoiceRepository) SetBlobKey(id, key string) error {
+	result := r.db.Model(&InvoiceModel{}).Where("id = ?", id).Update("blob_key", key)
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to set document file in database. ID: %s, Error: %v", id, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Mapping functions
+
+func toInvoiceModel(d *invoice.Document) *InvoiceModel {
+	return &InvoiceModel{
+		Base: Base{
+			ID:        d.ID,
+			CreatedAt: d.CreatedAt,
+			UpdatedAt: d.UpdatedAt,
+		},
+		Type:     string(d.Type),
+		Number:   d.Number,
+		OrderID:  d.OrderID,
+		RefundID: nullableID(d.RefundID),
+		Amount:   d.Amount.Amount,
+		Currency: d.Amount.Currency,
+		BlobKey:  d.BlobKey,
+		IssuedAt: d.IssuedAt,
+	}
+}
+
+func toInvoiceDomain(m *InvoiceModel) *invoice.Document {
+	return &invoice.Document{
+		ID:        m.ID,
+		Type:      invoice.Type(m.Type),
+		Number:    m.Number,
+		OrderID:   m.OrderID,
+		RefundID:  stringValue(m.RefundID),
+		Amount:    money.New(m.Amount, m.Currency),
+		BlobKey:   m.BlobKey,
+		IssuedAt:  m.IssuedAt,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
@@ -0,0 +1,105 @@
+package gorm
+
+import (
+	"log"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type likeRepository struct {
+	db *gorm.DB
+}
+
+// NewLikeRepository creates a new GORM implementation of product.LikeRepository
+func NewLikeRepository(db *gorm.DB) product.LikeRepository {
+	return &likeRepository{db: db}
+}
+
+func (r *likeRepository) Like(userID, productID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&ProductLikeModel{UserID: userID, ProductID: productID})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		return tx.Model(&ProductModel{}).
+			Where("id = ?", productID).
+			Update("like_count", gorm.Expr("like_count + 1")).Error
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to like product in database. ProductID: %s, UserID: %s, Error: %v", productID, userID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+func (r *likeRepository) Unlike(userID, productID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND product_id = ?", userID, productID).Delete(&ProductLikeModel{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		return tx.Model(&ProductModel{}).
+			Where("id = ?", productID).
+			Update("like_count", gorm.Expr("GREATEST(like_count - 1, 0)")).Error
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to unlike product in database. ProductID: %s, UserID: %s, Error: %v", productID, userID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+func (r *likeRepository) ListLikes(userID string, params pagination.Params) ([]*product.Like, int64, error) {
+	var models []*ProductLikeModel
+	var totalCount int64
+
+	query := r.db.Model(&ProductLikeModel{}).Where("user_id = ?", userID)
+
+	if params.NeedsTotal() {
+		if err := query.Count(&totalCount).Error; err != nil {
+			return nil, 0, apperrors.ErrDatabaseError
+		}
+	}
+
+	err := paginate(query, params, "product_likes").
+		Preload("Product").
+		Preload("Product.Images", "variant_id IS NULL").
+		Preload("Product.Variants", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		Preload("Product.Variants.OptionValues").
+		Find(&models).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to list likes in database. UserID: %s, Error: %v", userID, err)
+		return nil, 0, apperrors.ErrDatabaseError
+	}
+
+	likes := make([]*product.Like, len(models))
+	for i, model := range models {
+		likes[i] = toLikeDomain(model)
+	}
+
+	return likes, totalCount, nil
+}
+
+// Mapping functions
+
+func toLikeDomain(m *ProductLikeModel) *product.Like {
+	like := &product.Like{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		ProductID: m.ProductID,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.Product != nil {
+		like.Product = toProductDomain(m.Product)
+	}
+	return like
+}
@@ -14,6 +14,25 @@ type Base struct {
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
+// SEOColumns contains the search engine metadata shared by products and categories
+type SEOColumns struct {
+	MetaTitle       string `gorm:"size:255"`
+	MetaDescription string `gorm:"size:500"`
+	CanonicalURL    string `gorm:"size:500"`
+}
+
+// AddressColumns contains a postal address
+type AddressColumns struct {
+	Name       string `gorm:"size:255"`
+	Line1      string `gorm:"size:255"`
+	Line2      string `gorm:"size:255"`
+	City       string `gorm:"size:100"`
+	Region     string `gorm:"size:100"`
+	PostalCode string `gorm:"size:20"`
+	Country    string `gorm:"size:2"`
+	Phone      string `gorm:"size:32"`
+}
+
 // UserModel represents the GORM model for users
 type UserModel struct {
 	Base
@@ -22,9 +41,11 @@ type UserModel struct {
 	Password            string                    `gorm:"not null"`
 	FirstName           string                    `gorm:""`
 	LastName            string                    `gorm:""`
+	PreferredCurrency   string                    `gorm:"size:3"`
+	CustomerGroup       string                    `gorm:"size:64;index"`
 	RefreshTokens       []RefreshTokenModel       `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	PasswordResetTokens []PasswordResetTokenModel `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Roles               []RoleModel               `gorm:"many2many:user_roles;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Roles               []RoleModel               `gorm:"many2many:user_roles;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
 // TableName overrides the table name for UserModel
@@ -75,15 +96,53 @@ func (PasswordResetTokenModel) TableName() string {
 	return "password_reset_tokens"
 }
 
+// AddressModel represents the GORM model for address book entries
+type AddressModel struct {
+	Base
+	UserID string `gorm:"type:uuid;not null;index"`
+	AddressColumns
+	DefaultShipping bool       `gorm:"not null"`
+	DefaultBilling  bool       `gorm:"not null"`
+	User            *UserModel `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for AddressModel
+func (AddressModel) TableName() string {
+	return "addresses"
+}
+
 // ProductModel represents the GORM model for products
 type ProductModel struct {
 	Base
-	Name       string              `gorm:"not null;size:255"`
-	Price      float64             `gorm:"not null"`
-	Disabled   bool                `gorm:"default:false"`
-	Stock      int                 `gorm:"default:0"`
-	CategoryID string              `gorm:"type:uuid"`
-	Images     []ProductImageModel `gorm:"foreignKey:ProductID"`
+	SKU           *string                  `gorm:"uniqueIndex;size:64"`
+	Name          string                   `gorm:"not null;size:255"`
+	Price         int64                    `gorm:"not null"` // minor units of Currency
+	Currency      string                   `gorm:"not null;size:3"`
+	SalePrice     *int64                   `gorm:""` // minor units of Currency
+	SaleStartsAt  *time.Time               `gorm:""`
+	SaleEndsAt    *time.Time               `gorm:""`
+	Disabled      bool                     `gorm:"default:false"`
+	Stock         int                      `gorm:"default:0"`
+	Reserved      int                      `gorm:"not null;default:0"`
+	LowStock      *int                     `gorm:"column:low_stock_threshold"`
+	LikeCount     int                      `gorm:"not null;default:0;index"`
+	RatingCount   int                      `gorm:"not null;default:0"`
+	RatingAverage float64                  `gorm:"type:numeric(3,2);not null;default:0;index"`
+	CategoryID    string                   `gorm:"type:uuid"`
+	Slug          string                   `gorm:"uniqueIndex;not null;size:280"`
+	SEO           SEOColumns               `gorm:"embedded"`
+	Description   string                   `gorm:"type:text"` // sanitized HTML
+	Brand         string                   `gorm:"size:100;index"`
+	TaxClass      string                   `gorm:"not null;size:32;default:'standard'"`
+	WeightGrams   int                      `gorm:"not null;default:0"`
+	GiftCard      bool                     `gorm:"not null;default:false"`
+	LengthMM      int                      `gorm:"not null;default:0"`
+	WidthMM       int                      `gorm:"not null;default:0"`
+	HeightMM      int                      `gorm:"not null;default:0"`
+	Images        []ProductImageModel      `gorm:"foreignKey:ProductID"`
+	Options       []ProductOptionTypeModel `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Variants      []ProductVariantModel    `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Specs         []ProductSpecModel       `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
 // TableName overrides the table name for ProductModel
@@ -94,9 +153,10 @@ func (ProductModel) TableName() string {
 // ProductImageModel represents the GORM model for product images
 type ProductImageModel struct {
 	Base
-	ProductID string `gorm:"type:uuid;not null;index"`
-	URL       string `gorm:"not null;size:500"`
-	AltText   string `gorm:"size:255"`
+	ProductID string  `gorm:"type:uuid;not null;index"`
+	VariantID *string `gorm:"type:uuid;index"`
+	URL       string  `gorm:"not null;size:500"`
+	AltText   string  `gorm:"size:255"`
 }
 
 // TableName overrides the table name for ProductImageModel
@@ -104,11 +164,178 @@ func (ProductImageModel) TableName() string {
 	return "product_images"
 }
 
+// ProductOptionTypeModel represents the GORM model for product option types
+type ProductOptionTypeModel struct {
+	Base
+	ProductID string                    `gorm:"type:uuid;not null;index"`
+	Name      string                    `gorm:"not null;size:100"`
+	Position  int                       `gorm:"default:0"`
+	Values    []ProductOptionValueModel `gorm:"foreignKey:OptionTypeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ProductOptionTypeModel
+func (ProductOptionTypeModel) TableName() string {
+	return "product_option_types"
+}
+
+// ProductOptionValueModel represents the GORM model for product option values
+type ProductOptionValueModel struct {
+	Base
+	OptionTypeID string `gorm:"type:uuid;not null;index"`
+	Value        string `gorm:"not null;size:100"`
+	Position     int    `gorm:"default:0"`
+}
+
+// TableName overrides the table name for ProductOptionValueModel
+func (ProductOptionValueModel) TableName() string {
+	return "product_option_values"
+}
+
+// ProductVariantModel represents the GORM model for product variants
+type ProductVariantModel struct {
+	Base
+	ProductID    string                    `gorm:"type:uuid;not null;index"`
+	SKU          string                    `gorm:"uniqueIndex;not null;size:64"`
+	Price        *int64                    `gorm:""` // minor units of the product currency
+	Stock        int                       `gorm:"default:0"`
+	Reserved     int                       `gorm:"not null;default:0"`
+	LowStock     *int                      `gorm:"column:low_stock_threshold"`
+	Disabled     bool                      `gorm:"default:false"`
+	OptionValues []ProductOptionValueModel `gorm:"many2many:product_variant_option_values;joinForeignKey:VariantID;joinReferences:OptionValueID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Images       []ProductImageModel       `gorm:"foreignKey:VariantID"`
+}
+
+// TableName overrides the table name for ProductVariantModel
+func (ProductVariantModel) TableName() string {
+	return "product_variants"
+}
+
+// StockMovementModel represents the GORM model for the append-only stock ledger
+type StockMovementModel struct {
+	ID         string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt  time.Time `gorm:"index"`
+	ProductID  string    `gorm:"type:uuid;not null;index"`
+	VariantID  *string   `gorm:"type:uuid;index"`
+	Type       string    `gorm:"not null;size:32"`
+	Quantity   int       `gorm:"not null"`
+	StockAfter int       `gorm:"not null"`
+	Reason     string    `gorm:"size:255"`
+	ActorID    *string   `gorm:"type:uuid"`
+	Reference  string    `gorm:"size:64;index"`
+}
+
+// TableName overrides the table name for StockMovementModel
+func (StockMovementModel) TableName() string {
+	return "stock_movements"
+}
+
+// StockReservationModel represents the GORM model for stock reservations
+type StockReservationModel struct {
+	ID        string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt time.Time `gorm:""`
+	UpdatedAt time.Time `gorm:""`
+	ProductID string    `gorm:"type:uuid;not null;index"`
+	VariantID *string   `gorm:"type:uuid"`
+	OrderID   string    `gorm:"type:uuid;not null;index"`
+	Quantity  int       `gorm:"not null"`
+	Status    string    `gorm:"not null;size:32;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+// TableName overrides the table name for StockReservationModel
+func (StockReservationModel) TableName() string {
+	return "stock_reservations"
+}
+
+// CartModel represents the GORM model for shopping carts
+type CartModel struct {
+	Base
+	UserID       *string         `gorm:"type:uuid;uniqueIndex"` // nil for guest carts
+	Currency     string          `gorm:"not null;size:3"`
+	DiscountCode string          `gorm:"size:64"`
+	Items        []CartItemModel `gorm:"foreignKey:CartID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for CartModel
+func (CartModel) TableName() string {
+	return "carts"
+}
+
+// CartItemModel represents the GORM model for cart items
+type CartItemModel struct {
+	ID        string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt time.Time `gorm:""`
+	UpdatedAt time.Time `gorm:""`
+	CartID    string    `gorm:"type:uuid;not null;index"`
+	ProductID string    `gorm:"type:uuid;not null"`
+	VariantID *string   `gorm:"type:uuid"`
+	Quantity  int       `gorm:"not null"`
+}
+
+// TableName overrides the table name for CartItemModel
+func (CartItemModel) TableName() string {
+	return "cart_items"
+}
+
+// OrderModel represents the GORM model for orders
+type OrderModel struct {
+	Base
+	UserID          *string          `gorm:"type:uuid;index"` // nil for guest orders
+	Email           string           `gorm:"size:255"`
+	Status          string           `gorm:"not null;size:32;index"`
+	Fulfilment      string           `gorm:"not null;size:20;default:unfulfilled"`
+	Subtotal        int64            `gorm:"not null;default:0"` // minor units of Currency
+	Discount        int64            `gorm:"not null;default:0"` // minor units of Currency
+	Tax             int64            `gorm:"not null;default:0"` // minor units of Currency
+	TaxInclusive    bool             `gorm:"not null;default:false"`
+	Shipping        int64            `gorm:"not null;default:0"` // minor units of Currency
+	ShippingMethod  string           `gorm:"size:255"`
+	Total           int64            `gorm:"not null"`           // minor units of Currency
+	Redeemed        int64            `gorm:"not null;default:0"` // minor units of Currency
+	Refunded        int64            `gorm:"not null;default:0"` // minor units of Currency
+	DiscountCode    string           `gorm:"size:64"`
+	ShippingAddress AddressColumns   `gorm:"embedded;embeddedPrefix:shipping_"`
+	BillingAddress  AddressColumns   `gorm:"embedded;embeddedPrefix:billing_"`
+	Currency        string           `gorm:"not null;size:3"`
+	PaymentID       string           `gorm:"size:255;index"`
+	Lines           []OrderLineModel `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for OrderModel
+func (OrderModel) TableName() string {
+	return "orders"
+}
+
+// OrderLineModel represents the GORM model for order lines
+type OrderLineModel struct {
+	Base
+	OrderID   string  `gorm:"type:uuid;not null;index"`
+	ProductID string  `gorm:"type:uuid;not null"`
+	VariantID *string `gorm:"type:uuid"`
+	SKU       string  `gorm:"size:64"`
+	Name      string  `gorm:"not null;size:255"`
+	UnitPrice int64   `gorm:"not null"`                             // minor units of the order currency
+	Discount  int64   `gorm:"not null;default:0"`                   // minor units of the order currency
+	Tax       int64   `gorm:"not null;default:0"`                   // minor units of the order currency
+	TaxRate   string  `gorm:"type:numeric(7,4);not null;default:0"` // percentage
+	Quantity  int     `gorm:"not null"`
+	GiftCard  bool    `gorm:"not null;default:false"`
+}
+
+// TableName overrides the table name for OrderLineModel
+func (OrderLineModel) TableName() string {
+	return "order_lines"
+}
+
 // CategoryModel represents the GORM model for categories
 type CategoryModel struct {
 	Base
-	Name     string         `gorm:"not null;size:100"`
-	Products []ProductModel `gorm:"foreignKey:CategoryID"`
+	ParentID *string         `gorm:"type:uuid;index"`
+	Name     string          `gorm:"not null;size:100"`
+	Slug     string          `gorm:"uniqueIndex;not null;size:120"`
+	SEO      SEOColumns      `gorm:"embedded"`
+	Children []CategoryModel `gorm:"foreignKey:ParentID"`
+	Products []ProductModel  `gorm:"foreignKey:CategoryID"`
 }
 
 // TableName overrides the table name for CategoryModel
@@ -116,6 +343,559 @@ func (CategoryModel) TableName() string {
 	return "categories"
 }
 
+// CategoryAttributeModel represents the GORM model for a typed attribute of a category
+type CategoryAttributeModel struct {
+	ID         string         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt  time.Time      `gorm:""`
+	UpdatedAt  time.Time      `gorm:""`
+	CategoryID string         `gorm:"type:uuid;not null;uniqueIndex:idx_category_attributes_category_key,priority:1"`
+	Key        string         `gorm:"not null;size:100;uniqueIndex:idx_category_attributes_category_key,priority:2;index"`
+	Label      string         `gorm:"not null;size:100"`
+	Type       string         `gorm:"not null;size:20"`
+	Unit       string         `gorm:"size:20"`
+	Options    string         `gorm:"type:jsonb;not null;default:'[]'"` // JSON array of enum values
+	Required   bool           `gorm:"not null;default:false"`
+	Position   int            `gorm:"not null;default:0"`
+	Category   *CategoryModel `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for CategoryAttributeModel
+func (CategoryAttributeModel) TableName() string {
+	return "category_attributes"
+}
+
+// ProductSpecModel represents the GORM model for a product's value of a category attribute
+type ProductSpecModel struct {
+	ID          string                  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt   time.Time               `gorm:""`
+	ProductID   string                  `gorm:"type:uuid;not null;uniqueIndex:idx_product_specifications_product_attribute,priority:1"`
+	AttributeID string                  `gorm:"type:uuid;not null;uniqueIndex:idx_product_specifications_product_attribute,priority:2;index"`
+	Value       string                  `gorm:"not null;size:255"` // canonical text form
+	NumberValue *float64                `gorm:"type:numeric"`      // set for number attributes, used by range filters
+	Attribute   *CategoryAttributeModel `gorm:"foreignKey:AttributeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ProductSpecModel
+func (ProductSpecModel) TableName() string {
+	return "product_specifications"
+}
+
+// ProductSlugHistoryModel keeps the slugs a product was renamed away from so
+// old URLs can redirect to the current one
+type ProductSlugHistoryModel struct {
+	ID        string        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt time.Time     `gorm:""`
+	ProductID string        `gorm:"type:uuid;not null;index"`
+	Slug      string        `gorm:"uniqueIndex;not null;size:280"`
+	Product   *ProductModel `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ProductSlugHistoryModel
+func (ProductSlugHistoryModel) TableName() string {
+	return "product_slug_history"
+}
+
+// ProductLinkModel represents the GORM model for manager-curated links
+// between products
+type ProductLinkModel struct {
+	ID        string        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt time.Time     `gorm:""`
+	ProductID string        `gorm:"type:uuid;not null;uniqueIndex:idx_product_links_unique"`
+	TargetID  string        `gorm:"type:uuid;not null;uniqueIndex:idx_product_links_unique"`
+	Type      string        `gorm:"not null;size:20;uniqueIndex:idx_product_links_unique"`
+	Position  int           `gorm:"not null;default:0"`
+	Product   *ProductModel `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Target    *ProductModel `gorm:"foreignKey:TargetID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ProductLinkModel
+func (ProductLinkModel) TableName() string {
+	return "product_links"
+}
+
+// CoPurchaseModel stores how many paid orders contained both products, as
+// computed by the last recommendation run
+type CoPurchaseModel struct {
+	ProductID  string        `gorm:"type:uuid;primaryKey"`
+	RelatedID  string        `gorm:"type:uuid;primaryKey"`
+	Orders     int           `gorm:"not null"`
+	ComputedAt time.Time     `gorm:"not null"`
+	Product    *ProductModel `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Related    *ProductModel `gorm:"foreignKey:RelatedID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for CoPurchaseModel
+func (CoPurchaseModel) TableName() string {
+	return "product_co_purchases"
+}
+
+// RecommendationModel is an entry of a product's precomputed recommendation list
+type RecommendationModel struct {
+	ProductID     string        `gorm:"type:uuid;primaryKey"`
+	RecommendedID string        `gorm:"type:uuid;primaryKey"`
+	Type          string        `gorm:"not null;size:20"`
+	Source        string        `gorm:"not null;size:20"`
+	Score         int           `gorm:"not null;default:0"`
+	Position      int           `gorm:"not null"`
+	ComputedAt    time.Time     `gorm:"not null"`
+	Product       *ProductModel `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Recommended   *ProductModel `gorm:"foreignKey:RecommendedID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for RecommendationModel
+func (RecommendationModel) TableName() string {
+	return "product_recommendations"
+}
+
+// PriceHistoryModel is an append-only record of a product's price and sale
+// after each change
+type PriceHistoryModel struct {
+	ID            string        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt     time.Time     `gorm:"index"`
+	ProductID     string        `gorm:"type:uuid;not null;index"`
+	Price         int64         `gorm:"not null"` // minor units of Currency
+	PreviousPrice *int64        `gorm:""`
+	Currency      string        `gorm:"not null;size:3"`
+	SalePrice     *int64        `gorm:""`
+	SaleStartsAt  *time.Time    `gorm:""`
+	SaleEndsAt    *time.Time    `gorm:""`
+	Source        string        `gorm:"not null;size:20"`
+	ScheduleID    *string       `gorm:"type:uuid"`
+	Product       *ProductModel `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for PriceHistoryModel
+func (PriceHistoryModel) TableName() string {
+	return "product_price_history"
+}
+
+// PriceScheduleModel represents the GORM model for scheduled price changes
+type PriceScheduleModel struct {
+	Base
+	ProductID   string        `gorm:"type:uuid;not null;index"`
+	Price       int64         `gorm:"not null"` // minor units of Currency
+	Currency    string        `gorm:"not null;size:3"`
+	EffectiveAt time.Time     `gorm:"not null;index"`
+	Status      string        `gorm:"not null;size:20;index"`
+	AppliedAt   *time.Time    `gorm:""`
+	Product     *ProductModel `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for PriceScheduleModel
+func (PriceScheduleModel) TableName() string {
+	return "product_price_schedules"
+}
+
+// ProductLikeModel represents the GORM model for product likes (one per user and product)
+type ProductLikeModel struct {
+	ID        string        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt time.Time     `gorm:""`
+	UserID    string        `gorm:"type:uuid;not null;uniqueIndex:idx_product_likes_user_product,priority:1"`
+	ProductID string        `gorm:"type:uuid;not null;uniqueIndex:idx_product_likes_user_product,priority:2;index"`
+	Product   *ProductModel `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ProductLikeModel
+func (ProductLikeModel) TableName() string {
+	return "product_likes"
+}
+
+// ReviewModel represents the GORM model for product reviews
+type ReviewModel struct {
+	Base
+	ProductID    string        `gorm:"type:uuid;not null;index;uniqueIndex:idx_reviews_user_product,priority:2"`
+	UserID       string        `gorm:"type:uuid;not null;uniqueIndex:idx_reviews_user_product,priority:1"`
+	OrderID      string        `gorm:"type:uuid;not null"`
+	Rating       int           `gorm:"not null"`
+	Title        string        `gorm:"size:200"`
+	Body         string        `gorm:"type:text"`
+	Status       string        `gorm:"not null;size:32;index"`
+	HelpfulCount int           `gorm:"not null;default:0"`
+	ModeratedBy  *string       `gorm:"type:uuid"`
+	ModeratedAt  *time.Time    `gorm:""`
+	Product      *ProductModel `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User         *UserModel    `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Order        *OrderModel   `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ReviewModel
+func (ReviewModel) TableName() string {
+	return "reviews"
+}
+
+// ReviewVoteModel represents the GORM model for helpful votes (one per user and review)
+type ReviewVoteModel struct {
+	ID        string       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt time.Time    `gorm:""`
+	ReviewID  string       `gorm:"type:uuid;not null;uniqueIndex:idx_review_votes_review_user,priority:1"`
+	UserID    string       `gorm:"type:uuid;not null;uniqueIndex:idx_review_votes_review_user,priority:2"`
+	Review    *ReviewModel `gorm:"foreignKey:ReviewID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User      *UserModel   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ReviewVoteModel
+func (ReviewVoteModel) TableName() string {
+	return "review_votes"
+}
+
+// PriceListModel represents the GORM model for price lists
+type PriceListModel struct {
+	Base
+	Name          string                `gorm:"not null;size:100"`
+	Currency      string                `gorm:"not null;size:3;index"`
+	CustomerGroup string                `gorm:"size:64;index"`
+	Prices        []PriceListPriceModel `gorm:"foreignKey:PriceListID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for PriceListModel
+func (PriceListModel) TableName() string {
+	return "price_lists"
+}
+
+// PriceListPriceModel represents the GORM model for price list entries
+type PriceListPriceModel struct {
+	Base
+	PriceListID string  `gorm:"type:uuid;not null;index:idx_price_list_prices_product,priority:1"`
+	ProductID   string  `gorm:"type:uuid;not null;index:idx_price_list_prices_product,priority:2"`
+	VariantID   *string `gorm:"type:uuid"`
+	Amount      int64   `gorm:"not null"` // minor units of the price list currency
+}
+
+// TableName overrides the table name for PriceListPriceModel
+func (PriceListPriceModel) TableName() string {
+	return "price_list_prices"
+}
+
+// ExchangeRateModel represents the GORM model for exchange rates
+type ExchangeRateModel struct {
+	Base
+	BaseCurrency  string `gorm:"not null;size:3;uniqueIndex:idx_exchange_rates_pair,priority:1"`
+	QuoteCurrency string `gorm:"not null;size:3;uniqueIndex:idx_exchange_rates_pair,priority:2"`
+	Rate          string `gorm:"type:numeric(20,10);not null"`
+}
+
+// TableName overrides the table name for ExchangeRateModel
+func (ExchangeRateModel) TableName() string {
+	return "exchange_rates"
+}
+
+// ImportJobModel represents the GORM model for bulk product import jobs
+type ImportJobModel struct {
+	Base
+	Format        string     `gorm:"not null;size:10"`
+	DryRun        bool       `gorm:"not null;default:false"`
+	Status        string     `gorm:"not null;size:20;index"`
+	TotalRows     int        `gorm:"not null;default:0"`
+	ProcessedRows int        `gorm:"not null;default:0"`
+	Created       int        `gorm:"not null;default:0"`
+	Updated       int        `gorm:"not null;default:0"`
+	Failed        int        `gorm:"not null;default:0"`
+	Errors        string     `gorm:"type:jsonb;not null;default:'[]'"` // JSON array of row errors
+	Message       string     `gorm:"size:255"`
+	CreatedBy     *string    `gorm:"type:uuid"`
+	Payload       []byte     `gorm:"type:bytea"` // uploaded file, cleared when the job finishes
+	StartedAt     *time.Time `gorm:""`
+	FinishedAt    *time.Time `gorm:""`
+}
+
+// TableName overrides the table name for ImportJobModel
+func (ImportJobModel) TableName() string {
+	return "import_jobs"
+}
+
+// TaxRateModel represents the GORM model for tax rates by country, region and tax class
+type TaxRateModel struct {
+	Base
+	Country  string `gorm:"not null;size:2;uniqueIndex:idx_tax_rates_scope,priority:1"`
+	Region   string `gorm:"not null;size:100;default:'';uniqueIndex:idx_tax_rates_scope,priority:2"`
+	TaxClass string `gorm:"not null;size:32;uniqueIndex:idx_tax_rates_scope,priority:3"`
+	Name     string `gorm:"not null;size:100"`
+	Rate     string `gorm:"type:numeric(7,6);not null"` // fraction of the taxed amount; rows are hard-deleted
+}
+
+// TableName overrides the table name for TaxRateModel
+func (TaxRateModel) TableName() string {
+	return "tax_rates"
+}
+
+// ShippingZoneModel represents the GORM model for shipping zones
+type ShippingZoneModel struct {
+	Base
+	Name      string                `gorm:"not null;size:255"`
+	Countries string                `gorm:"type:jsonb;not null;default:'[]'"` // JSON array of country codes
+	Methods   []ShippingMethodModel `gorm:"foreignKey:ZoneID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ShippingZoneModel
+func (ShippingZoneModel) TableName() string {
+	return "shipping_zones"
+}
+
+// ShippingMethodModel represents the GORM model for the shipping methods of a zone
+type ShippingMethodModel struct {
+	Base
+	ZoneID     string `gorm:"type:uuid;not null;index"`
+	Name       string `gorm:"not null;size:255"`
+	Carrier    string `gorm:"size:100"`
+	Type       string `gorm:"not null;size:32"`
+	Price      int64  `gorm:"not null;default:0"` // minor units of Currency
+	PricePerKg *int64 `gorm:""`                   // minor units of Currency
+	FreeOver   *int64 `gorm:""`                   // minor units of Currency
+	Currency   string `gorm:"not null;size:3"`
+	Active     bool   `gorm:"not null"`
+}
+
+// TableName overrides the table name for ShippingMethodModel
+func (ShippingMethodModel) TableName() string {
+	return "shipping_methods"
+}
+
+// ShipmentModel represents the GORM model for parcels sent for orders
+type ShipmentModel struct {
+	Base
+	OrderID        string      `gorm:"type:uuid;not null;index"`
+	Carrier        string      `gorm:"not null;size:100"`
+	TrackingNumber string      `gorm:"not null;size:255"`
+	Status         string      `gorm:"not null;size:20"`
+	ShippedAt      time.Time   `gorm:"not null"`
+	DeliveredAt    *time.Time  `gorm:""`
+	Order          *OrderModel `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ShipmentModel
+func (ShipmentModel) TableName() string {
+	return "shipments"
+}
+
+// ReturnModel represents the GORM model for return merchandise authorizations
+type ReturnModel struct {
+	Base
+	OrderID    string            `gorm:"type:uuid;not null;index"`
+	UserID     string            `gorm:"type:uuid;not null;index"`
+	Status     string            `gorm:"not null;size:20;index"`
+	Comment    string            `gorm:"type:text"`
+	Resolution string            `gorm:"type:text"`
+	RefundID   *string           `gorm:"type:uuid"`
+	ReceivedAt *time.Time        `gorm:""`
+	Lines      []ReturnLineModel `gorm:"foreignKey:ReturnID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Order      *OrderModel       `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ReturnModel
+func (ReturnModel) TableName() string {
+	return "returns"
+}
+
+// ReturnLineModel represents the GORM model for order lines being returned
+type ReturnLineModel struct {
+	ID          string          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ReturnID    string          `gorm:"type:uuid;not null;index"`
+	OrderLineID string          `gorm:"type:uuid;not null;index"`
+	ProductID   string          `gorm:"type:uuid;not null"`
+	VariantID   *string         `gorm:"type:uuid"`
+	Quantity    int             `gorm:"not null"`
+	Reason      string          `gorm:"not null;size:32"`
+	OrderLine   *OrderLineModel `gorm:"foreignKey:OrderLineID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for ReturnLineModel
+func (ReturnLineModel) TableName() string {
+	return "return_lines"
+}
+
+// RefundModel represents the GORM model for money given back on orders
+type RefundModel struct {
+	Base
+	OrderID     string       `gorm:"type:uuid;not null;index"`
+	ReturnID    *string      `gorm:"type:uuid;index"`
+	Amount      int64        `gorm:"not null"`           // minor units of Currency
+	StoreCredit int64        `gorm:"not null;default:0"` // minor units of Currency
+	Currency    string       `gorm:"not null;size:3"`
+	Reason      string       `gorm:"size:255"`
+	Status      string       `gorm:"not null;size:20"`
+	ProviderID  string       `gorm:"size:255"`
+	ActorID     *string      `gorm:"type:uuid"`
+	Order       *OrderModel  `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Return      *ReturnModel `gorm:"foreignKey:ReturnID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
+
+// TableName overrides the table name for RefundModel
+func (RefundModel) TableName() string {
+	return "refunds"
+}
+
+// InvoiceModel represents the GORM model for invoices and credit notes
+type InvoiceModel struct {
+	Base
+	Type     string       `gorm:"not null;size:20;index:idx_invoices_order_type,priority:2"`
+	Number   string       `gorm:"not null;size:32;uniqueIndex"`
+	OrderID  string       `gorm:"type:uuid;not null;index:idx_invoices_order_type,priority:1"`
+	RefundID *string      `gorm:"type:uuid;uniqueIndex"`
+	Amount   int64        `gorm:"not null"` // minor units of Currency
+	Currency string       `gorm:"not null;size:3"`
+	BlobKey  string       `gorm:"size:255"`
+	IssuedAt time.Time    `gorm:"not null"`
+	Order    *OrderModel  `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	Refund   *RefundModel `gorm:"foreignKey:RefundID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
+
+// TableName overrides the table name for InvoiceModel
+func (InvoiceModel) TableName() string {
+	return "invoices"
+}
+
+// InvoiceSequenceModel represents the GORM model for the last number handed
+// out in each invoice or credit note series
+type InvoiceSequenceModel struct {
+	Series    string `gorm:"primaryKey;size:32"`
+	LastValue int64  `gorm:"not null;default:0"`
+}
+
+// TableName overrides the table name for InvoiceSequenceModel
+func (InvoiceSequenceModel) TableName() string {
+	return "invoice_sequences"
+}
+
+// CartReminderModel represents the GORM model for abandoned cart reminders
+type CartReminderModel struct {
+	ID       string      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CartID   string      `gorm:"type:uuid;not null;index"`
+	UserID   string      `gorm:"type:uuid;not null;index"`
+	Email    string      `gorm:"not null;size:255"`
+	Sequence int         `gorm:"not null"`
+	Value    int64       `gorm:"not null"` // minor units of Currency
+	Currency string      `gorm:"not null;size:3"`
+	OrderID  *string     `gorm:"type:uuid;index"`
+	SentAt   time.Time   `gorm:"not null;index"`
+	Cart     *CartModel  `gorm:"foreignKey:CartID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Order    *OrderModel `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
+
+// TableName overrides the table name for CartReminderModel
+func (CartReminderModel) TableName() string {
+	return "cart_reminders"
+}
+
+// IdempotencyKeyModel represents the GORM model for requests made with an
+// idempotency key and their stored responses
+type IdempotencyKeyModel struct {
+	Key         string    `gorm:"primaryKey;size:64"` // SHA-256 of the scoped client key
+	Fingerprint string    `gorm:"not null;size:64"`
+	Status      int       `gorm:"not null;default:0"`               // zero while in flight
+	Header      string    `gorm:"type:jsonb;not null;default:'{}'"` // JSON object of response headers
+	Body        []byte    `gorm:"type:bytea"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time `gorm:""`
+}
+
+// TableName overrides the table name for IdempotencyKeyModel
+func (IdempotencyKeyModel) TableName() string {
+	return "idempotency_keys"
+}
+
+// GiftCardModel represents the GORM model for gift cards
+type GiftCardModel struct {
+	Base
+	Code        string  `gorm:"not null;size:32;uniqueIndex"`
+	Initial     int64   `gorm:"not null"` // minor units of Currency
+	Balance     int64   `gorm:"not null"` // minor units of Currency; only written with a ledger entry
+	Currency    string  `gorm:"not null;size:3"`
+	OrderID     *string `gorm:"type:uuid;index"` // NULL when issued by a manager
+	OrderLineID *string `gorm:"type:uuid;index"`
+	Email       string  `gorm:"size:255"`
+	VoidedAt    *time.Time
+	Order       *OrderModel `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
+
+// TableName overrides the table name for GiftCardModel
+func (GiftCardModel) TableName() string {
+	return "gift_cards"
+}
+
+// StoreCreditModel represents the GORM model for a customer's store credit in one currency
+type StoreCreditModel struct {
+	Base
+	UserID   string     `gorm:"type:uuid;not null;uniqueIndex:idx_store_credits_user_currency,priority:1"`
+	Currency string     `gorm:"not null;size:3;uniqueIndex:idx_store_credits_user_currency,priority:2"`
+	Balance  int64      `gorm:"not null;default:0"` // minor units of Currency; only written with a ledger entry
+	User     *UserModel `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for StoreCreditModel
+func (StoreCreditModel) TableName() string {
+	return "store_credits"
+}
+
+// BalanceEntryModel represents the GORM model for the append-only ledger of
+// gift card and store credit balances
+type BalanceEntryModel struct {
+	ID            string            `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt     time.Time         `gorm:"index"`
+	GiftCardID    *string           `gorm:"type:uuid;index"`
+	StoreCreditID *string           `gorm:"type:uuid;index"`
+	Type          string            `gorm:"not null;size:20"`
+	Amount        int64             `gorm:"not null"` // minor units of Currency; negative when spent
+	BalanceAfter  int64             `gorm:"not null"`
+	Currency      string            `gorm:"not null;size:3"`
+	OrderID       *string           `gorm:"type:uuid;index"`
+	RefundID      *string           `gorm:"type:uuid;index;uniqueIndex:idx_balance_entries_refund_credit,where:store_credit_id IS NOT NULL"` // a refund is credited once
+	ActorID       *string           `gorm:"type:uuid"`
+	GiftCard      *GiftCardModel    `gorm:"foreignKey:GiftCardID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	StoreCredit   *StoreCreditModel `gorm:"foreignKey:StoreCreditID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Order         *OrderModel       `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	Refund        *RefundModel      `gorm:"foreignKey:RefundID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
+
+// TableName overrides the table name for BalanceEntryModel
+func (BalanceEntryModel) TableName() string {
+	return "balance_entries"
+}
+
+// PromotionModel represents the GORM model for discount codes and automatic promotions
+type PromotionModel struct {
+	Base
+	Name             string     `gorm:"not null;size:255"`
+	Code             *string    `gorm:"uniqueIndex;size:64"` // NULL for automatic promotions
+	Type             string     `gorm:"not null;size:20"`
+	Percent          int        `gorm:"not null;default:0"`
+	Amount           *int64     `gorm:""` // minor units of Currency
+	BuyQuantity      int        `gorm:"not null;default:0"`
+	GetQuantity      int        `gorm:"not null;default:0"`
+	MinOrderValue    *int64     `gorm:""` // minor units of Currency
+	Currency         string     `gorm:"not null;size:3"`
+	UsageLimit       int        `gorm:"not null;default:0"`
+	PerCustomerLimit int        `gorm:"not null;default:0"`
+	UsageCount       int        `gorm:"not null;default:0"`
+	ProductIDs       string     `gorm:"type:jsonb;not null;default:'[]'"` // JSON array of product IDs
+	CategoryIDs      string     `gorm:"type:jsonb;not null;default:'[]'"` // JSON array of category IDs
+	StartsAt         *time.Time `gorm:""`
+	EndsAt           *time.Time `gorm:""`
+	Active           bool       `gorm:"not null;index"`
+}
+
+// TableName overrides the table name for PromotionModel
+func (PromotionModel) TableName() string {
+	return "promotions"
+}
+
+// PromotionRedemptionModel represents the GORM model for promotions used by orders
+type PromotionRedemptionModel struct {
+	ID          string          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt   time.Time       `gorm:""`
+	PromotionID string          `gorm:"type:uuid;not null;index:idx_promotion_redemptions_promotion_user,priority:1"`
+	UserID      *string         `gorm:"type:uuid;index:idx_promotion_redemptions_promotion_user,priority:2"` // nil for guest orders
+	OrderID     string          `gorm:"type:uuid;not null;index"`
+	Amount      int64           `gorm:"not null"` // minor units of Currency
+	Currency    string          `gorm:"not null;size:3"`
+	Promotion   *PromotionModel `gorm:"foreignKey:PromotionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Order       *OrderModel     `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TableName overrides the table name for PromotionRedemptionModel
+func (PromotionRedemptionModel) TableName() string {
+	return "promotion_redemptions"
+}
+
 // AllModels returns all GORM models for schema migration tools (Atlas, etc.)
 func AllModels() []interface{} {
 	return []interface{}{
@@ -126,5 +906,46 @@ func AllModels() []interface{} {
 		&ProductModel{},
 		&ProductImageModel{},
 		&CategoryModel{},
+		&ProductOptionTypeModel{},
+		&ProductOptionValueModel{},
+		&ProductVariantModel{},
+		&StockMovementModel{},
+		&StockReservationModel{},
+		&CartModel{},
+		&CartItemModel{},
+		&OrderModel{},
+		&OrderLineModel{},
+		&ProductSlugHistoryModel{},
+		&CategoryAttributeModel{},
+		&ProductSpecModel{},
+		&ProductLikeModel{},
+		&ReviewModel{},
+		&ReviewVoteModel{},
+		&PriceListModel{},
+		&PriceListPriceModel{},
+		&ExchangeRateModel{},
+		&ImportJobModel{},
+		&PriceHistoryModel{},
+		&PriceScheduleModel{},
+		&ProductLinkModel{},
+		&CoPurchaseModel{},
+		&RecommendationModel{},
+		&PromotionModel{},
+		&PromotionRedemptionModel{},
+		&TaxRateModel{},
+		&ShippingZoneModel{},
+		&ShippingMethodModel{},
+		&ShipmentModel{},
+		&AddressModel{},
+		&ReturnModel{},
+		&ReturnLineModel{},
+		&RefundModel{},
+		&InvoiceModel{},
+		&InvoiceSequenceModel{},
+		&CartReminderModel{},
+		&IdempotencyKeyModel{},
+		&GiftCardModel{},
+		&StoreCreditModel{},
+		&BalanceEntryModel{},
 	}
 }
@@ -0,0 +1,273 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+	"math/big"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/tax"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+type orderRepository struct {
+	db *gorm.DB
+}
+
+// NewOrderRepository creates a new GORM implementation of order.Repository
+func NewOrderRepository(db *gorm.DB) order.Repository {
+	return &orderRepository{db: db}
+}
+
+func (r *orderRepository) ListOrders(params pagination.Params, filters order.Filters) ([]*order.Order, int64, error) {
+	var models []*OrderModel
+	var totalCount int64
+
+	query := r.db.Model(&OrderModel{})
+	if filters.UserID != "" {
+		query = query.Where("user_id = ?", filters.UserID)
+	}
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+
+	if params.NeedsTotal() {
+		if err := query.Count(&totalCount).Error; err != nil {
+			return nil, 0, apperrors.ErrDatabaseError
+		}
+	}
+
+	if err := paginate(query.Preload("Lines"), params, "orders").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to read orders in database. Error: %v", err)
+		return nil, 0, apperrors.ErrDatabaseError
+	}
+
+	orders := make([]*order.Order, len(models))
+	for i, model := range models {
+		orders[i] = toOrderDomain(model)
+	}
+
+	return orders, totalCount, nil
+}
+
+func (r *orderRepository) GetOrder(id string) (*order.Order, error) {
+	var model OrderModel
+	if err := r.db.Preload("Lines").First(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read order in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toOrderDomain(&model), nil
+}
+
+func (r *orderRepository) CreateOrder(o *order.Order) error {
+	model := toOrderModel(o)
+	if err := r.db.Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to create order in database. UserID: %s, Error: %v", o.UserID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*o = *toOrderDomain(model)
+	return nil
+}
+
+func (r *orderRepository) UpdateOrderStatus(id string, status order.Status) error {
+	result := r.db.Model(&OrderModel{}).Where("id = ?", id).Update("status", string(status))
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to update order status in database. ID: %s, Error: %v", id, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *orderRepository) SetPaymentID(id, paymentID string) error {
+	result := r.db.Model(&OrderModel{}).Where("id = ?", id).Update("payment_id", paymentID)
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to set order payment in database. ID: %s, Error: %v", id, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *orderRepository) TransitionStatus(id string, from, to order.Status) (bool, error) {
+	result := r.db.Model(&OrderModel{}).
+		Where("id = ? AND status = ?", id, string(from)).
+		Update("status", string(to))
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to transition order status in database. ID: %s, Error: %v", id, result.Error)
+		return false, apperrors.ErrDatabaseError
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *orderRepository) SetFulfilment(id string, fulfilment order.Fulfilment) error {
+	result := r.db.Model(&OrderModel{}).Where("id = ?", id).Update("fulfilment", string(fulfilment))
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to set order fulfilment in database. ID: %s, Error: %v", id, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Mapping functions
+
+func toOrderModel(o *order.Order) *OrderModel {
+	lines := make([]OrderLineModel, len(o.Lines))
+	for i, l := range o.Lines {
+		lines[i] = OrderLineModel{
+			Base: Base{
+				ID:        l.ID,
+				CreatedAt: l.CreatedAt,
+				UpdatedAt: l.UpdatedAt,
+			},
+			OrderID:   l.OrderID,
+			ProductID: l.ProductID,
+			VariantID: nullableID(l.VariantID),
+			SKU:       l.SKU,
+			Name:      l.Name,
+			UnitPrice: l.UnitPrice.Amount,
+			Discount:  l.Discount.Amount,
+			Tax:       l.Tax.Amount,
+			TaxRate:   taxRateColumn(l.TaxRate),
+			Quantity:  l.Quantity,
+			GiftCard:  l.GiftCard,
+		}
+	}
+
+	return &OrderModel{
+		Base: Base{
+			ID:        o.ID,
+			CreatedAt: o.CreatedAt,
+			UpdatedAt: o.UpdatedAt,
+		},
+		UserID:          nullableID(o.UserID),
+		Email:           o.Email,
+		Status:          string(o.Status),
+		Fulfilment:      string(o.Fulfilment),
+		Subtotal:        o.Subtotal.Amount,
+		Discount:        o.Discount.Amount,
+		Tax:             o.Tax.Amount,
+		TaxInclusive:    o.TaxInclusive,
+		Shipping:        o.Shipping.Amount,
+		ShippingMethod:  o.ShippingMethod,
+		Total:           o.Total.Amount,
+		Redeemed:        o.Redeemed.Amount,
+		Refunded:        o.Refunded.Amount,
+		DiscountCode:    o.DiscountCode,
+		ShippingAddress: toAddressColumns(o.ShippingAddress),
+		BillingAddress:  toAddressColumns(o.BillingAddress),
+		Currency:        o.Total.Currency,
+		PaymentID:       o.PaymentID,
+		Lines:           lines,
+	}
+}
+
+func toOrderDomain(m *OrderModel) *order.Order {
+	lines := make([]order.Line, len(m.Lines))
+	for i, l := range m.Lines {
+		lines[i] = order.Line{
+			ID:        l.ID,
+			OrderID:   l.OrderID,
+			ProductID: l.ProductID,
+			VariantID: stringValue(l.VariantID),
+			SKU:       l.SKU,
+			Name:      l.Name,
+			UnitPrice: money.New(l.UnitPrice, m.Currency),
+			Discount:  money.New(l.Discount, m.Currency),
+			Tax:       money.New(l.Tax, m.Currency),
+			TaxRate:   taxRatePercent(l.TaxRate),
+			Quantity:  l.Quantity,
+			GiftCard:  l.GiftCard,
+			CreatedAt: l.CreatedAt,
+			UpdatedAt: l.UpdatedAt,
+		}
+	}
+
+	return &order.Order{
+		ID:              m.ID,
+		UserID:          stringValue(m.UserID),
+		Email:           m.Email,
+		Status:          order.Status(m.Status),
+		Fulfilment:      order.Fulfilment(m.Fulfilment),
+		Subtotal:        money.New(m.Subtotal, m.Currency),
+		Discount:        money.New(m.Discount, m.Currency),
+		Tax:             money.New(m.Tax, m.Currency),
+		TaxInclusive:    m.TaxInclusive,
+		Shipping:        money.New(m.Shipping, m.Currency),
+		ShippingMethod:  m.ShippingMethod,
+		Total:           money.New(m.Total, m.Currency),
+		Redeemed:        money.New(m.Redeemed, m.Currency),
+		Refunded:        money.New(m.Refunded, m.Currency),
+		DiscountCode:    m.DiscountCode,
+		ShippingAddress: toAddressDomain(m.ShippingAddress),
+		BillingAddress:  toAddressDomain(m.BillingAddress),
+		PaymentID:       m.PaymentID,
+		Lines:           lines,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+func toAddressColumns(a order.Address) AddressColumns {
+	return AddressColumns{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+		Phone:      a.Phone,
+	}
+}
+
+func toAddressDomain(c AddressColumns) order.Address {
+	return order.Address{
+		Name:       c.Name,
+		Line1:      c.Line1,
+		Line2:      c.Line2,
+		City:       c.City,
+		Region:     c.Region,
+		PostalCode: c.PostalCode,
+		Country:    c.Country,
+		Phone:      c.Phone,
+	}
+}
+
+// taxRateColumn stores an empty line tax rate as zero
+func taxRateColumn(percent string) string {
+	if percent == "" {
+		return "0"
+	}
+	return percent
+}
+
+// taxRatePercent renders a stored line tax rate without trailing zeros
+func taxRatePercent(column string) string {
+	percent, ok := new(big.Rat).SetString(column)
+	if !ok {
+		return column
+	}
+	return tax.FormatPercent(percent.Quo(percent, big.NewRat(100, 1)))
+}
@@ -0,0 +1,44 @@
+package gorm
+
+import (
+	"fmt"
+
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// paginate orders the query newest first and applies offset or keyset
+// pagination. In keyset mode one extra row is fetched so the caller can tell
+// whether another page exists; backward pages come back in ascending order.
+func paginate(query *gorm.DB, params pagination.Params, table string) *gorm.DB {
+	createdAt := fmt.Sprintf("%s.created_at", table)
+	id := fmt.Sprintf("%s.id", table)
+
+	if !params.IsCursor() {
+		return query.
+			Order(createdAt + " DESC").
+			Order(id + " DESC").
+			Offset(params.Offset()).
+			Limit(params.Limit())
+	}
+
+	if params.Backward {
+		if params.Cursor != nil {
+			query = query.Where(
+				fmt.Sprintf("(%s, %s) > (?, ?)", createdAt, id),
+				params.Cursor.CreatedAt, params.Cursor.ID,
+			)
+		}
+		query = query.Order(createdAt + " ASC").Order(id + " ASC")
+	} else {
+		if params.Cursor != nil {
+			query = query.Where(
+				fmt.Sprintf("(%s, %s) < (?, ?)", createdAt, id),
+				params.Cursor.CreatedAt, params.Cursor.ID,
+			)
+		}
+		query = query.Order(createdAt + " DESC").Order(id + " DESC")
+	}
+
+	return query.Limit(params.Limit() + 1)
+}
@@ -0,0 +1,267 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+type priceRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceRepository creates a new GORM implementation of product.PriceRepository
+func NewPriceRepository(db *gorm.DB) product.PriceRepository {
+	return &priceRepository{db: db}
+}
+
+func (r *priceRepository) ListPriceHistory(productID string, params pagination.Params) ([]*product.PriceChange, int64, error) {
+	var models []*PriceHistoryModel
+	var totalCount int64
+
+	query := r.db.Model(&PriceHistoryModel{}).Where("product_id = ?", productID)
+
+	if params.NeedsTotal() {
+		if err := query.Count(&totalCount).Error; err != nil {
+			return nil, 0, apperrors.ErrDatabaseError
+		}
+	}
+
+	if err := paginate(query, params, "product_price_history").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list price history in database. ProductID: %s, Error: %v", productID, err)
+		return nil, 0, apperrors.ErrDatabaseError
+	}
+
+	changes := make([]*product.PriceChange, len(models))
+	for i, model := range models {
+		changes[i] = toPriceChangeDomain(model)
+	}
+
+	return changes, totalCount, nil
+}
+
+func (r *priceRepository) CreateSchedule(s *product.PriceSchedule) error {
+	model := toPriceScheduleModel(s)
+	if err := r.db.Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to create price schedule in database. ProductID: %s, Error: %v", s.ProductID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*s = *toPriceScheduleDomain(model)
+	return nil
+}
+
+func (r *priceRepository) ListSchedules(productID string) ([]*product.PriceSchedule, error) {
+	var models []*PriceScheduleModel
+	if err := r.db.Where("product_id = ?", productID).Order("effective_at DESC").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list price schedules in database. ProductID: %s, Error: %v", productID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	schedules := make([]*product.PriceSchedule, len(models))
+	for i, model := range models {
+		schedules[i] = toPriceScheduleDomain(model)
+	}
+	return schedules, nil
+}
+
+func (r *priceRepository) CancelSchedule(productID, scheduleID string) error {
+	result := r.db.Model(&PriceScheduleModel{}).
+		Where("id = ? AND product_id = ? AND status = ?", scheduleID, productID, string(product.SchedulePending)).
+		Update("status", string(product.ScheduleCancelled))
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to cancel price schedule in database. ID: %s, Error: %v", scheduleID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *priceRepository) ListDueSchedules(now time.Time, limit int) ([]*product.PriceSchedule, error) {
+	var models []*PriceScheduleModel
+	err := r.db.Where("status = ? AND effective_at <= ?", string(product.SchedulePending), now).
+		Order("effective_at").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to list due price schedules in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	schedules := make([]*product.PriceSchedule, len(models))
+	for i, model := range models {
+		schedules[i] = toPriceScheduleDomain(model)
+	}
+	return schedules, nil
+}
+
+func (r *priceRepository) ApplySchedule(scheduleID string, now time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var schedule PriceScheduleModel
+		err := tx.Clauses(lockForUpdate()).
+			First(&schedule, "id = ? AND status = ?", scheduleID, string(product.SchedulePending)).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrNotFound
+			}
+			return apperrors.ErrDatabaseError
+		}
+
+		var p ProductModel
+		err = tx.Select("id", "price", "currency", "sale_price", "sale_starts_at", "sale_ends_at").
+			Clauses(lockForUpdate()).
+			First(&p, "id = ?", schedule.ProductID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The product was deleted; drop the schedule instead of retrying it
+			if err := tx.Model(&schedule).Update("status", string(product.ScheduleCancelled)).Error; err != nil {
+				return apperrors.ErrDatabaseError
+			}
+			return nil
+		}
+		if err != nil {
+			return apperrors.ErrDatabaseError
+		}
+
+		previous := p.Price
+		if p.Price != schedule.Price || p.Currency != schedule.Currency {
+			p.Price = schedule.Price
+			p.Currency = schedule.Currency
+			err := tx.Model(&ProductModel{}).Where("id = ?", p.ID).
+				Updates(map[string]interface{}{"price": p.Price, "currency": p.Currency}).Error
+			if err != nil {
+				return apperrors.ErrDatabaseError
+			}
+
+			if err := tx.Create(toPriceHistoryModel(&p, &previous, product.PriceChangeSchedule, schedule.ID)).Error; err != nil {
+				return apperrors.ErrDatabaseError
+			}
+		}
+
+		err = tx.Model(&schedule).
+			Updates(map[string]interface{}{"status": string(product.ScheduleApplied), "applied_at": now}).Error
+		if err != nil {
+			return apperrors.ErrDatabaseError
+		}
+		return nil
+	})
+	if err != nil {
+		if err != apperrors.ErrNotFound {
+			log.Printf("ERROR: Failed to apply price schedule in database. ID: %s, Error: %v", scheduleID, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// pricingChanged reports whether the price or sale columns differ between the
+// stored product and its update
+func pricingChanged(previous, next *ProductModel) bool {
+	return previous.Price != next.Price ||
+		previous.Currency != next.Currency ||
+		!equalInt64(previous.SalePrice, next.SalePrice) ||
+		!equalTime(previous.SaleStartsAt, next.SaleStartsAt) ||
+		!equalTime(previous.SaleEndsAt, next.SaleEndsAt)
+}
+
+func equalInt64(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// Mapping functions
+
+func toSaleColumns(s *product.Sale) (price *int64, startsAt, endsAt *time.Time) {
+	if s == nil {
+		return nil, nil, nil
+	}
+	amount := s.Price.Amount
+	return &amount, s.StartsAt, s.EndsAt
+}
+
+func toSaleDomain(price *int64, startsAt, endsAt *time.Time, currency string) *product.Sale {
+	if price == nil {
+		return nil
+	}
+	return &product.Sale{
+		Price:    money.New(*price, currency),
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+	}
+}
+
+// toPriceHistoryModel records the pricing of a product model after a change
+func toPriceHistoryModel(m *ProductModel, previousPrice *int64, source product.PriceChangeSource, scheduleID string) *PriceHistoryModel {
+	return &PriceHistoryModel{
+		ProductID:     m.ID,
+		Price:         m.Price,
+		PreviousPrice: previousPrice,
+		Currency:      m.Currency,
+		SalePrice:     m.SalePrice,
+		SaleStartsAt:  m.SaleStartsAt,
+		SaleEndsAt:    m.SaleEndsAt,
+		Source:        string(source),
+		ScheduleID:    nullableID(scheduleID),
+	}
+}
+
+func toPriceChangeDomain(m *PriceHistoryModel) *product.PriceChange {
+	return &product.PriceChange{
+		ID:            m.ID,
+		ProductID:     m.ProductID,
+		Price:         money.New(m.Price, m.Currency),
+		PreviousPrice: priceOverride(m.PreviousPrice, m.Currency),
+		Sale:          toSaleDomain(m.SalePrice, m.SaleStartsAt, m.SaleEndsAt, m.Currency),
+		Source:        product.PriceChangeSource(m.Source),
+		ScheduleID:    stringValue(m.ScheduleID),
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+func toPriceScheduleModel(s *product.PriceSchedule) *PriceScheduleModel {
+	return &PriceScheduleModel{
+		Base: Base{
+			ID:        s.ID,
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.UpdatedAt,
+		},
+		ProductID:   s.ProductID,
+		Price:       s.Price.Amount,
+		Currency:    s.Price.Currency,
+		EffectiveAt: s.EffectiveAt,
+		Status:      string(s.Status),
+		AppliedAt:   s.AppliedAt,
+	}
+}
+
+func toPriceScheduleDomain(m *PriceScheduleModel) *product.PriceSchedule {
+	return &product.PriceSchedule{
+		ID:          m.ID,
+		ProductID:   m.ProductID,
+		Price:       money.New(m.Price, m.Currency),
+		EffectiveAt: m.EffectiveAt,
+		Status:      product.ScheduleStatus(m.Status),
+		AppliedAt:   m.AppliedAt,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
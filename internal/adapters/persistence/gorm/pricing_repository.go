@@ -0,0 +1,195 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/pricing"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+type pricingRepository struct {
+	db *gorm.DB
+}
+
+// NewPricingRepository creates a new GORM implementation of pricing.Repository
+func NewPricingRepository(db *gorm.DB) pricing.Repository {
+	return &pricingRepository{db: db}
+}
+
+func (r *pricingRepository) CreatePriceList(pl *pricing.PriceList) error {
+	model := toPriceListModel(pl)
+	if err := r.db.Omit("Prices").Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to create price list in database. Name: %s, Error: %v", pl.Name, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*pl = *toPriceListDomain(model)
+	return nil
+}
+
+func (r *pricingRepository) GetPriceList(id string) (*pricing.PriceList, error) {
+	var model PriceListModel
+	err := r.db.
+		Preload("Prices", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		First(&model, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read price list in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toPriceListDomain(&model), nil
+}
+
+func (r *pricingRepository) ListPriceLists() ([]*pricing.PriceList, error) {
+	var models []PriceListModel
+	if err := r.db.Order("currency").Order("name").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list price lists in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	lists := make([]*pricing.PriceList, len(models))
+	for i := range models {
+		lists[i] = toPriceListDomain(&models[i])
+	}
+
+	return lists, nil
+}
+
+// SetPrice creates or replaces the entry for the product/variant pair
+func (r *pricingRepository) SetPrice(price *pricing.Price) error {
+	model := toPriceListPriceModel(price)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing PriceListPriceModel
+		err := priceEntry(tx, price.PriceListID, price.ProductID, price.VariantID).
+			Clauses(lockForUpdate()).
+			First(&existing).Error
+		if err == nil {
+			model.ID = existing.ID
+			model.CreatedAt = existing.CreatedAt
+			return tx.Model(&existing).Update("amount", model.Amount).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to set price in database. PriceListID: %s, ProductID: %s, Error: %v", price.PriceListID, price.ProductID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	price.ID = model.ID
+	price.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *pricingRepository) DeletePrice(priceListID, productID, variantID string) error {
+	result := priceEntry(r.db, priceListID, productID, variantID).Delete(&PriceListPriceModel{})
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to delete price in database. PriceListID: %s, ProductID: %s, Error: %v", priceListID, productID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *pricingRepository) FindPriceLists(productIDs []string, currency, customerGroup string) ([]*pricing.PriceList, error) {
+	var models []PriceListModel
+	err := r.db.
+		Preload("Prices", "product_id IN ?", productIDs).
+		Where("currency = ?", currency).
+		Where("customer_group = ? OR customer_group = ''", customerGroup).
+		// Group-specific lists win over lists for everyone
+		Order("customer_group = '' ASC").
+		Order("created_at").
+		Find(&models).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to find price lists in database. Currency: %s, Error: %v", currency, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	lists := make([]*pricing.PriceList, len(models))
+	for i := range models {
+		lists[i] = toPriceListDomain(&models[i])
+	}
+
+	return lists, nil
+}
+
+// priceEntry scopes a query to one price list entry; a NULL variant is the product-wide price
+func priceEntry(db *gorm.DB, priceListID, productID, variantID string) *gorm.DB {
+	query := db.Where("price_list_id = ? AND product_id = ?", priceListID, productID)
+	if variantID == "" {
+		return query.Where("variant_id IS NULL")
+	}
+	return query.Where("variant_id = ?", variantID)
+}
+
+// Mapping functions
+
+func toPriceListModel(pl *pricing.PriceList) *PriceListModel {
+	return &PriceListModel{
+		Base: Base{
+			ID:        pl.ID,
+			CreatedAt: pl.CreatedAt,
+			UpdatedAt: pl.UpdatedAt,
+		},
+		Name:          pl.Name,
+		Currency:      pl.Currency,
+		CustomerGroup: pl.CustomerGroup,
+	}
+}
+
+func toPriceListDomain(m *PriceListModel) *pricing.PriceList {
+	prices := make([]pricing.Price, len(m.Prices))
+	for i := range m.Prices {
+		prices[i] = *toPriceDomain(&m.Prices[i], m.Currency)
+	}
+
+	return &pricing.PriceList{
+		ID:            m.ID,
+		Name:          m.Name,
+		Currency:      m.Currency,
+		CustomerGroup: m.CustomerGroup,
+		Prices:        prices,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func toPriceListPriceModel(p *pricing.Price) *PriceListPriceModel {
+	return &PriceListPriceModel{
+		Base: Base{
+			ID:        p.ID,
+			CreatedAt: p.CreatedAt,
+			UpdatedAt: p.UpdatedAt,
+		},
+		PriceListID: p.PriceListID,
+		ProductID:   p.ProductID,
+		VariantID:   nullableID(p.VariantID),
+		Amount:      p.Amount.Amount,
+	}
+}
+
+func toPriceDomain(m *PriceListPriceModel, currency string) *pricing.Price {
+	return &pricing.Price{
+		ID:          m.ID,
+		PriceListID: m.PriceListID,
+		ProductID:   m.ProductID,
+		VariantID:   stringValue(m.VariantID),
+		Amount:      money.New(m.Amount, currency),
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
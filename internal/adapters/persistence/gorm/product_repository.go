@@ -2,11 +2,15 @@ package gorm
 
 import (
 	"errors"
+	"log"
 
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/category"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
 	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
 	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
 	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type productRepository struct {
@@ -26,16 +30,23 @@ func (r *productRepository) ListProducts(params pagination.Params, filters produ
 	query := r.db.Model(&ProductModel{})
 	query = applyProductFilters(query, filters)
 
-	// Count total records (with filters applied)
-	if err := query.Count(&totalCount).Error; err != nil {
-		return nil, 0, apperrors.ErrDatabaseError
+	// Count total records (with filters applied); cursor pages skip this unless asked
+	if params.NeedsTotal() {
+		if err := query.Count(&totalCount).Error; err != nil {
+			return nil, 0, apperrors.ErrDatabaseError
+		}
+	}
+
+	// Popularity orders; paginate breaks ties newest first
+	switch filters.Sort {
+	case product.SortMostLiked:
+		query = query.Order("products.like_count DESC")
+	case product.SortTopRated:
+		query = query.Order("products.rating_average DESC").Order("products.rating_count DESC")
 	}
 
 	// Apply pagination and fetch results
-	err := query.
-		Preload("Images").
-		Offset(params.Offset()).
-		Limit(params.Limit()).
+	err := paginate(preloadProductAssociations(query), params, "products").
 		Find(&models).Error
 
 	if err != nil {
@@ -50,21 +61,67 @@ func (r *productRepository) ListProducts(params pagination.Params, filters produ
 	return products, totalCount, nil
 }
 
+const categorySubtreeQuery = `
+WITH RECURSIVE subtree AS (
+	SELECT id FROM categories WHERE id = ? AND deleted_at IS NULL
+	UNION
+	SELECT c.id FROM categories c JOIN subtree ON c.parent_id = subtree.id WHERE c.deleted_at IS NULL
+)
+SELECT id FROM subtree`
+
+// effectivePriceColumn is the price charged now, mirroring
+// product.Product.EffectiveUnitPrice, so price filters match sale prices
+const effectivePriceColumn = `(CASE WHEN products.sale_price IS NOT NULL
+	AND products.sale_price < products.price
+	AND (products.sale_starts_at IS NULL OR products.sale_starts_at <= NOW())
+	AND (products.sale_ends_at IS NULL OR products.sale_ends_at > NOW())
+	THEN products.sale_price ELSE products.price END)`
+
 // applyProductFilters applies filters to the GORM query
 func applyProductFilters(query *gorm.DB, filters product.Filters) *gorm.DB {
-	// Filter by category
+	// Filter by category, including every category below it. UNION drops
+	// repeated ids, so the walk also terminates on a cyclic parent chain.
 	if filters.CategoryID != "" {
-		query = query.Where("category_id = ?", filters.CategoryID)
+		query = query.Where("products.category_id IN (?)", gorm.Expr(categorySubtreeQuery, filters.CategoryID))
 	}
 
 	// Filter by minimum price
 	if filters.MinPrice != nil {
-		query = query.Where("price >= ?", *filters.MinPrice)
+		query = query.Where(effectivePriceColumn+" >= ? AND products.currency = ?", filters.MinPrice.Amount, filters.MinPrice.Currency)
 	}
 
 	// Filter by maximum price
 	if filters.MaxPrice != nil {
-		query = query.Where("price <= ?", *filters.MaxPrice)
+		query = query.Where(effectivePriceColumn+" <= ? AND products.currency = ?", filters.MaxPrice.Amount, filters.MaxPrice.Currency)
+	}
+
+	// Filter by minimum average rating
+	if filters.MinRating != nil {
+		query = query.Where("rating_average >= ?", *filters.MinRating)
+	}
+
+	// Filter by brand
+	if filters.Brand != "" {
+		query = query.Where("LOWER(products.brand) = LOWER(?)", filters.Brand)
+	}
+
+	// Filter by specification values, one subquery per attribute
+	for _, f := range filters.Attributes {
+		specs := query.Session(&gorm.Session{NewDB: true}).
+			Table("product_specifications").
+			Select("product_specifications.product_id").
+			Joins("JOIN category_attributes ON category_attributes.id = product_specifications.attribute_id").
+			Where("category_attributes.key = ?", f.Key)
+		if f.Value != nil {
+			specs = specs.Where("LOWER(product_specifications.value) = LOWER(?)", *f.Value)
+		}
+		if f.Min != nil {
+			specs = specs.Where("product_specifications.number_value >= ?", *f.Min)
+		}
+		if f.Max != nil {
+			specs = specs.Where("product_specifications.number_value <= ?", *f.Max)
+		}
+		query = query.Where("products.id IN (?)", specs)
 	}
 
 	// Filter by disabled status
@@ -75,9 +132,25 @@ func applyProductFilters(query *gorm.DB, filters product.Filters) *gorm.DB {
 	return query
 }
 
+// preloadProductAssociations loads product-level images and the option/variant matrix
+func preloadProductAssociations(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Images", "variant_id IS NULL").
+		Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("position, created_at") }).
+		Preload("Options.Values", func(db *gorm.DB) *gorm.DB { return db.Order("position, created_at") }).
+		Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		Preload("Variants.OptionValues").
+		Preload("Variants.Images").
+		Preload("Specs", func(db *gorm.DB) *gorm.DB {
+			return db.Joins("JOIN category_attributes ON category_attributes.id = product_specifications.attribute_id").
+				Order("category_attributes.position, category_attributes.created_at")
+		}).
+		Preload("Specs.Attribute")
+}
+
 func (r *productRepository) GetProduct(id string) (*product.Product, error) {
 	var model ProductModel
-	if err := r.db.Preload("Images").First(&model, "id = ?", id).Error; err != nil {
+	if err := preloadProductAssociations(r.db).First(&model, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, apperrors.ErrNotFound
 		}
@@ -87,79 +160,386 @@ func (r *productRepository) GetProduct(id string) (*product.Product, error) {
 	return toProductDomain(&model), nil
 }
 
+func (r *productRepository) GetProductBySlug(slug string) (*product.Product, error) {
+	var model ProductModel
+	if err := preloadProductAssociations(r.db).First(&model, "slug = ?", slug).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toProductDomain(&model), nil
+}
+
+func (r *productRepository) FindProductIDBySlugHistory(slug string) (string, error) {
+	var ids []string
+	err := r.db.Model(&ProductSlugHistoryModel{}).
+		Joins("JOIN products ON products.id = product_slug_history.product_id AND products.deleted_at IS NULL").
+		Where("product_slug_history.slug = ?", slug).
+		Pluck("product_slug_history.product_id", &ids).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to read product slug history in database. Slug: %s, Error: %v", slug, err)
+		return "", apperrors.ErrDatabaseError
+	}
+
+	if len(ids) == 0 {
+		return "", apperrors.ErrNotFound
+	}
+	return ids[0], nil
+}
+
+func (r *productRepository) SlugTaken(slug, exceptProductID string) (bool, error) {
+	// Soft-deleted products keep their slug under the unique index
+	current := r.db.Unscoped().Model(&ProductModel{}).Where("slug = ?", slug)
+	history := r.db.Model(&ProductSlugHistoryModel{}).Where("slug = ?", slug)
+	if exceptProductID != "" {
+		current = current.Where("id <> ?", exceptProductID)
+		history = history.Where("product_id <> ?", exceptProductID)
+	}
+
+	var count int64
+	if err := current.Count(&count).Error; err != nil {
+		return false, apperrors.ErrDatabaseError
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	if err := history.Count(&count).Error; err != nil {
+		return false, apperrors.ErrDatabaseError
+	}
+	return count > 0, nil
+}
+
 func (r *productRepository) CreateProduct(p *product.Product) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return createProduct(tx, p, product.PriceChangeManual)
+	})
+}
+
+// createProduct inserts p and the first entry of its price history inside tx
+func createProduct(tx *gorm.DB, p *product.Product, source product.PriceChangeSource) error {
 	model := toProductModel(p)
-	if err := r.db.Create(model).Error; err != nil {
+	if err := tx.Create(model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return apperrors.ErrDuplicateEntry
+		}
 		return apperrors.ErrDatabaseError
 	}
 	p.ID = model.ID
 	p.CreatedAt = model.CreatedAt
 	p.UpdatedAt = model.UpdatedAt
+
+	if err := tx.Create(toPriceHistoryModel(model, nil, source, "")).Error; err != nil {
+		return apperrors.ErrDatabaseError
+	}
 	return nil
 }
 
 func (r *productRepository) UpdateProduct(p *product.Product) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return updateProduct(tx, p, product.PriceChangeManual)
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to update product in database. ID: %s, Error: %v", p.ID, err)
+		return err
+	}
+
+	return nil
+}
+
+// updateProduct writes the catalogue fields and specifications of p inside
+// tx, recording a price history entry when the price or sale changed
+func updateProduct(tx *gorm.DB, p *product.Product, source product.PriceChangeSource) error {
 	model := toProductModel(p)
-	if err := r.db.Model(&ProductModel{}).Where("id = ?", p.ID).Updates(model).Error; err != nil {
+
+	var previous ProductModel
+	err := tx.Select("id", "slug", "price", "currency", "sale_price", "sale_starts_at", "sale_ends_at").
+		Clauses(lockForUpdate()).
+		First(&previous, "id = ?", p.ID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrNotFound
+		}
+		return apperrors.ErrDatabaseError
+	}
+
+	if previous.Slug != p.Slug {
+		// Keep the old slug for redirects, and drop the new one from the
+		// history when the product returns to an earlier slug
+		old := &ProductSlugHistoryModel{ProductID: p.ID, Slug: previous.Slug}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(old).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+		if err := tx.Where("product_id = ? AND slug = ?", p.ID, p.Slug).Delete(&ProductSlugHistoryModel{}).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+	}
+
+	// Stock is owned by the inventory ledger and counters by their own
+	// writers, so only catalogue fields are written here
+	err = tx.Model(&ProductModel{}).Where("id = ?", p.ID).
+		Select(
+			"sku", "name", "slug", "price", "currency", "disabled", "category_id",
+			"sale_price", "sale_starts_at", "sale_ends_at",
+			"meta_title", "meta_description", "canonical_url",
+			"description", "brand", "tax_class", "weight_grams", "length_mm", "width_mm", "height_mm",
+			"gift_card",
+		).
+		Updates(model).Error
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return apperrors.ErrDuplicateEntry
+		}
+		return apperrors.ErrDatabaseError
+	}
+
+	if pricingChanged(&previous, model) {
+		if err := tx.Create(toPriceHistoryModel(model, &previous.Price, source, "")).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+	}
+
+	if err := tx.Where("product_id = ?", p.ID).Delete(&ProductSpecModel{}).Error; err != nil {
 		return apperrors.ErrDatabaseError
 	}
+	if len(model.Specs) > 0 {
+		if err := tx.Create(&model.Specs).Error; err != nil {
+			return apperrors.ErrDatabaseError
+		}
+	}
 	return nil
 }
 
+func (r *productRepository) ListProductsBySKU(skus []string) ([]*product.Product, error) {
+	var models []*ProductModel
+	if len(skus) == 0 {
+		return nil, nil
+	}
+
+	if err := r.db.Preload("Specs").Where("sku IN ?", skus).Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to read products by SKU in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	products := make([]*product.Product, len(models))
+	for i, model := range models {
+		products[i] = toProductDomain(model)
+	}
+	return products, nil
+}
+
+func (r *productRepository) ImportProducts(creates, updates []*product.Product) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range creates {
+			if err := createProduct(tx, p, product.PriceChangeImport); err != nil {
+				return err
+			}
+		}
+
+		for _, p := range updates {
+			if err := updateProduct(tx, p, product.PriceChangeImport); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to import products in database. Creates: %d, Updates: %d, Error: %v", len(creates), len(updates), err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) StreamProducts(filters product.Filters, batchSize int, fn func([]*product.Product) error) error {
+	var models []*ProductModel
+	query := applyProductFilters(r.db.Model(&ProductModel{}), filters).
+		Preload("Specs").
+		Preload("Specs.Attribute")
+
+	result := query.FindInBatches(&models, batchSize, func(tx *gorm.DB, batch int) error {
+		products := make([]*product.Product, len(models))
+		for i, model := range models {
+			products[i] = toProductDomain(model)
+		}
+		return fn(products)
+	})
+	if result.Error != nil {
+		if _, ok := result.Error.(*apperrors.AppError); !ok {
+			log.Printf("ERROR: Failed to stream products from database. Error: %v", result.Error)
+		}
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *productRepository) ListSitemapEntries(limit int) ([]*product.SitemapEntry, error) {
+	var models []*ProductModel
+	err := r.db.Select("slug", "canonical_url", "updated_at").
+		Where("disabled = ?", false).
+		Order("updated_at DESC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to read sitemap products in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	entries := make([]*product.SitemapEntry, len(models))
+	for i, m := range models {
+		entries[i] = &product.SitemapEntry{
+			Slug:         m.Slug,
+			CanonicalURL: m.SEO.CanonicalURL,
+			UpdatedAt:    m.UpdatedAt,
+		}
+	}
+	return entries, nil
+}
+
 // Mapping functions
 
 func toProductModel(p *product.Product) *ProductModel {
 	images := make([]ProductImageModel, len(p.Images))
-	for i, img := range p.Images {
-		images[i] = ProductImageModel{
-			Base: Base{
-				ID:        img.ID,
-				CreatedAt: img.CreatedAt,
-				UpdatedAt: img.UpdatedAt,
-			},
-			ProductID: img.ProductID,
-			URL:       img.URL,
-			AltText:   img.AltText,
+	for i := range p.Images {
+		images[i] = *toProductImageModel(&p.Images[i])
+	}
+
+	specs := make([]ProductSpecModel, len(p.Specifications))
+	for i, spec := range p.Specifications {
+		specs[i] = ProductSpecModel{
+			ProductID:   p.ID,
+			AttributeID: spec.AttributeID,
+			Value:       spec.Value,
+			NumberValue: spec.NumberValue,
 		}
 	}
 
+	salePrice, saleStartsAt, saleEndsAt := toSaleColumns(p.Sale)
+
 	return &ProductModel{
 		Base: Base{
 			ID:        p.ID,
 			CreatedAt: p.CreatedAt,
 			UpdatedAt: p.UpdatedAt,
 		},
-		Name:       p.Name,
-		Price:      p.Price,
-		Disabled:   p.Disabled,
-		Stock:      p.Stock,
-		CategoryID: p.CategoryID,
-		Images:     images,
+		SKU:          nullableID(p.SKU),
+		Name:         p.Name,
+		Slug:         p.Slug,
+		SEO:          toSEOColumns(p.SEO),
+		Description:  p.Description,
+		Brand:        p.Brand,
+		TaxClass:     p.TaxClass,
+		WeightGrams:  p.WeightGrams,
+		GiftCard:     p.GiftCard,
+		LengthMM:     p.Dimensions.LengthMM,
+		WidthMM:      p.Dimensions.WidthMM,
+		HeightMM:     p.Dimensions.HeightMM,
+		Price:        p.Price.Amount,
+		Currency:     p.Price.Currency,
+		SalePrice:    salePrice,
+		SaleStartsAt: saleStartsAt,
+		SaleEndsAt:   saleEndsAt,
+		Disabled:     p.Disabled,
+		Stock:        p.Stock,
+		LowStock:     p.LowStockThreshold,
+		CategoryID:   p.CategoryID,
+		Images:       images,
+		Specs:        specs,
 	}
 }
 
 func toProductDomain(m *ProductModel) *product.Product {
 	images := make([]product.ProductImage, len(m.Images))
-	for i, img := range m.Images {
-		images[i] = product.ProductImage{
+	for i := range m.Images {
+		images[i] = *toProductImageDomain(&m.Images[i])
+	}
+
+	options := make([]product.OptionType, len(m.Options))
+	for i := range m.Options {
+		options[i] = *toOptionTypeDomain(&m.Options[i])
+	}
+
+	variants := make([]product.Variant, len(m.Variants))
+	for i := range m.Variants {
+		variants[i] = *toVariantDomain(&m.Variants[i], m.Currency)
+	}
+
+	specs := make([]product.Specification, len(m.Specs))
+	for i, spec := range m.Specs {
+		specs[i] = product.Specification{
+			AttributeID: spec.AttributeID,
+			Value:       spec.Value,
+			NumberValue: spec.NumberValue,
+		}
+		if spec.Attribute != nil {
+			specs[i].Key = spec.Attribute.Key
+			specs[i].Label = spec.Attribute.Label
+			specs[i].Type = category.AttributeType(spec.Attribute.Type)
+			specs[i].Unit = spec.Attribute.Unit
+		}
+	}
+
+	return &product.Product{
+		ID:          m.ID,
+		SKU:         stringValue(m.SKU),
+		Name:        m.Name,
+		Slug:        m.Slug,
+		SEO:         toSEOMetadata(m.SEO),
+		Description: m.Description,
+		Brand:       m.Brand,
+		TaxClass:    m.TaxClass,
+		WeightGrams: m.WeightGrams,
+		GiftCard:    m.GiftCard,
+		Dimensions: product.Dimensions{
+			LengthMM: m.LengthMM,
+			WidthMM:  m.WidthMM,
+			HeightMM: m.HeightMM,
+		},
+		Price:             money.New(m.Price, m.Currency),
+		Sale:              toSaleDomain(m.SalePrice, m.SaleStartsAt, m.SaleEndsAt, m.Currency),
+		Disabled:          m.Disabled,
+		Stock:             m.Stock,
+		Reserved:          m.Reserved,
+		Available:         m.Stock - m.Reserved,
+		LowStockThreshold: m.LowStock,
+		LikeCount:         m.LikeCount,
+		RatingCount:       m.RatingCount,
+		RatingAverage:     m.RatingAverage,
+		CategoryID:        m.CategoryID,
+		Images:            images,
+		Options:           options,
+		Variants:          variants,
+		Specifications:    specs,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+	}
+}
+
+func toProductImageModel(img *product.ProductImage) *ProductImageModel {
+	return &ProductImageModel{
+		Base: Base{
 			ID:        img.ID,
-			ProductID: img.ProductID,
-			URL:       img.URL,
-			AltText:   img.AltText,
 			CreatedAt: img.CreatedAt,
 			UpdatedAt: img.UpdatedAt,
-		}
+		},
+		ProductID: img.ProductID,
+		VariantID: nullableID(img.VariantID),
+		URL:       img.URL,
+		AltText:   img.AltText,
 	}
+}
 
-	return &product.Product{
-		ID:         m.ID,
-		Name:       m.Name,
-		Price:      m.Price,
-		Disabled:   m.Disabled,
-		Stock:      m.Stock,
-		CategoryID: m.CategoryID,
-		Images:     images,
-		CreatedAt:  m.CreatedAt,
-		UpdatedAt:  m.UpdatedAt,
+func toProductImageDomain(m *ProductImageModel) *product.ProductImage {
+	return &product.ProductImage{
+		ID:        m.ID,
+		ProductID: m.ProductID,
+		VariantID: stringValue(m.VariantID),
+		URL:       m.URL,
+		AltText:   m.AltText,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
 	}
 }
@@ -0,0 +1,323 @@
+package gorm
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/promotion"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+// NewPromotionRepository creates a new GORM implementation of promotion.Repository
+func NewPromotionRepository(db *gorm.DB) promotion.Repository {
+	return &promotionRepository{db: db}
+}
+
+func (r *promotionRepository) CreatePromotion(p *promotion.Promotion) error {
+	model := toPromotionModel(p)
+	if err := r.db.Create(model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return apperrors.ErrDuplicateEntry
+		}
+		log.Printf("ERROR: Failed to create promotion in database. Name: %s, Error: %v", p.Name, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*p = *toPromotionDomain(model)
+	return nil
+}
+
+// UpdatePromotion writes the rules of the promotion; the usage count is only
+// changed by redemptions
+func (r *promotionRepository) UpdatePromotion(p *promotion.Promotion) error {
+	model := toPromotionModel(p)
+	result := r.db.Model(model).
+		Select("name", "code", "type", "percent", "amount", "buy_quantity", "get_quantity",
+			"min_order_value", "currency", "usage_limit", "per_customer_limit", "product_ids",
+			"category_ids", "starts_at", "ends_at", "active").
+		Updates(model)
+	if result.Error != nil {
+		if isDuplicateKeyError(result.Error) {
+			return apperrors.ErrDuplicateEntry
+		}
+		log.Printf("ERROR: Failed to update promotion in database. ID: %s, Error: %v", p.ID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	updated, err := r.GetPromotion(p.ID)
+	if err != nil {
+		return err
+	}
+
+	*p = *updated
+	return nil
+}
+
+func (r *promotionRepository) GetPromotion(id string) (*promotion.Promotion, error) {
+	return r.findPromotion(r.db.Where("id = ?", id))
+}
+
+func (r *promotionRepository) GetPromotionByCode(code string) (*promotion.Promotion, error) {
+	return r.findPromotion(r.db.Where("code = ?", code))
+}
+
+func (r *promotionRepository) findPromotion(query *gorm.DB) (*promotion.Promotion, error) {
+	var model PromotionModel
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read promotion in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toPromotionDomain(&model), nil
+}
+
+func (r *promotionRepository) ListPromotions() ([]*promotion.Promotion, error) {
+	return r.findPromotions(r.db.Order("created_at DESC"))
+}
+
+func (r *promotionRepository) ListAutomatic(now time.Time) ([]*promotion.Promotion, error) {
+	return r.findPromotions(r.db.
+		Where("code IS NULL AND active").
+		Where("starts_at IS NULL OR starts_at <= ?", now).
+		Where("ends_at IS NULL OR ends_at > ?", now).
+		Order("created_at"))
+}
+
+func (r *promotionRepository) findPromotions(query *gorm.DB) ([]*promotion.Promotion, error) {
+	var models []*PromotionModel
+	if err := query.Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list promotions in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	promotions := make([]*promotion.Promotion, len(models))
+	for i, model := range models {
+		promotions[i] = toPromotionDomain(model)
+	}
+	return promotions, nil
+}
+
+func (r *promotionRepository) DeletePromotion(id string) error {
+	result := r.db.Delete(&PromotionModel{}, "id = ?", id)
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to delete promotion in database. ID: %s, Error: %v", id, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *promotionRepository) CountRedemptions(promotionID, userID string) (int, error) {
+	var count int64
+	err := r.db.Model(&PromotionRedemptionModel{}).
+		Where("promotion_id = ? AND user_id = ?", promotionID, userID).
+		Count(&count).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to count promotion redemptions in database. PromotionID: %s, Error: %v", promotionID, err)
+		return 0, apperrors.ErrDatabaseError
+	}
+
+	return int(count), nil
+}
+
+func (r *promotionRepository) Redeem(redemptions []*promotion.Redemption) error {
+	// Promotion rows are locked in a fixed order so checkouts applying the
+	// same promotions cannot deadlock
+	ordered := make([]*promotion.Redemption, len(redemptions))
+	copy(ordered, redemptions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].PromotionID < ordered[j].PromotionID
+	})
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, redemption := range ordered {
+			var p PromotionModel
+			err := tx.Select("id", "usage_limit", "per_customer_limit", "usage_count").
+				Clauses(lockForUpdate()).
+				First(&p, "id = ?", redemption.PromotionID).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return promotion.ErrInvalidCode
+				}
+				log.Printf("ERROR: Failed to lock promotion in database. PromotionID: %s, Error: %v", redemption.PromotionID, err)
+				return apperrors.ErrDatabaseError
+			}
+
+			if p.UsageLimit > 0 && p.UsageCount >= p.UsageLimit {
+				return promotion.ErrUsageLimitReached
+			}
+			if p.PerCustomerLimit > 0 {
+				var used int64
+				err := tx.Model(&PromotionRedemptionModel{}).
+					Where("promotion_id = ? AND user_id = ?", p.ID, redemption.UserID).
+					Count(&used).Error
+				if err != nil {
+					log.Printf("ERROR: Failed to count promotion redemptions in database. PromotionID: %s, Error: %v", p.ID, err)
+					return apperrors.ErrDatabaseError
+				}
+				if int(used) >= p.PerCustomerLimit {
+					return promotion.ErrUsageLimitReached
+				}
+			}
+
+			model := toRedemptionModel(redemption)
+			if err := tx.Create(model).Error; err != nil {
+				log.Printf("ERROR: Failed to create promotion redemption in database. PromotionID: %s, Error: %v", p.ID, err)
+				return apperrors.ErrDatabaseError
+			}
+			err = tx.Model(&PromotionModel{}).Where("id = ?", p.ID).
+				Update("usage_count", gorm.Expr("usage_count + 1")).Error
+			if err != nil {
+				log.Printf("ERROR: Failed to update promotion usage in database. PromotionID: %s, Error: %v", p.ID, err)
+				return apperrors.ErrDatabaseError
+			}
+
+			redemption.ID = model.ID
+			redemption.CreatedAt = model.CreatedAt
+		}
+		return nil
+	})
+}
+
+func (r *promotionRepository) ReleaseRedemptions(orderID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var models []*PromotionRedemptionModel
+		if err := tx.Where("order_id = ?", orderID).Find(&models).Error; err != nil {
+			return err
+		}
+
+		for _, m := range models {
+			if err := tx.Delete(m).Error; err != nil {
+				return err
+			}
+			err := tx.Model(&PromotionModel{}).Where("id = ? AND usage_count > 0", m.PromotionID).
+				Update("usage_count", gorm.Expr("usage_count - 1")).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to release promotion redemptions in database. OrderID: %s, Error: %v", orderID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+// Mapping functions
+
+func toPromotionModel(p *promotion.Promotion) *PromotionModel {
+	productIDs, categoryIDs := p.ProductIDs, p.CategoryIDs
+	if productIDs == nil {
+		productIDs = []string{}
+	}
+	if categoryIDs == nil {
+		categoryIDs = []string{}
+	}
+	encodedProducts, _ := json.Marshal(productIDs)
+	encodedCategories, _ := json.Marshal(categoryIDs)
+
+	model := &PromotionModel{
+		Base: Base{
+			ID:        p.ID,
+			CreatedAt: p.CreatedAt,
+			UpdatedAt: p.UpdatedAt,
+		},
+		Name:             p.Name,
+		Code:             nullableID(p.Code),
+		Type:             string(p.Type),
+		Percent:          p.Percent,
+		BuyQuantity:      p.BuyQuantity,
+		GetQuantity:      p.GetQuantity,
+		UsageLimit:       p.UsageLimit,
+		PerCustomerLimit: p.PerCustomerLimit,
+		UsageCount:       p.UsageCount,
+		ProductIDs:       string(encodedProducts),
+		CategoryIDs:      string(encodedCategories),
+		StartsAt:         p.StartsAt,
+		EndsAt:           p.EndsAt,
+		Active:           p.Active,
+	}
+	if p.Amount != nil {
+		model.Amount = &p.Amount.Amount
+		model.Currency = p.Amount.Currency
+	}
+	if p.MinOrderValue != nil {
+		model.MinOrderValue = &p.MinOrderValue.Amount
+		model.Currency = p.MinOrderValue.Currency
+	}
+	return model
+}
+
+func toPromotionDomain(m *PromotionModel) *promotion.Promotion {
+	var productIDs, categoryIDs []string
+	if err := json.Unmarshal([]byte(m.ProductIDs), &productIDs); err != nil {
+		log.Printf("ERROR: Invalid product targets on promotion. ID: %s, Error: %v", m.ID, err)
+	}
+	if err := json.Unmarshal([]byte(m.CategoryIDs), &categoryIDs); err != nil {
+		log.Printf("ERROR: Invalid category targets on promotion. ID: %s, Error: %v", m.ID, err)
+	}
+
+	p := &promotion.Promotion{
+		ID:               m.ID,
+		Name:             m.Name,
+		Code:             stringValue(m.Code),
+		Type:             promotion.Type(m.Type),
+		Percent:          m.Percent,
+		BuyQuantity:      m.BuyQuantity,
+		GetQuantity:      m.GetQuantity,
+		UsageLimit:       m.UsageLimit,
+		PerCustomerLimit: m.PerCustomerLimit,
+		UsageCount:       m.UsageCount,
+		ProductIDs:       productIDs,
+		CategoryIDs:      categoryIDs,
+		StartsAt:         m.StartsAt,
+		EndsAt:           m.EndsAt,
+		Active:           m.Active,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+	if m.Amount != nil {
+		amount := money.New(*m.Amount, m.Currency)
+		p.Amount = &amount
+	}
+	if m.MinOrderValue != nil {
+		minOrderValue := money.New(*m.MinOrderValue, m.Currency)
+		p.MinOrderValue = &minOrderValue
+	}
+	return p
+}
+
+func toRedemptionModel(r *promotion.Redemption) *PromotionRedemptionModel {
+	return &PromotionRedemptionModel{
+		ID:          r.ID,
+		CreatedAt:   r.CreatedAt,
+		PromotionID: r.PromotionID,
+		UserID:      nullableID(r.UserID),
+		OrderID:     r.OrderID,
+		Amount:      r.Amount.Amount,
+		Currency:    r.Amount.Currency,
+	}
+}
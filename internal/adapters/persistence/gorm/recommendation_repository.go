@@ -0,0 +1,283 @@
+package gorm
+
+import (
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/recommendation"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+// insertBatchSize bounds the rows per INSERT when replacing computed tables
+const insertBatchSize = 500
+
+type recommendationRepository struct {
+	db *gorm.DB
+}
+
+// NewRecommendationRepository creates a new GORM implementation of recommendation.Repository
+func NewRecommendationRepository(db *gorm.DB) recommendation.Repository {
+	return &recommendationRepository{db: db}
+}
+
+func (r *recommendationRepository) CreateLink(link *recommendation.Link) error {
+	model := &ProductLinkModel{
+		ProductID: link.ProductID,
+		TargetID:  link.TargetID,
+		Type:      string(link.Type),
+		Position:  link.Position,
+	}
+	if err := r.db.Create(model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return apperrors.ErrDuplicateEntry
+		}
+		log.Printf("ERROR: Failed to create product link in database. ProductID: %s, Error: %v", link.ProductID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*link = *toLinkDomain(model)
+	return nil
+}
+
+func (r *recommendationRepository) DeleteLink(productID, linkID string) error {
+	result := r.db.Where("id = ? AND product_id = ?", linkID, productID).Delete(&ProductLinkModel{})
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to delete product link in database. ID: %s, Error: %v", linkID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *recommendationRepository) ListLinks(productID string) ([]*recommendation.Link, error) {
+	return r.findLinks(r.db.Where("product_id = ?", productID))
+}
+
+func (r *recommendationRepository) ListAllLinks() ([]*recommendation.Link, error) {
+	return r.findLinks(r.db)
+}
+
+func (r *recommendationRepository) findLinks(query *gorm.DB) ([]*recommendation.Link, error) {
+	var models []*ProductLinkModel
+	if err := query.Order("type, position, created_at").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list product links in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	links := make([]*recommendation.Link, len(models))
+	for i, model := range models {
+		links[i] = toLinkDomain(model)
+	}
+	return links, nil
+}
+
+// coPurchaseQuery counts, per ordered pair of distinct products, the paid
+// orders containing both, and keeps the top pairs of each product
+const coPurchaseQuery = `
+WITH pairs AS (
+	SELECT a.product_id, b.product_id AS related_id, COUNT(DISTINCT a.order_id) AS orders
+	FROM order_lines a
+	JOIN order_lines b ON b.order_id = a.order_id AND b.product_id <> a.product_id AND b.deleted_at IS NULL
+	JOIN orders o ON o.id = a.order_id AND o.deleted_at IS NULL
+	WHERE a.deleted_at IS NULL AND o.status IN ? AND o.created_at >= ?
+	GROUP BY a.product_id, b.product_id
+	HAVING COUNT(DISTINCT a.order_id) >= ?
+), ranked AS (
+	SELECT product_id, related_id, orders,
+		ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY orders DESC, related_id) AS rank
+	FROM pairs
+)
+SELECT product_id, related_id, orders FROM ranked WHERE rank <= ?`
+
+func (r *recommendationRepository) ComputeCoPurchases(since time.Time, minOrders, limit int) ([]recommendation.CoPurchase, error) {
+	var pairs []recommendation.CoPurchase
+	err := r.db.Raw(coPurchaseQuery, order.PaidStatuses, since, minOrders, limit).Scan(&pairs).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to compute co-purchases in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+	return pairs, nil
+}
+
+func (r *recommendationRepository) ReplaceCoPurchases(pairs []recommendation.CoPurchase) error {
+	now := time.Now()
+	models := make([]*CoPurchaseModel, len(pairs))
+	for i, p := range pairs {
+		models[i] = &CoPurchaseModel{ProductID: p.ProductID, RelatedID: p.RelatedID, Orders: p.Orders, ComputedAt: now}
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&CoPurchaseModel{}).Error; err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(models, insertBatchSize).Error
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to replace co-purchases in database. Pairs: %d, Error: %v", len(pairs), err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+func (r *recommendationRepository) ListCoPurchases(productID string) ([]recommendation.CoPurchase, error) {
+	var models []*CoPurchaseModel
+	if err := r.db.Where("product_id = ?", productID).Order("orders DESC").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list co-purchases in database. ProductID: %s, Error: %v", productID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	pairs := make([]recommendation.CoPurchase, len(models))
+	for i, m := range models {
+		pairs[i] = recommendation.CoPurchase{ProductID: m.ProductID, RelatedID: m.RelatedID, Orders: m.Orders}
+	}
+	return pairs, nil
+}
+
+func (r *recommendationRepository) ReplaceRecommendations(lists map[string][]*recommendation.Recommendation) error {
+	productIDs := make([]string, 0, len(lists))
+	for productID := range lists {
+		productIDs = append(productIDs, productID)
+	}
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id IN ?", productIDs).Delete(&RecommendationModel{}).Error; err != nil {
+			return err
+		}
+		return insertRecommendations(tx, lists)
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to replace recommendations in database. Products: %d, Error: %v", len(productIDs), err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+func (r *recommendationRepository) ReplaceAllRecommendations(lists map[string][]*recommendation.Recommendation) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&RecommendationModel{}).Error; err != nil {
+			return err
+		}
+		return insertRecommendations(tx, lists)
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to replace all recommendations in database. Error: %v", err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+func insertRecommendations(tx *gorm.DB, lists map[string][]*recommendation.Recommendation) error {
+	now := time.Now()
+	var models []*RecommendationModel
+	for _, recs := range lists {
+		for _, rec := range recs {
+			models = append(models, &RecommendationModel{
+				ProductID:     rec.ProductID,
+				RecommendedID: rec.RecommendedID,
+				Type:          string(rec.Type),
+				Source:        string(rec.Source),
+				Score:         rec.Score,
+				Position:      rec.Position,
+				ComputedAt:    now,
+			})
+		}
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(models, insertBatchSize).Error
+}
+
+// availableProductCondition keeps enabled products with stock to sell on the
+// product itself or on an enabled variant
+const availableProductCondition = `products.deleted_at IS NULL AND NOT products.disabled AND (
+	products.stock - products.reserved > 0 OR EXISTS (
+		SELECT 1 FROM product_variants v
+		WHERE v.product_id = products.id AND v.deleted_at IS NULL AND NOT v.disabled AND v.stock - v.reserved > 0
+	)
+)`
+
+func (r *recommendationRepository) ListRecommendations(productID string, limit int) ([]*recommendation.Recommendation, error) {
+	var models []*RecommendationModel
+	err := r.db.Select("product_recommendations.*").
+		Joins("JOIN products ON products.id = product_recommendations.recommended_id").
+		Where("product_recommendations.product_id = ?", productID).
+		Where(availableProductCondition).
+		Order("product_recommendations.position").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to list recommendations in database. ProductID: %s, Error: %v", productID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(models))
+	for i, m := range models {
+		ids[i] = m.RecommendedID
+	}
+
+	var products []*ProductModel
+	if err := preloadProductAssociations(r.db.Model(&ProductModel{})).Where("id IN ?", ids).Find(&products).Error; err != nil {
+		log.Printf("ERROR: Failed to load recommended products in database. ProductID: %s, Error: %v", productID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+	byID := make(map[string]*ProductModel, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+
+	recs := make([]*recommendation.Recommendation, 0, len(models))
+	for _, m := range models {
+		p, ok := byID[m.RecommendedID]
+		if !ok {
+			continue
+		}
+		rec := toRecommendationDomain(m)
+		rec.Product = toProductDomain(p)
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+// Mapping functions
+
+func toLinkDomain(m *ProductLinkModel) *recommendation.Link {
+	return &recommendation.Link{
+		ID:        m.ID,
+		ProductID: m.ProductID,
+		TargetID:  m.TargetID,
+		Type:      recommendation.LinkType(m.Type),
+		Position:  m.Position,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func toRecommendationDomain(m *RecommendationModel) *recommendation.Recommendation {
+	return &recommendation.Recommendation{
+		ProductID:     m.ProductID,
+		RecommendedID: m.RecommendedID,
+		Type:          recommendation.LinkType(m.Type),
+		Source:        recommendation.Source(m.Source),
+		Score:         m.Score,
+		Position:      m.Position,
+	}
+}
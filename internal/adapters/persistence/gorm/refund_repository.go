@@ -0,0 +1,183 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+type refundRepository struct {
+	db *gorm.DB
+}
+
+// NewRefundRepository creates a new GORM implementation of order.RefundRepository
+func NewRefundRepository(db *gorm.DB) order.RefundRepository {
+	return &refundRepository{db: db}
+}
+
+func (r *refundRepository) ListRefunds(orderID string) ([]*order.Refund, error) {
+	var models []*RefundModel
+	if err := r.db.Where("order_id = ?", orderID).Order("created_at").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list refunds in database. OrderID: %s, Error: %v", orderID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	refunds := make([]*order.Refund, len(models))
+	for i, model := range models {
+		refunds[i] = toRefundDomain(model)
+	}
+	return refunds, nil
+}
+
+func (r *refundRepository) GetRefund(id string) (*order.Refund, error) {
+	var model RefundModel
+	if err := r.db.First(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read refund in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toRefundDomain(&model), nil
+}
+
+func (r *refundRepository) CreateRefund(refund *order.Refund) error {
+	model := toRefundModel(refund)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Locking the order serialises refunds so two of them cannot both fit
+		// under the total
+		var o OrderModel
+		if err := tx.Select("id", "total").Clauses(lockForUpdate()).First(&o, "id = ?", refund.OrderID).Error; err != nil {
+			return err
+		}
+
+		var committed int64
+		err := tx.Model(&RefundModel{}).
+			Where("order_id = ? AND status IN ?", refund.OrderID, []string{string(order.RefundPending), string(order.RefundSucceeded)}).
+			Select("COALESCE(SUM(amount), 0)").
+			Scan(&committed).Error
+		if err != nil {
+			return err
+		}
+		if committed+refund.Amount.Amount > o.Total {
+			return order.ErrRefundExceedsTotal
+		}
+
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		if err == order.ErrRefundExceedsTotal {
+			return err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to create refund in database. OrderID: %s, Error: %v", refund.OrderID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*refund = *toRefundDomain(model)
+	return nil
+}
+
+func (r *refundRepository) CompleteRefund(id, providerID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var refund RefundModel
+		if err := tx.Clauses(lockForUpdate()).First(&refund, "id = ? AND status = ?", id, string(order.RefundPending)).Error; err != nil {
+			return err
+		}
+
+		var o OrderModel
+		if err := tx.Select("id", "total", "refunded").Clauses(lockForUpdate()).First(&o, "id = ?", refund.OrderID).Error; err != nil {
+			return err
+		}
+
+		err := tx.Model(&RefundModel{}).Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"status":      string(order.RefundSucceeded),
+				"provider_id": providerID,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		refunded := o.Refunded + refund.Amount
+		status := order.StatusPartiallyRefunded
+		if refunded >= o.Total {
+			status = order.StatusRefunded
+		}
+		return tx.Model(&OrderModel{}).Where("id = ?", o.ID).
+			Updates(map[string]interface{}{
+				"refunded": refunded,
+				"status":   string(status),
+			}).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to complete refund in database. ID: %s, Error: %v", id, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+func (r *refundRepository) FailRefund(id string) error {
+	result := r.db.Model(&RefundModel{}).
+		Where("id = ? AND status = ?", id, string(order.RefundPending)).
+		Update("status", string(order.RefundFailed))
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to mark refund failed in database. ID: %s, Error: %v", id, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Mapping functions
+
+func toRefundModel(r *order.Refund) *RefundModel {
+	return &RefundModel{
+		Base: Base{
+			ID:        r.ID,
+			CreatedAt: r.CreatedAt,
+			UpdatedAt: r.UpdatedAt,
+		},
+		OrderID:     r.OrderID,
+		ReturnID:    nullableID(r.ReturnID),
+		Amount:      r.Amount.Amount,
+		StoreCredit: r.StoreCredit.Amount,
+		Currency:    r.Amount.Currency,
+		Reason:      r.Reason,
+		Status:      string(r.Status),
+		ProviderID:  r.ProviderID,
+		ActorID:     nullableID(r.ActorID),
+	}
+}
+
+func toRefundDomain(m *RefundModel) *order.Refund {
+	return &order.Refund{
+		ID:          m.ID,
+		OrderID:     m.OrderID,
+		ReturnID:    stringValue(m.ReturnID),
+		Amount:      money.New(m.Amount, m.Currency),
+		StoreCredit: money.New(m.StoreCredit, m.Currency),
+		Reason:      m.Reason,
+		Status:      order.RefundStatus(m.Status),
+		ProviderID:  m.ProviderID,
+		ActorID:     stringValue(m.ActorID),
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
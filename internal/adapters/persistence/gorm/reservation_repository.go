@@ -0,0 +1,214 @@
+package gorm
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/inventory"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+type reservationRepository struct {
+	db *gorm.DB
+}
+
+// NewReservationRepository creates a new GORM implementation of inventory.ReservationRepository
+func NewReservationRepository(db *gorm.DB) inventory.ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+func (r *reservationRepository) Reserve(reservations []*inventory.Reservation) error {
+	// Stock rows are locked in a fixed order, here and when reservations are
+	// converted or released, so checkouts of the same products cannot
+	// deadlock
+	ordered := make([]*inventory.Reservation, len(reservations))
+	copy(ordered, reservations)
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].ProductID != ordered[j].ProductID {
+			return ordered[i].ProductID < ordered[j].ProductID
+		}
+		return ordered[i].VariantID < ordered[j].VariantID
+	})
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, res := range ordered {
+			// Guarded increment: only reserve what is still available to sell
+			result := stockTarget(tx, res.ProductID, res.VariantID).
+				Where("stock - reserved >= ?", res.Quantity).
+				Update("reserved", gorm.Expr("reserved + ?", res.Quantity))
+			if result.Error != nil {
+				log.Printf("ERROR: Failed to reserve stock in database. ProductID: %s, Error: %v", res.ProductID, result.Error)
+				return apperrors.ErrDatabaseError
+			}
+			if result.RowsAffected == 0 {
+				return apperrors.ErrInsufficientStock
+			}
+
+			if err := recordHold(tx, res.ProductID, res.VariantID, res.Quantity, "checkout", res.OrderID); err != nil {
+				return err
+			}
+
+			res.Status = inventory.ReservationActive
+			model := toStockReservationModel(res)
+			if err := tx.Create(model).Error; err != nil {
+				log.Printf("ERROR: Failed to create reservation in database. OrderID: %s, Error: %v", res.OrderID, err)
+				return apperrors.ErrDatabaseError
+			}
+			res.ID = model.ID
+			res.CreatedAt = model.CreatedAt
+			res.UpdatedAt = model.UpdatedAt
+		}
+		return nil
+	})
+}
+
+func (r *reservationRepository) ConvertReservations(orderID string) ([]*inventory.Movement, error) {
+	var sales []*inventory.Movement
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		reservations, err := activeReservations(tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		for _, res := range reservations {
+			if err := releaseHold(tx, res, "order paid"); err != nil {
+				return err
+			}
+
+			sale := &inventory.Movement{
+				ProductID: res.ProductID,
+				VariantID: stringValue(res.VariantID),
+				Type:      inventory.MovementSale,
+				Quantity:  -res.Quantity,
+				Reason:    "order paid",
+				Reference: orderID,
+			}
+			if err := recordMovement(tx, sale); err != nil {
+				return err
+			}
+			sales = append(sales, sale)
+		}
+
+		return setReservationStatus(tx, orderID, inventory.ReservationConverted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sales, nil
+}
+
+func (r *reservationRepository) ReleaseReservations(orderID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		reservations, err := activeReservations(tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		for _, res := range reservations {
+			if err := releaseHold(tx, res, "reservation released"); err != nil {
+				return err
+			}
+		}
+
+		return setReservationStatus(tx, orderID, inventory.ReservationReleased)
+	})
+}
+
+func (r *reservationRepository) ListExpiredOrderIDs(now time.Time) ([]string, error) {
+	var orderIDs []string
+	err := r.db.Model(&StockReservationModel{}).
+		Where("status = ? AND expires_at <= ?", string(inventory.ReservationActive), now).
+		Distinct().
+		Pluck("order_id", &orderIDs).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to read expired reservations in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return orderIDs, nil
+}
+
+// activeReservations locks and returns an order's active reservations, in the
+// order Reserve locks their stock
+func activeReservations(tx *gorm.DB, orderID string) ([]StockReservationModel, error) {
+	var models []StockReservationModel
+	err := tx.Clauses(lockForUpdate()).
+		Where("order_id = ? AND status = ?", orderID, string(inventory.ReservationActive)).
+		Order("product_id, variant_id NULLS FIRST").
+		Find(&models).Error
+	if err != nil {
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return models, nil
+}
+
+func releaseHold(tx *gorm.DB, res StockReservationModel, reason string) error {
+	variantID := stringValue(res.VariantID)
+	err := stockTarget(tx, res.ProductID, variantID).
+		Update("reserved", gorm.Expr("GREATEST(reserved - ?, 0)", res.Quantity)).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to release reserved stock in database. ProductID: %s, Error: %v", res.ProductID, err)
+		return apperrors.ErrDatabaseError
+	}
+	return recordHold(tx, res.ProductID, variantID, -res.Quantity, reason, res.OrderID)
+}
+
+// recordHold writes the ledger entry of a change to the reserved stock; the
+// stock on hand is left as it is
+func recordHold(tx *gorm.DB, productID, variantID string, quantity int, reason, orderID string) error {
+	var stock int
+	if err := stockTarget(tx, productID, variantID).Select("stock").Scan(&stock).Error; err != nil {
+		log.Printf("ERROR: Failed to read stock in database. ProductID: %s, Error: %v", productID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	movementType := inventory.MovementReservation
+	if quantity < 0 {
+		movementType = inventory.MovementRelease
+	}
+	model := toStockMovementModel(&inventory.Movement{
+		ProductID:  productID,
+		VariantID:  variantID,
+		Type:       movementType,
+		Quantity:   quantity,
+		StockAfter: stock,
+		Reason:     reason,
+		Reference:  orderID,
+	})
+	if err := tx.Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to record stock movement in database. ProductID: %s, Error: %v", productID, err)
+		return apperrors.ErrDatabaseError
+	}
+	return nil
+}
+
+func setReservationStatus(tx *gorm.DB, orderID string, status inventory.ReservationStatus) error {
+	err := tx.Model(&StockReservationModel{}).
+		Where("order_id = ? AND status = ?", orderID, string(inventory.ReservationActive)).
+		Update("status", string(status)).Error
+	if err != nil {
+		return apperrors.ErrDatabaseError
+	}
+	return nil
+}
+
+// Mapping functions
+
+func toStockReservationModel(res *inventory.Reservation) *StockReservationModel {
+	return &StockReservationModel{
+		ID:        res.ID,
+		CreatedAt: res.CreatedAt,
+		UpdatedAt: res.UpdatedAt,
+		ProductID: res.ProductID,
+		VariantID: nullableID(res.VariantID),
+		OrderID:   res.OrderID,
+		Quantity:  res.Quantity,
+		Status:    string(res.Status),
+		ExpiresAt: res.ExpiresAt,
+	}
+}
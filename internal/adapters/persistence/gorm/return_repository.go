@@ -0,0 +1,216 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/rma"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+type returnRepository struct {
+	db *gorm.DB
+}
+
+// NewReturnRepository creates a new GORM implementation of rma.Repository
+func NewReturnRepository(db *gorm.DB) rma.Repository {
+	return &returnRepository{db: db}
+}
+
+func (r *returnRepository) ListReturns(params pagination.Params, filters rma.Filters) ([]*rma.Return, int64, error) {
+	var models []*ReturnModel
+	var totalCount int64
+
+	query := r.db.Model(&ReturnModel{})
+	if filters.OrderID != "" {
+		query = query.Where("order_id = ?", filters.OrderID)
+	}
+	if filters.UserID != "" {
+		query = query.Where("user_id = ?", filters.UserID)
+	}
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+
+	if params.NeedsTotal() {
+		if err := query.Count(&totalCount).Error; err != nil {
+			return nil, 0, apperrors.ErrDatabaseError
+		}
+	}
+
+	if err := paginate(query.Preload("Lines"), params, "returns").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to read returns in database. Error: %v", err)
+		return nil, 0, apperrors.ErrDatabaseError
+	}
+
+	returns := make([]*rma.Return, len(models))
+	for i, model := range models {
+		returns[i] = toReturnDomain(model)
+	}
+
+	return returns, totalCount, nil
+}
+
+func (r *returnRepository) GetReturn(id string) (*rma.Return, error) {
+	var model ReturnModel
+	if err := r.db.Preload("Lines").First(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, rma.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read return in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toReturnDomain(&model), nil
+}
+
+func (r *returnRepository) CreateReturn(ret *rma.Return) error {
+	model := toReturnModel(ret)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Locking the order serialises returns so two requests cannot both
+		// claim the last units of a line
+		var o OrderModel
+		if err := tx.Select("id").Clauses(lockForUpdate()).First(&o, "id = ?", ret.OrderID).Error; err != nil {
+			return err
+		}
+
+		for _, line := range ret.Lines {
+			var bought int
+			err := tx.Model(&OrderLineModel{}).
+				Where("id = ? AND order_id = ?", line.OrderLineID, ret.OrderID).
+				Select("quantity").
+				Scan(&bought).Error
+			if err != nil {
+				return err
+			}
+
+			var returned int
+			err = tx.Model(&ReturnLineModel{}).
+				Joins("JOIN returns ON returns.id = return_lines.return_id").
+				Where("return_lines.order_line_id = ? AND returns.status <> ? AND returns.deleted_at IS NULL",
+					line.OrderLineID, string(rma.StatusRejected)).
+				Select("COALESCE(SUM(return_lines.quantity), 0)").
+				Scan(&returned).Error
+			if err != nil {
+				return err
+			}
+
+			if returned+line.Quantity > bought {
+				return rma.ErrQuantityExceeded
+			}
+		}
+
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		if err == rma.ErrQuantityExceeded {
+			return err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to create return in database. OrderID: %s, Error: %v", ret.OrderID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*ret = *toReturnDomain(model)
+	return nil
+}
+
+func (r *returnRepository) Transition(ret *rma.Return, from []rma.Status) (bool, error) {
+	result := r.db.Model(&ReturnModel{}).
+		Where("id = ? AND status IN ?", ret.ID, from).
+		Updates(map[string]interface{}{
+			"status":     string(ret.Status),
+			"resolution": ret.Resolution,
+			"refund_id":  nullableID(ret.RefundID),
+		})
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to transition return in database. ID: %s, Error: %v", ret.ID, result.Error)
+		return false, apperrors.ErrDatabaseError
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *returnRepository) MarkReceived(id string, at time.Time) (bool, error) {
+	result := r.db.Model(&ReturnModel{}).
+		Where("id = ? AND status IN ? AND received_at IS NULL", id, rma.ReceivableStatuses).
+		Updates(map[string]interface{}{
+			"status": gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END",
+				string(rma.StatusApproved), string(rma.StatusReceived)),
+			"received_at": at,
+		})
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to mark return received in database. ID: %s, Error: %v", id, result.Error)
+		return false, apperrors.ErrDatabaseError
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Mapping functions
+
+func toReturnModel(ret *rma.Return) *ReturnModel {
+	lines := make([]ReturnLineModel, len(ret.Lines))
+	for i, l := range ret.Lines {
+		lines[i] = ReturnLineModel{
+			ID:          l.ID,
+			ReturnID:    l.ReturnID,
+			OrderLineID: l.OrderLineID,
+			ProductID:   l.ProductID,
+			VariantID:   nullableID(l.VariantID),
+			Quantity:    l.Quantity,
+			Reason:      string(l.Reason),
+		}
+	}
+
+	return &ReturnModel{
+		Base: Base{
+			ID:        ret.ID,
+			CreatedAt: ret.CreatedAt,
+			UpdatedAt: ret.UpdatedAt,
+		},
+		OrderID:    ret.OrderID,
+		UserID:     ret.UserID,
+		Status:     string(ret.Status),
+		Comment:    ret.Comment,
+		Resolution: ret.Resolution,
+		RefundID:   nullableID(ret.RefundID),
+		ReceivedAt: ret.ReceivedAt,
+		Lines:      lines,
+	}
+}
+
+func toReturnDomain(m *ReturnModel) *rma.Return {
+	lines := make([]rma.Line, len(m.Lines))
+	for i, l := range m.Lines {
+		lines[i] = rma.Line{
+			ID:          l.ID,
+			ReturnID:    l.ReturnID,
+			OrderLineID: l.OrderLineID,
+			ProductID:   l.ProductID,
+			VariantID:   stringValue(l.VariantID),
+			Quantity:    l.Quantity,
+			Reason:      rma.Reason(l.Reason),
+		}
+	}
+
+	return &rma.Return{
+		ID:         m.ID,
+		OrderID:    m.OrderID,
+		UserID:     m.UserID,
+		Status:     rma.Status(m.Status),
+		Comment:    m.Comment,
+		Resolution: m.Resolution,
+		RefundID:   stringValue(m.RefundID),
+		ReceivedAt: m.ReceivedAt,
+		Lines:      lines,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
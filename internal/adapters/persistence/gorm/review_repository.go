@@ -0,0 +1,189 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/review"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository creates a new GORM implementation of review.Repository
+func NewReviewRepository(db *gorm.DB) review.Repository {
+	return &reviewRepository{db: db}
+}
+
+func (r *reviewRepository) ListReviews(params pagination.Params, filters review.Filters) ([]*review.Review, int64, error) {
+	var models []*ReviewModel
+	var totalCount int64
+
+	query := r.db.Model(&ReviewModel{})
+	if filters.ProductID != "" {
+		query = query.Where("product_id = ?", filters.ProductID)
+	}
+	if filters.UserID != "" {
+		query = query.Where("user_id = ?", filters.UserID)
+	}
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+
+	if params.NeedsTotal() {
+		if err := query.Count(&totalCount).Error; err != nil {
+			return nil, 0, apperrors.ErrDatabaseError
+		}
+	}
+
+	if err := paginate(query, params, "reviews").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to read reviews in database. Error: %v", err)
+		return nil, 0, apperrors.ErrDatabaseError
+	}
+
+	reviews := make([]*review.Review, len(models))
+	for i, model := range models {
+		reviews[i] = toReviewDomain(model)
+	}
+
+	return reviews, totalCount, nil
+}
+
+func (r *reviewRepository) GetReview(id string) (*review.Review, error) {
+	var model ReviewModel
+	if err := r.db.First(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read review in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toReviewDomain(&model), nil
+}
+
+func (r *reviewRepository) CreateReview(rv *review.Review) error {
+	model := toReviewModel(rv)
+	if err := r.db.Create(model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return review.ErrAlreadyReviewed
+		}
+		log.Printf("ERROR: Failed to create review in database. ProductID: %s, Error: %v", rv.ProductID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*rv = *toReviewDomain(model)
+	return nil
+}
+
+func (r *reviewRepository) Moderate(id string, from []review.Status, to review.Status, moderatorID string) (bool, error) {
+	moved := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var model ReviewModel
+		if err := tx.Clauses(lockForUpdate()).First(&model, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&ReviewModel{}).
+			Where("id = ? AND status IN ?", id, from).
+			Updates(map[string]interface{}{
+				"status":       to,
+				"moderated_by": nullableID(moderatorID),
+				"moderated_at": time.Now(),
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		moved = true
+
+		return refreshProductRating(tx, model.ProductID)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, apperrors.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to moderate review in database. ID: %s, Error: %v", id, err)
+		return false, apperrors.ErrDatabaseError
+	}
+
+	return moved, nil
+}
+
+func (r *reviewRepository) AddHelpfulVote(reviewID, userID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&ReviewVoteModel{ReviewID: reviewID, UserID: userID})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		return tx.Model(&ReviewModel{}).
+			Where("id = ?", reviewID).
+			Update("helpful_count", gorm.Expr("helpful_count + 1")).Error
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to record helpful vote in database. ReviewID: %s, Error: %v", reviewID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	return nil
+}
+
+// refreshProductRating recomputes the product's rating aggregates from its approved reviews
+func refreshProductRating(tx *gorm.DB, productID string) error {
+	approved := tx.Model(&ReviewModel{}).Where("product_id = ? AND status = ?", productID, review.StatusApproved)
+
+	return tx.Model(&ProductModel{}).
+		Where("id = ?", productID).
+		UpdateColumns(map[string]interface{}{
+			"rating_count":   approved.Session(&gorm.Session{}).Select("COUNT(*)"),
+			"rating_average": approved.Session(&gorm.Session{}).Select("COALESCE(ROUND(AVG(rating), 2), 0)"),
+		}).Error
+}
+
+// Mapping functions
+
+func toReviewModel(rv *review.Review) *ReviewModel {
+	return &ReviewModel{
+		Base: Base{
+			ID:        rv.ID,
+			CreatedAt: rv.CreatedAt,
+			UpdatedAt: rv.UpdatedAt,
+		},
+		ProductID:    rv.ProductID,
+		UserID:       rv.UserID,
+		OrderID:      rv.OrderID,
+		Rating:       rv.Rating,
+		Title:        rv.Title,
+		Body:         rv.Body,
+		Status:       string(rv.Status),
+		HelpfulCount: rv.HelpfulCount,
+		ModeratedBy:  nullableID(rv.ModeratedBy),
+		ModeratedAt:  rv.ModeratedAt,
+	}
+}
+
+func toReviewDomain(m *ReviewModel) *review.Review {
+	return &review.Review{
+		ID:           m.ID,
+		ProductID:    m.ProductID,
+		UserID:       m.UserID,
+		OrderID:      m.OrderID,
+		Rating:       m.Rating,
+		Title:        m.Title,
+		Body:         m.Body,
+		Status:       review.Status(m.Status),
+		HelpfulCount: m.HelpfulCount,
+		ModeratedBy:  stringValue(m.ModeratedBy),
+		ModeratedAt:  m.ModeratedAt,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
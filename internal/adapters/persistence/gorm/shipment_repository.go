@@ -0,0 +1,105 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/shipping"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+type shipmentRepository struct {
+	db *gorm.DB
+}
+
+// NewShipmentRepository creates a new GORM implementation of shipping.ShipmentRepository
+func NewShipmentRepository(db *gorm.DB) shipping.ShipmentRepository {
+	return &shipmentRepository{db: db}
+}
+
+func (r *shipmentRepository) CreateShipment(s *shipping.Shipment) error {
+	model := toShipmentModel(s)
+	if err := r.db.Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to create shipment in database. OrderID: %s, Error: %v", s.OrderID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*s = *toShipmentDomain(model)
+	return nil
+}
+
+func (r *shipmentRepository) GetShipment(id string) (*shipping.Shipment, error) {
+	var model ShipmentModel
+	if err := r.db.First(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shipping.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read shipment in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toShipmentDomain(&model), nil
+}
+
+func (r *shipmentRepository) ListShipments(orderID string) ([]*shipping.Shipment, error) {
+	var models []*ShipmentModel
+	if err := r.db.Where("order_id = ?", orderID).Order("shipped_at").Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list shipments in database. OrderID: %s, Error: %v", orderID, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	shipments := make([]*shipping.Shipment, len(models))
+	for i, model := range models {
+		shipments[i] = toShipmentDomain(model)
+	}
+	return shipments, nil
+}
+
+func (r *shipmentRepository) MarkDelivered(id string, at time.Time) (bool, error) {
+	result := r.db.Model(&ShipmentModel{}).
+		Where("id = ? AND status = ?", id, string(shipping.ShipmentShipped)).
+		Updates(map[string]interface{}{
+			"status":       string(shipping.ShipmentDelivered),
+			"delivered_at": at,
+		})
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to mark shipment delivered in database. ID: %s, Error: %v", id, result.Error)
+		return false, apperrors.ErrDatabaseError
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Mapping functions
+
+func toShipmentModel(s *shipping.Shipment) *ShipmentModel {
+	return &ShipmentModel{
+		Base: Base{
+			ID:        s.ID,
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.UpdatedAt,
+		},
+		OrderID:        s.OrderID,
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		Status:         string(s.Status),
+		ShippedAt:      s.ShippedAt,
+		DeliveredAt:    s.DeliveredAt,
+	}
+}
+
+func toShipmentDomain(m *ShipmentModel) *shipping.Shipment {
+	return &shipping.Shipment{
+		ID:             m.ID,
+		OrderID:        m.OrderID,
+		Carrier:        m.Carrier,
+		TrackingNumber: m.TrackingNumber,
+		Status:         shipping.ShipmentStatus(m.Status),
+		ShippedAt:      m.ShippedAt,
+		DeliveredAt:    m.DeliveredAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
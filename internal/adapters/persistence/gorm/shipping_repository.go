@@ -0,0 +1,258 @@
+package gorm
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/shipping"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+type shippingRepository struct {
+	db *gorm.DB
+}
+
+// NewShippingRepository creates a new GORM implementation of shipping.Repository
+func NewShippingRepository(db *gorm.DB) shipping.Repository {
+	return &shippingRepository{db: db}
+}
+
+func (r *shippingRepository) ListZones() ([]*shipping.Zone, error) {
+	var models []*ShippingZoneModel
+	err := r.db.Preload("Methods", func(db *gorm.DB) *gorm.DB {
+		return db.Order("price").Order("created_at")
+	}).Order("name").Find(&models).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to list shipping zones in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	zones := make([]*shipping.Zone, len(models))
+	for i, model := range models {
+		zones[i] = toShippingZoneDomain(model)
+	}
+	return zones, nil
+}
+
+func (r *shippingRepository) GetZone(id string) (*shipping.Zone, error) {
+	var model ShippingZoneModel
+	err := r.db.Preload("Methods", func(db *gorm.DB) *gorm.DB {
+		return db.Order("price").Order("created_at")
+	}).First(&model, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shipping.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read shipping zone in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toShippingZoneDomain(&model), nil
+}
+
+func (r *shippingRepository) CreateZone(z *shipping.Zone) error {
+	model := toShippingZoneModel(z)
+	if err := r.db.Omit("Methods").Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to create shipping zone in database. Name: %s, Error: %v", z.Name, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*z = *toShippingZoneDomain(model)
+	return nil
+}
+
+func (r *shippingRepository) UpdateZone(z *shipping.Zone) error {
+	model := toShippingZoneModel(z)
+	result := r.db.Model(model).Select("name", "countries").Updates(model)
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to update shipping zone in database. ID: %s, Error: %v", z.ID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return shipping.ErrNotFound
+	}
+
+	updated, err := r.GetZone(z.ID)
+	if err != nil {
+		return err
+	}
+
+	*z = *updated
+	return nil
+}
+
+// DeleteZone deletes the zone together with its methods
+func (r *shippingRepository) DeleteZone(id string) error {
+	var rows int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("zone_id = ?", id).Delete(&ShippingMethodModel{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&ShippingZoneModel{}, "id = ?", id)
+		rows = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		log.Printf("ERROR: Failed to delete shipping zone in database. ID: %s, Error: %v", id, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	if rows == 0 {
+		return shipping.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *shippingRepository) GetMethod(id string) (*shipping.Method, error) {
+	var model ShippingMethodModel
+	if err := r.db.First(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shipping.ErrNotFound
+		}
+		log.Printf("ERROR: Failed to read shipping method in database. ID: %s, Error: %v", id, err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return toShippingMethodDomain(&model), nil
+}
+
+func (r *shippingRepository) CreateMethod(m *shipping.Method) error {
+	model := toShippingMethodModel(m)
+	if err := r.db.Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to create shipping method in database. ZoneID: %s, Error: %v", m.ZoneID, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*m = *toShippingMethodDomain(model)
+	return nil
+}
+
+func (r *shippingRepository) UpdateMethod(m *shipping.Method) error {
+	model := toShippingMethodModel(m)
+	result := r.db.Model(model).
+		Select("name", "carrier", "type", "price", "price_per_kg", "free_over", "currency", "active").
+		Updates(model)
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to update shipping method in database. ID: %s, Error: %v", m.ID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return shipping.ErrNotFound
+	}
+
+	updated, err := r.GetMethod(m.ID)
+	if err != nil {
+		return err
+	}
+
+	*m = *updated
+	return nil
+}
+
+func (r *shippingRepository) DeleteMethod(id string) error {
+	result := r.db.Delete(&ShippingMethodModel{}, "id = ?", id)
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to delete shipping method in database. ID: %s, Error: %v", id, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return shipping.ErrNotFound
+	}
+
+	return nil
+}
+
+// Mapping functions
+
+func toShippingZoneModel(z *shipping.Zone) *ShippingZoneModel {
+	countries := z.Countries
+	if countries == nil {
+		countries = []string{}
+	}
+	encoded, _ := json.Marshal(countries)
+
+	return &ShippingZoneModel{
+		Base: Base{
+			ID:        z.ID,
+			CreatedAt: z.CreatedAt,
+			UpdatedAt: z.UpdatedAt,
+		},
+		Name:      z.Name,
+		Countries: string(encoded),
+	}
+}
+
+func toShippingZoneDomain(m *ShippingZoneModel) *shipping.Zone {
+	var countries []string
+	if err := json.Unmarshal([]byte(m.Countries), &countries); err != nil {
+		log.Printf("ERROR: Invalid countries on shipping zone. ID: %s, Error: %v", m.ID, err)
+	}
+
+	methods := make([]shipping.Method, len(m.Methods))
+	for i := range m.Methods {
+		methods[i] = *toShippingMethodDomain(&m.Methods[i])
+	}
+
+	return &shipping.Zone{
+		ID:        m.ID,
+		Name:      m.Name,
+		Countries: countries,
+		Methods:   methods,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func toShippingMethodModel(m *shipping.Method) *ShippingMethodModel {
+	model := &ShippingMethodModel{
+		Base: Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+		ZoneID:   m.ZoneID,
+		Name:     m.Name,
+		Carrier:  m.Carrier,
+		Type:     string(m.Type),
+		Price:    m.Price.Amount,
+		Currency: m.Price.Currency,
+		Active:   m.Active,
+	}
+	if m.PricePerKg != nil {
+		model.PricePerKg = &m.PricePerKg.Amount
+	}
+	if m.FreeOver != nil {
+		model.FreeOver = &m.FreeOver.Amount
+	}
+	return model
+}
+
+func toShippingMethodDomain(m *ShippingMethodModel) *shipping.Method {
+	method := &shipping.Method{
+		ID:        m.ID,
+		ZoneID:    m.ZoneID,
+		Name:      m.Name,
+		Carrier:   m.Carrier,
+		Type:      shipping.MethodType(m.Type),
+		Price:     money.New(m.Price, m.Currency),
+		Active:    m.Active,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.PricePerKg != nil {
+		pricePerKg := money.New(*m.PricePerKg, m.Currency)
+		method.PricePerKg = &pricePerKg
+	}
+	if m.FreeOver != nil {
+		freeOver := money.New(*m.FreeOver, m.Currency)
+		method.FreeOver = &freeOver
+	}
+	return method
+}
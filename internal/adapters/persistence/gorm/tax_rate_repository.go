@@ -0,0 +1,126 @@
+package gorm
+
+import (
+	"log"
+	"math/big"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/tax"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type taxRateRepository struct {
+	db *gorm.DB
+}
+
+// NewTaxRateRepository creates a database-backed tax.RateRepository
+func NewTaxRateRepository(db *gorm.DB) tax.RateRepository {
+	return &taxRateRepository{db: db}
+}
+
+func (r *taxRateRepository) ListRates() ([]*tax.Rate, error) {
+	return r.findRates(r.db.Order("country").Order("region").Order("tax_class"))
+}
+
+func (r *taxRateRepository) ListRatesForCountry(country string) ([]*tax.Rate, error) {
+	return r.findRates(r.db.Where("country = ?", country))
+}
+
+func (r *taxRateRepository) findRates(query *gorm.DB) ([]*tax.Rate, error) {
+	var models []TaxRateModel
+	if err := query.Find(&models).Error; err != nil {
+		log.Printf("ERROR: Failed to list tax rates in database. Error: %v", err)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	rates := make([]*tax.Rate, 0, len(models))
+	for i := range models {
+		rate, err := toTaxRateDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+// SetRate creates or replaces the rate for its country, region and tax class
+func (r *taxRateRepository) SetRate(rate *tax.Rate) error {
+	model := toTaxRateModel(rate)
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "country"}, {Name: "region"}, {Name: "tax_class"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "rate", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to set tax rate in database. Country: %s, Region: %s, Class: %s, Error: %v", rate.Country, rate.Region, rate.TaxClass, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	var stored TaxRateModel
+	err = r.db.Where("country = ? AND region = ? AND tax_class = ?", rate.Country, rate.Region, rate.TaxClass).
+		First(&stored).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to read tax rate in database. Country: %s, Region: %s, Class: %s, Error: %v", rate.Country, rate.Region, rate.TaxClass, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	updated, err := toTaxRateDomain(&stored)
+	if err != nil {
+		return err
+	}
+
+	*rate = *updated
+	return nil
+}
+
+func (r *taxRateRepository) DeleteRate(id string) error {
+	result := r.db.Unscoped().Delete(&TaxRateModel{}, "id = ?", id)
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to delete tax rate in database. ID: %s, Error: %v", id, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return tax.ErrRateNotFound
+	}
+
+	return nil
+}
+
+// Mapping functions
+
+func toTaxRateModel(rate *tax.Rate) *TaxRateModel {
+	return &TaxRateModel{
+		Base: Base{
+			ID:        rate.ID,
+			CreatedAt: rate.CreatedAt,
+			UpdatedAt: rate.UpdatedAt,
+		},
+		Country:  rate.Country,
+		Region:   rate.Region,
+		TaxClass: rate.TaxClass,
+		Name:     rate.Name,
+		Rate:     rate.Rate.FloatString(6),
+	}
+}
+
+func toTaxRateDomain(m *TaxRateModel) (*tax.Rate, error) {
+	rate, ok := new(big.Rat).SetString(m.Rate)
+	if !ok {
+		log.Printf("ERROR: Invalid tax rate stored in database. ID: %s, Rate: %s", m.ID, m.Rate)
+		return nil, apperrors.ErrDatabaseError
+	}
+
+	return &tax.Rate{
+		ID:        m.ID,
+		Country:   m.Country,
+		Region:    m.Region,
+		TaxClass:  m.TaxClass,
+		Name:      m.Name,
+		Rate:      rate,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}, nil
+}
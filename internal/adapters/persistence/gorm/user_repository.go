@@ -2,10 +2,12 @@ package gorm
 
 import (
 	"errors"
+	"log"
 	"strings"
 
 	"github.com/RubenRodrigo/go-tiny-store/internal/domain/user"
 	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -60,17 +62,27 @@ func (r *userRepository) GetUserByID(id string) (*user.User, error) {
 	return toUserDomain(&model), nil
 }
 
-func (r *userRepository) ListUsers() ([]*user.User, error) {
+func (r *userRepository) ListUsers(params pagination.Params) ([]*user.User, int64, error) {
 	var models []*UserModel
-	if err := r.db.Find(&models).Error; err != nil {
-		return nil, apperrors.ErrDatabaseError
+	var totalCount int64
+
+	query := r.db.Model(&UserModel{})
+
+	if params.NeedsTotal() {
+		if err := query.Count(&totalCount).Error; err != nil {
+			return nil, 0, apperrors.ErrDatabaseError
+		}
+	}
+
+	if err := paginate(query, params, "users").Find(&models).Error; err != nil {
+		return nil, 0, apperrors.ErrDatabaseError
 	}
 
 	users := make([]*user.User, len(models))
 	for i, model := range models {
 		users[i] = toUserDomain(model)
 	}
-	return users, nil
+	return users, totalCount, nil
 }
 
 func (r *userRepository) UpdateUser(u *user.User) error {
@@ -96,6 +108,41 @@ func (r *userRepository) UpdateUserPassword(userID, newHashedPassword string) er
 	return nil
 }
 
+func (r *userRepository) UpdatePreferredCurrency(userID, currency string) error {
+	result := r.db.Model(&UserModel{}).Where("id = ?", userID).Update("preferred_currency", currency)
+	if result.Error != nil {
+		return apperrors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) UpdateCustomerGroup(userID, customerGroup string) error {
+	result := r.db.Model(&UserModel{}).Where("id = ?", userID).Update("customer_group", customerGroup)
+	if result.Error != nil {
+		return apperrors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) HasRole(userID, role string) (bool, error) {
+	var count int64
+	err := r.db.Table("user_roles").
+		Joins("JOIN roles ON roles.id = user_roles.role_model_id AND roles.deleted_at IS NULL").
+		Where("user_roles.user_model_id = ? AND roles.name = ?", userID, role).
+		Count(&count).Error
+	if err != nil {
+		log.Printf("ERROR: Failed to read user roles in database. UserID: %s, Error: %v", userID, err)
+		return false, apperrors.ErrDatabaseError
+	}
+	return count > 0, nil
+}
+
 // Mapping functions: Domain <-> GORM Model
 
 func toUserModel(u *user.User) *UserModel {
@@ -105,23 +152,27 @@ func toUserModel(u *user.User) *UserModel {
 			CreatedAt: u.CreatedAt,
 			UpdatedAt: u.UpdatedAt,
 		},
-		Email:     u.Email,
-		Username:  u.Username,
-		Password:  u.Password,
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
+		Email:             u.Email,
+		Username:          u.Username,
+		Password:          u.Password,
+		FirstName:         u.FirstName,
+		LastName:          u.LastName,
+		PreferredCurrency: u.PreferredCurrency,
+		CustomerGroup:     u.CustomerGroup,
 	}
 }
 
 func toUserDomain(m *UserModel) *user.User {
 	return &user.User{
-		ID:        m.ID,
-		Email:     m.Email,
-		Username:  m.Username,
-		Password:  m.Password,
-		FirstName: m.FirstName,
-		LastName:  m.LastName,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
+		ID:                m.ID,
+		Email:             m.Email,
+		Username:          m.Username,
+		Password:          m.Password,
+		FirstName:         m.FirstName,
+		LastName:          m.LastName,
+		PreferredCurrency: m.PreferredCurrency,
+		CustomerGroup:     m.CustomerGroup,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
 	}
 }
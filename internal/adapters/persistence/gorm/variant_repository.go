@@ -0,0 +1,233 @@
+package gorm
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+type variantRepository struct {
+	db *gorm.DB
+}
+
+// NewVariantRepository creates a new GORM implementation of product.VariantRepository
+func NewVariantRepository(db *gorm.DB) product.VariantRepository {
+	return &variantRepository{db: db}
+}
+
+func (r *variantRepository) CreateOptionType(ot *product.OptionType) error {
+	model := toOptionTypeModel(ot)
+	if err := r.db.Create(model).Error; err != nil {
+		log.Printf("ERROR: Failed to create option type in database. Name: %s, Error: %v", ot.Name, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	*ot = *toOptionTypeDomain(model)
+	return nil
+}
+
+func (r *variantRepository) CreateVariant(v *product.Variant, optionValueIDs []string) error {
+	model := toVariantModel(v)
+	for _, id := range optionValueIDs {
+		model.OptionValues = append(model.OptionValues, ProductOptionValueModel{Base: Base{ID: id}})
+	}
+
+	// Only link the existing option values; never upsert them
+	if err := r.db.Omit("OptionValues.*").Create(model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return apperrors.ErrDuplicateEntry
+		}
+		log.Printf("ERROR: Failed to create variant in database. SKU: %s, Error: %v", v.SKU, err)
+		return apperrors.ErrDatabaseError
+	}
+
+	if err := r.db.Preload("OptionValues").First(model, "id = ?", model.ID).Error; err != nil {
+		return apperrors.ErrDatabaseError
+	}
+
+	// The price override, when set, already carries the product currency
+	currency := ""
+	if v.Price != nil {
+		currency = v.Price.Currency
+	}
+
+	*v = *toVariantDomain(model, currency)
+	return nil
+}
+
+func (r *variantRepository) UpdateVariant(v *product.Variant) error {
+	model := toVariantModel(v)
+	result := r.db.Model(&ProductVariantModel{}).
+		Where("id = ? AND product_id = ?", v.ID, v.ProductID).
+		Select("sku", "price", "disabled").
+		Updates(model)
+	if result.Error != nil {
+		if isDuplicateKeyError(result.Error) {
+			return apperrors.ErrDuplicateEntry
+		}
+		log.Printf("ERROR: Failed to update variant in database. ID: %s, Error: %v", v.ID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *variantRepository) DeleteVariant(productID, variantID string) error {
+	result := r.db.Where("id = ? AND product_id = ?", variantID, productID).Delete(&ProductVariantModel{})
+	if result.Error != nil {
+		log.Printf("ERROR: Failed to delete variant in database. ID: %s, Error: %v", variantID, result.Error)
+		return apperrors.ErrDatabaseError
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *variantRepository) AddVariantImage(img *product.ProductImage) error {
+	var variant ProductVariantModel
+	if err := r.db.Where("id = ? AND product_id = ?", img.VariantID, img.ProductID).First(&variant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrNotFound
+		}
+		return apperrors.ErrDatabaseError
+	}
+
+	model := toProductImageModel(img)
+	if err := r.db.Create(model).Error; err != nil {
+		return apperrors.ErrDatabaseError
+	}
+
+	*img = *toProductImageDomain(model)
+	return nil
+}
+
+// isDuplicateKeyError reports whether err is a unique constraint violation
+func isDuplicateKeyError(err error) bool {
+	return errors.Is(err, gorm.ErrDuplicatedKey) ||
+		strings.Contains(err.Error(), "duplicate") ||
+		strings.Contains(err.Error(), "unique constraint")
+}
+
+// Mapping functions
+
+func toOptionTypeModel(ot *product.OptionType) *ProductOptionTypeModel {
+	values := make([]ProductOptionValueModel, len(ot.Values))
+	for i, v := range ot.Values {
+		values[i] = ProductOptionValueModel{
+			Base:         Base{ID: v.ID},
+			OptionTypeID: v.OptionTypeID,
+			Value:        v.Value,
+			Position:     v.Position,
+		}
+	}
+
+	return &ProductOptionTypeModel{
+		Base: Base{
+			ID:        ot.ID,
+			CreatedAt: ot.CreatedAt,
+			UpdatedAt: ot.UpdatedAt,
+		},
+		ProductID: ot.ProductID,
+		Name:      ot.Name,
+		Position:  ot.Position,
+		Values:    values,
+	}
+}
+
+func toOptionTypeDomain(m *ProductOptionTypeModel) *product.OptionType {
+	values := make([]product.OptionValue, len(m.Values))
+	for i := range m.Values {
+		values[i] = toOptionValueDomain(&m.Values[i])
+	}
+
+	return &product.OptionType{
+		ID:        m.ID,
+		ProductID: m.ProductID,
+		Name:      m.Name,
+		Position:  m.Position,
+		Values:    values,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func toOptionValueDomain(m *ProductOptionValueModel) product.OptionValue {
+	return product.OptionValue{
+		ID:           m.ID,
+		OptionTypeID: m.OptionTypeID,
+		Value:        m.Value,
+		Position:     m.Position,
+	}
+}
+
+func toVariantModel(v *product.Variant) *ProductVariantModel {
+	return &ProductVariantModel{
+		Base: Base{
+			ID:        v.ID,
+			CreatedAt: v.CreatedAt,
+			UpdatedAt: v.UpdatedAt,
+		},
+		ProductID: v.ProductID,
+		SKU:       v.SKU,
+		Price:     priceAmount(v.Price),
+		Stock:     v.Stock,
+		LowStock:  v.LowStockThreshold,
+		Disabled:  v.Disabled,
+	}
+}
+
+// toVariantDomain maps a variant; price overrides are stored in the product currency
+func toVariantDomain(m *ProductVariantModel, currency string) *product.Variant {
+	optionValues := make([]product.OptionValue, len(m.OptionValues))
+	for i := range m.OptionValues {
+		optionValues[i] = toOptionValueDomain(&m.OptionValues[i])
+	}
+
+	images := make([]product.ProductImage, len(m.Images))
+	for i := range m.Images {
+		images[i] = *toProductImageDomain(&m.Images[i])
+	}
+
+	return &product.Variant{
+		ID:                m.ID,
+		ProductID:         m.ProductID,
+		SKU:               m.SKU,
+		Price:             priceOverride(m.Price, currency),
+		Stock:             m.Stock,
+		Reserved:          m.Reserved,
+		Available:         m.Stock - m.Reserved,
+		LowStockThreshold: m.LowStock,
+		Disabled:          m.Disabled,
+		OptionValues:      optionValues,
+		Images:            images,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+	}
+}
+
+func priceAmount(price *money.Money) *int64 {
+	if price == nil {
+		return nil
+	}
+	return &price.Amount
+}
+
+func priceOverride(amount *int64, currency string) *money.Money {
+	if amount == nil {
+		return nil
+	}
+	price := money.New(*amount, currency)
+	return &price
+}
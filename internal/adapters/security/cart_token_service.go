@@ -0,0 +1,77 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/cart"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+type hmacCartTokenService struct {
+	secret []byte
+}
+
+// NewHMACCartTokenService creates a cart token service that signs cart IDs
+// with HMAC-SHA256
+func NewHMACCartTokenService(secret string) cart.TokenService {
+	return &hmacCartTokenService{
+		secret: []byte(secret),
+	}
+}
+
+func (s *hmacCartTokenService) Issue(cartID string) string {
+	return cartID + "." + s.sign(cartID)
+}
+
+func (s *hmacCartTokenService) Parse(token string) (string, error) {
+	cartID, signature, ok := strings.Cut(token, ".")
+	if !ok || cartID == "" || !hmac.Equal([]byte(signature), []byte(s.sign(cartID))) {
+		return "", apperrors.ErrInvalidCartToken
+	}
+
+	return cartID, nil
+}
+
+func (s *hmacCartTokenService) IssueRestore(cartID string, expiresAt time.Time) string {
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+	return cartID + "." + expiry + "." + s.signRestore(cartID, expiry)
+}
+
+func (s *hmacCartTokenService) ParseRestore(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", apperrors.ErrInvalidRestoreLink
+	}
+	cartID, expiry, signature := parts[0], parts[1], parts[2]
+	if !hmac.Equal([]byte(signature), []byte(s.signRestore(cartID, expiry))) {
+		return "", apperrors.ErrInvalidRestoreLink
+	}
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || time.Now().Unix() >= expiresAt {
+		return "", apperrors.ErrInvalidRestoreLink
+	}
+
+	return cartID, nil
+}
+
+func (s *hmacCartTokenService) sign(cartID string) string {
+	return s.mac("cart:" + cartID)
+}
+
+// signRestore signs restore tokens apart from cart tokens, so neither can be
+// passed off as the other
+func (s *hmacCartTokenService) signRestore(cartID, expiry string) string {
+	return s.mac("restore:" + cartID + ":" + expiry)
+}
+
+func (s *hmacCartTokenService) mac(message string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(message))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,71 @@
+package tax
+
+import (
+	"math/big"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/tax"
+)
+
+// RuleConfig holds settings for the rule-based calculator
+type RuleConfig struct {
+	ExemptGroups []string // customer groups that pay no tax, e.g. "wholesale"
+}
+
+type ruleCalculator struct {
+	rateRepo tax.RateRepository
+	exempt   map[string]bool
+}
+
+// NewRuleCalculator creates a tax calculator that applies the configured
+// rates by country, region and product tax class
+func NewRuleCalculator(rateRepo tax.RateRepository, config RuleConfig) tax.Calculator {
+	exempt := make(map[string]bool, len(config.ExemptGroups))
+	for _, group := range config.ExemptGroups {
+		exempt[group] = true
+	}
+
+	return &ruleCalculator{
+		rateRepo: rateRepo,
+		exempt:   exempt,
+	}
+}
+
+func (c *ruleCalculator) Calculate(req tax.Request) (*tax.Result, error) {
+	result := &tax.Result{
+		Lines: make([]tax.LineTax, len(req.Lines)),
+		Total: money.Zero(req.Currency),
+	}
+	for i := range result.Lines {
+		result.Lines[i] = tax.LineTax{Rate: new(big.Rat), Amount: money.Zero(req.Currency)}
+	}
+
+	if req.Customer.CustomerGroup != "" && c.exempt[req.Customer.CustomerGroup] {
+		return result, nil
+	}
+
+	rates, err := c.rateRepo.ListRatesForCountry(req.Address.Country)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, line := range req.Lines {
+		rate := tax.Match(rates, req.Address.Country, req.Address.Region, line.TaxClass)
+		if rate == nil || rate.Rate.Sign() == 0 {
+			continue
+		}
+
+		// Inclusive prices hold amount = net * (1 + rate), so the tax part
+		// is amount * rate / (1 + rate)
+		ratio := rate.Rate
+		if req.PricesIncludeTax {
+			ratio = new(big.Rat).Quo(rate.Rate, new(big.Rat).Add(big.NewRat(1, 1), rate.Rate))
+		}
+
+		amount := line.Amount.MulRat(ratio)
+		result.Lines[i] = tax.LineTax{Name: rate.Name, Rate: rate.Rate, Amount: amount}
+		result.Total.Amount += amount.Amount
+	}
+
+	return result, nil
+}
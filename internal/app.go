@@ -1,24 +1,49 @@
 package app
 
 import (
+	"context"
 	"time"
 
+	"github.com/RubenRodrigo/go-tiny-store/internal/adapters/blob"
 	"github.com/RubenRodrigo/go-tiny-store/internal/adapters/email"
+	"github.com/RubenRodrigo/go-tiny-store/internal/adapters/file"
+	"github.com/RubenRodrigo/go-tiny-store/internal/adapters/memory"
+	"github.com/RubenRodrigo/go-tiny-store/internal/adapters/payment"
+	"github.com/RubenRodrigo/go-tiny-store/internal/adapters/pdf"
 	gormadapter "github.com/RubenRodrigo/go-tiny-store/internal/adapters/persistence/gorm"
 	"github.com/RubenRodrigo/go-tiny-store/internal/adapters/security"
+	taxadapter "github.com/RubenRodrigo/go-tiny-store/internal/adapters/tax"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/addressapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/authapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/cartapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/categoryapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/checkoutapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/giftcardapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/inventoryapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/invoiceapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/orderapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/pricingapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/productapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/promotionapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/recommendationapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/reviewapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/rmaapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/shippingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/taxapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/userapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
 	"github.com/RubenRodrigo/go-tiny-store/internal/infrastructure/config"
 	"github.com/RubenRodrigo/go-tiny-store/internal/infrastructure/database"
+	"github.com/RubenRodrigo/go-tiny-store/internal/infrastructure/worker"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
 )
 
 // App represents the application
 type App struct {
 	config     *config.Config
 	restServer *http.Server
+	jobs       []worker.Job
 }
 
 // New creates a new application instance
@@ -32,12 +57,19 @@ func New() *App {
 
 // Initialize sets up all dependencies
 func (a *App) Initialize() error {
+	if err := a.config.Validate(); err != nil {
+		return err
+	}
+
 	// Setup database connection
 	db, err := database.SetupDatabase(&a.config.Database)
 	if err != nil {
 		return err
 	}
 
+	// Sign pagination cursors so clients cannot forge keyset positions
+	pagination.SetSigningKey(a.config.Pagination.CursorSecret)
+
 	// Initialize adapters (implementations of ports)
 	// Security adapters
 	passwordHasher := security.NewBcryptHasher()
@@ -48,6 +80,8 @@ func (a *App) Initialize() error {
 		RefreshTokenTTL: 7 * 24 * time.Hour,
 		Issuer:          "tiny-store-api",
 	})
+	cartTokenService := security.NewHMACCartTokenService(a.config.Cart.TokenSecret)
+	giftCardCodes := security.NewRandomGiftCardCodeGenerator()
 
 	// Email adapter
 	emailSender := email.NewSendgridSender(email.SendgridConfig{
@@ -55,17 +89,175 @@ func (a *App) Initialize() error {
 		FromEmail: "admin@admin.com",
 	})
 
+	// Payment adapter
+	paymentGateway := payment.NewStripeGateway(payment.StripeConfig{
+		SecretKey:     a.config.Payment.StripeSecretKey,
+		WebhookSecret: a.config.Payment.StripeWebhookSecret,
+	})
+
+	// Document adapters
+	documentRenderer := pdf.NewTemplateRenderer()
+	blobStore := blob.NewLocalStore(blob.LocalConfig{Dir: a.config.Storage.Dir})
+
 	// Repository adapters (GORM implementations)
 	userRepo := gormadapter.NewUserRepository(db)
 	refreshTokenRepo := gormadapter.NewRefreshTokenRepository(db)
 	passwordResetTokenRepo := gormadapter.NewPasswordResetTokenRepository(db)
 	categoryRepo := gormadapter.NewCategoryRepository(db)
 	productRepo := gormadapter.NewProductRepository(db)
+	variantRepo := gormadapter.NewVariantRepository(db)
+	likeRepo := gormadapter.NewLikeRepository(db)
+	reviewRepo := gormadapter.NewReviewRepository(db)
+	cartRepo := gormadapter.NewCartRepository(db)
+	orderRepo := gormadapter.NewOrderRepository(db)
+	inventoryRepo := gormadapter.NewInventoryRepository(db)
+	reservationRepo := gormadapter.NewReservationRepository(db)
+	pricingRepo := gormadapter.NewPricingRepository(db)
+	exchangeRateRepo := gormadapter.NewExchangeRateRepository(db)
+	if a.config.Pricing.RatesFile != "" {
+		// Rates maintained outside the store, e.g. by a feed job, replace
+		// the ones managers set
+		if exchangeRateRepo, err = file.NewExchangeRateRepository(a.config.Pricing.RatesFile); err != nil {
+			return err
+		}
+	}
+	importJobRepo := gormadapter.NewImportJobRepository(db)
+	priceRepo := gormadapter.NewPriceRepository(db)
+	recommendationRepo := gormadapter.NewRecommendationRepository(db)
+	promotionRepo := gormadapter.NewPromotionRepository(db)
+	taxRateRepo := gormadapter.NewTaxRateRepository(db)
+	shippingRepo := gormadapter.NewShippingRepository(db)
+	shipmentRepo := gormadapter.NewShipmentRepository(db)
+	addressRepo := gormadapter.NewAddressRepository(db)
+	returnRepo := gormadapter.NewReturnRepository(db)
+	refundRepo := gormadapter.NewRefundRepository(db)
+	invoiceRepo := gormadapter.NewInvoiceRepository(db)
+	cartReminderRepo := gormadapter.NewCartReminderRepository(db)
+	giftCardRepo := gormadapter.NewGiftCardRepository(db)
+
+	// Idempotency store; the in-memory one is not shared between instances
+	idempotencyStore := gormadapter.NewIdempotencyRepository(db)
+	if a.config.Idempotency.Store == "memory" {
+		idempotencyStore = memory.NewIdempotencyStore()
+	}
+
+	// Notification adapters
+	lowStockNotifier := email.NewLowStockNotifier(emailSender, a.config.Inventory.AlertEmail)
+	abandonedCartNotifier := email.NewAbandonedCartNotifier(emailSender)
+	giftCardNotifier := email.NewGiftCardNotifier(emailSender)
+
+	// Tax adapters
+	taxCalculator := taxadapter.NewRuleCalculator(taxRateRepo, taxadapter.RuleConfig{
+		ExemptGroups: a.config.Tax.ExemptGroups,
+	})
 
 	// Initialize application services (use cases)
 	userService := userapp.NewService(userRepo)
 	categoryService := categoryapp.NewService(categoryRepo)
-	productService := productapp.NewService(productRepo)
+	pricingService := pricingapp.NewService(pricingRepo, exchangeRateRepo, productRepo, userRepo, a.config.Store.Currency)
+	productService := productapp.NewService(
+		productRepo,
+		variantRepo,
+		likeRepo,
+		inventoryRepo,
+		categoryRepo,
+		priceRepo,
+		importJobRepo,
+		pricingService,
+		productapp.Config{
+			Currency:         a.config.Store.Currency,
+			StoreURL:         a.config.Store.URL,
+			ImportBatchSize:  a.config.Import.BatchSize,
+			ImportInlineRows: a.config.Import.InlineRows,
+			ImportMaxBytes:   int64(a.config.Import.MaxUploadMB) << 20,
+			ImportStaleAfter: time.Duration(a.config.Import.StaleMinutes) * time.Minute,
+		},
+	)
+	promotionService := promotionapp.NewService(
+		promotionRepo,
+		productRepo,
+		categoryRepo,
+		pricingService,
+		promotionapp.Config{Currency: a.config.Store.Currency},
+	)
+	cartService := cartapp.NewService(
+		cartRepo,
+		cartReminderRepo,
+		productRepo,
+		cartTokenService,
+		abandonedCartNotifier,
+		pricingService,
+		promotionService,
+		cartapp.Config{
+			StoreURL:       a.config.Store.URL,
+			AbandonedAfter: time.Duration(a.config.Cart.AbandonedAfterHours) * time.Hour,
+			MaxReminders:   a.config.Cart.MaxReminders,
+		},
+	)
+	orderService := orderapp.NewService(orderRepo)
+	reviewService := reviewapp.NewService(reviewRepo, orderRepo, productRepo)
+	recommendationService := recommendationapp.NewService(
+		recommendationRepo,
+		productRepo,
+		pricingService,
+		recommendationapp.Config{
+			Window:    time.Duration(a.config.Recommendation.WindowDays) * 24 * time.Hour,
+			MinOrders: a.config.Recommendation.MinOrders,
+		},
+	)
+	taxService := taxapp.NewService(taxRateRepo)
+	addressService := addressapp.NewService(addressRepo)
+	shippingService := shippingapp.NewService(
+		shippingRepo,
+		shipmentRepo,
+		orderRepo,
+		nil, // no external carriers yet
+		pricingService,
+		shippingapp.Config{Currency: a.config.Store.Currency},
+	)
+	inventoryService := inventoryapp.NewService(inventoryRepo, lowStockNotifier, a.config.Inventory.LowStockThreshold)
+	invoiceService := invoiceapp.NewService(
+		invoiceRepo,
+		orderRepo,
+		refundRepo,
+		documentRenderer,
+		blobStore,
+		invoiceapp.Config{
+			SellerName:    a.config.Invoice.SellerName,
+			SellerAddress: a.config.Invoice.SellerAddress,
+			SellerTaxID:   a.config.Invoice.SellerTaxID,
+		},
+	)
+	giftCardService := giftcardapp.NewService(giftCardRepo, orderRepo, userRepo, giftCardCodes, giftCardNotifier)
+	checkoutService := checkoutapp.NewService(
+		cartRepo,
+		productRepo,
+		orderRepo,
+		reservationRepo,
+		paymentGateway,
+		taxCalculator,
+		inventoryService,
+		pricingService,
+		promotionService,
+		shippingService,
+		addressService,
+		invoiceService,
+		cartService,
+		giftCardService,
+		checkoutapp.Config{
+			ReservationTTL:   time.Duration(a.config.Checkout.ReservationTTLMinutes) * time.Minute,
+			PricesIncludeTax: a.config.Tax.PricesIncludeTax,
+		},
+	)
+	rmaService := rmaapp.NewService(
+		returnRepo,
+		orderRepo,
+		refundRepo,
+		paymentGateway,
+		inventoryService,
+		invoiceService,
+		giftCardService,
+	)
 	authService := authapp.NewService(
 		userRepo,
 		refreshTokenRepo,
@@ -74,23 +266,86 @@ func (a *App) Initialize() error {
 		passwordHasher,
 		tokenHasher,
 		emailSender,
+		cartService,
 	)
 
 	// Create services container
 	services := http.Services{
-		User:     userService,
-		Auth:     authService,
-		Category: categoryService,
-		Product:  productService,
+		User:           userService,
+		Auth:           authService,
+		Category:       categoryService,
+		Product:        productService,
+		Cart:           cartService,
+		Order:          orderService,
+		Inventory:      inventoryService,
+		Checkout:       checkoutService,
+		Pricing:        pricingService,
+		Review:         reviewService,
+		Recommendation: recommendationService,
+		Promotion:      promotionService,
+		Tax:            taxService,
+		Shipping:       shippingService,
+		Address:        addressService,
+		Return:         rmaService,
+		Invoice:        invoiceService,
+		GiftCard:       giftCardService,
+	}
+
+	// Background jobs
+	a.jobs = []worker.Job{
+		{
+			Name:     "release-expired-reservations",
+			Interval: time.Duration(a.config.Checkout.SweepIntervalSeconds) * time.Second,
+			Run:      checkoutService.ReleaseExpired,
+		},
+		{
+			Name:     "process-product-imports",
+			Interval: time.Duration(a.config.Import.PollIntervalSeconds) * time.Second,
+			Run:      productService.ProcessImports,
+		},
+		{
+			Name:     "apply-scheduled-prices",
+			Interval: time.Duration(a.config.Pricing.ScheduleIntervalSeconds) * time.Second,
+			Run:      productService.ApplyScheduledPrices,
+		},
+		{
+			Name:     "rebuild-recommendations",
+			Interval: time.Duration(a.config.Recommendation.IntervalMinutes) * time.Minute,
+			Run:      recommendationService.Rebuild,
+		},
+		{
+			Name:     "send-abandoned-cart-reminders",
+			Interval: time.Duration(a.config.Cart.ReminderIntervalMinutes) * time.Minute,
+			Run:      cartService.SendReminders,
+		},
+		{
+			Name:     "purge-idempotency-keys",
+			Interval: time.Hour,
+			Run: func() error {
+				return idempotencyStore.DeleteExpired(time.Now())
+			},
+		},
 	}
 
 	// Initialize HTTP server (delivery layer)
-	a.restServer = http.NewServer(services, &a.config.Server, tokenService)
+	a.restServer = http.NewServer(
+		services,
+		&a.config.Server,
+		tokenService,
+		idempotencyStore,
+		middleware.IdempotencyOptions{
+			TTL:     time.Duration(a.config.Idempotency.TTLHours) * time.Hour,
+			LockTTL: time.Duration(a.config.Idempotency.LockTTLSeconds) * time.Second,
+			MaxBody: int64(a.config.Idempotency.MaxBodyMB) << 20,
+		},
+	)
 
 	return nil
 }
 
 // Start starts the application
 func (a *App) Start() error {
+	worker.Start(context.Background(), a.jobs...)
+
 	return a.restServer.Start()
 }
@@ -0,0 +1,15 @@
+package addressapp
+
+// AddressInput represents the input for creating or replacing an address book entry
+type AddressInput struct {
+	Name            string
+	Line1           string
+	Line2           string
+	City            string
+	Region          string
+	PostalCode      string
+	Country         string
+	Phone           string
+	DefaultShipping bool
+	DefaultBilling  bool
+}
@@ -0,0 +1,124 @@
+package addressapp
+
+import (
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/address"
+)
+
+// Service handles the customer address book
+type Service struct {
+	addressRepo address.Repository
+}
+
+// NewService creates a new address book application service
+func NewService(addressRepo address.Repository) *Service {
+	return &Service{
+		addressRepo: addressRepo,
+	}
+}
+
+func (s *Service) List(userID string) ([]*address.Address, error) {
+	return s.addressRepo.ListAddresses(userID)
+}
+
+// Get returns the address only if it belongs to the user
+func (s *Service) Get(userID, id string) (*address.Address, error) {
+	a, err := s.addressRepo.GetAddress(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't reveal other customers' addresses
+	if a.UserID != userID {
+		return nil, address.ErrAddressNotFound
+	}
+
+	return a, nil
+}
+
+// Create adds an address to the user's address book. The first address
+// becomes the default shipping and billing address.
+func (s *Service) Create(userID string, input AddressInput) (*address.Address, error) {
+	a := build(input)
+	a.UserID = userID
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.addressRepo.ListAddresses(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		a.DefaultShipping = true
+		a.DefaultBilling = true
+	}
+
+	if err := s.addressRepo.CreateAddress(a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (s *Service) Update(userID, id string, input AddressInput) (*address.Address, error) {
+	if _, err := s.Get(userID, id); err != nil {
+		return nil, err
+	}
+
+	a := build(input)
+	a.ID = id
+	a.UserID = userID
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.addressRepo.UpdateAddress(a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (s *Service) Delete(userID, id string) error {
+	if _, err := s.Get(userID, id); err != nil {
+		return err
+	}
+
+	return s.addressRepo.DeleteAddress(id)
+}
+
+// Defaults returns the user's default shipping and billing addresses, nil
+// when not set
+func (s *Service) Defaults(userID string) (shipping, billing *address.Address, err error) {
+	addresses, err := s.addressRepo.ListAddresses(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, a := range addresses {
+		if a.DefaultShipping && shipping == nil {
+			shipping = a
+		}
+		if a.DefaultBilling && billing == nil {
+			billing = a
+		}
+	}
+	return shipping, billing, nil
+}
+
+func build(input AddressInput) *address.Address {
+	a := &address.Address{
+		Name:            input.Name,
+		Line1:           input.Line1,
+		Line2:           input.Line2,
+		City:            input.City,
+		Region:          input.Region,
+		PostalCode:      input.PostalCode,
+		Country:         input.Country,
+		Phone:           input.Phone,
+		DefaultShipping: input.DefaultShipping,
+		DefaultBilling:  input.DefaultBilling,
+	}
+	a.Normalize()
+	return a
+}
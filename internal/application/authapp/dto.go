@@ -7,12 +7,14 @@ type SignUpDTO struct {
 	Password  string
 	FirstName string
 	LastName  string
+	CartToken string // guest cart to merge into the new user's cart
 }
 
 // SignInDTO represents the input for user login
 type SignInDTO struct {
-	Email    string
-	Password string
+	Email     string
+	Password  string
+	CartToken string // guest cart to merge into the user's cart
 }
 
 // AuthUserDTO represents the authenticated user response
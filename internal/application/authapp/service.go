@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/cartapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/domain/auth"
 	"github.com/RubenRodrigo/go-tiny-store/internal/domain/user"
 	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
@@ -19,6 +20,7 @@ type Service struct {
 	passwordHasher         auth.PasswordHasher
 	tokenHasher            auth.TokenHasher
 	emailSender            auth.EmailSender
+	cartService            *cartapp.Service
 }
 
 // NewService creates a new auth application service
@@ -30,6 +32,7 @@ func NewService(
 	passwordHasher auth.PasswordHasher,
 	tokenHasher auth.TokenHasher,
 	emailSender auth.EmailSender,
+	cartService *cartapp.Service,
 ) *Service {
 	return &Service{
 		userRepo:               userRepo,
@@ -39,6 +42,7 @@ func NewService(
 		passwordHasher:         passwordHasher,
 		tokenHasher:            tokenHasher,
 		emailSender:            emailSender,
+		cartService:            cartService,
 	}
 }
 
@@ -72,6 +76,8 @@ func (s *Service) SignUp(dto SignUpDTO) (*AuthUserDTO, error) {
 		return nil, err
 	}
 
+	s.mergeCart(newUser.ID, dto.CartToken)
+
 	// Convert to DTO
 	return &AuthUserDTO{
 		ID:           newUser.ID,
@@ -105,6 +111,8 @@ func (s *Service) SignIn(dto SignInDTO) (*AuthUserDTO, error) {
 		return nil, err
 	}
 
+	s.mergeCart(foundUser.ID, dto.CartToken)
+
 	// Convert to DTO
 	return &AuthUserDTO{
 		ID:           foundUser.ID,
@@ -251,6 +259,18 @@ func (s *Service) authenticate(u *user.User) (string, string, error) {
 	return accessToken.Token, refreshToken.Token, nil
 }
 
+// mergeCart moves the guest cart of the token, if any, into the user's cart.
+// A failed merge is logged rather than failing the sign-in.
+func (s *Service) mergeCart(userID, cartToken string) {
+	if cartToken == "" {
+		return
+	}
+
+	if err := s.cartService.Merge(userID, cartToken); err != nil {
+		log.Printf("Warning: failed to merge guest cart into cart of user %s: %v", userID, err)
+	}
+}
+
 func (s *Service) generateTokens(u *user.User) (*auth.GeneratedToken, *auth.GeneratedToken, error) {
 	accessToken, err := s.tokenService.GenerateAccessToken(u.ID, u.Email, u.Username)
 	if err != nil {
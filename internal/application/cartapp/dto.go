@@ -0,0 +1,24 @@
+package cartapp
+
+import "time"
+
+// Config holds cart settings
+type Config struct {
+	StoreURL       string        // storefront base URL, for the links in reminders
+	AbandonedAfter time.Duration // idle time before a reminder, and between reminders
+	MaxReminders   int           // reminders per idle period; zero sends none
+}
+
+// AddItemInput represents the input for adding a product to the cart
+type AddItemInput struct {
+	ProductID string
+	VariantID string
+	Quantity  int
+}
+
+// Owner identifies the cart a request works on: the cart of the signed-in
+// user, else the guest cart of the cart token
+type Owner struct {
+	UserID    string
+	CartToken string
+}
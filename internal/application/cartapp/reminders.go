@@ -0,0 +1,100 @@
+package cartapp
+
+import (
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/cart"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+// reminderBatchSize bounds the carts handled by one run of SendReminders
+const reminderBatchSize = 100
+
+// restoreLinkTTL is how long the link in a reminder keeps restoring the cart
+const restoreLinkTTL = 30 * 24 * time.Hour
+
+// SendReminders emails customers whose carts have been left idle, once the
+// cart has been idle for the configured time and again at the same interval,
+// up to the configured number of reminders. Changing the cart starts over;
+// checking out empties it, which stops the reminders. Guest carts have no
+// address to write to and are left out.
+func (s *Service) SendReminders() error {
+	if s.config.MaxReminders <= 0 {
+		return nil
+	}
+
+	idleSince := time.Now().Add(-s.config.AbandonedAfter)
+	abandoned, err := s.reminderRepo.ListAbandoned(idleSince, s.config.MaxReminders, reminderBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range abandoned {
+		if err := s.remind(a); err != nil {
+			log.Printf("Warning: failed to send reminder for abandoned cart %s: %v", a.CartID, err)
+		}
+	}
+
+	return nil
+}
+
+// remind emails the customer about their cart at its current prices and
+// records the reminder. A failed email is not recorded, so it is retried on
+// the next run.
+func (s *Service) remind(a *cart.Abandoned) error {
+	c, err := s.cartRepo.GetCart(a.CartID)
+	if err != nil {
+		return err
+	}
+	if c, err = s.price(c); err != nil {
+		return err
+	}
+
+	items := 0
+	for _, item := range c.Items {
+		items += item.Quantity
+	}
+
+	reminder := &cart.Reminder{
+		CartID:   c.ID,
+		UserID:   a.UserID,
+		Email:    a.Email,
+		Sequence: a.Reminders + 1,
+		Value:    c.Total,
+		SentAt:   time.Now(),
+	}
+
+	// The link carries a restore token, so the cart can be picked up on a
+	// device the customer is not signed in on
+	token := s.tokens.IssueRestore(c.ID, time.Now().Add(restoreLinkTTL))
+	err = s.notifier.NotifyAbandoned(cart.ReminderNotice{
+		To:         a.Email,
+		Sequence:   reminder.Sequence,
+		Items:      items,
+		Total:      c.Total,
+		RestoreURL: s.config.StoreURL + "/cart/restore?token=" + url.QueryEscape(token),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.reminderRepo.CreateReminder(reminder)
+}
+
+// MarkRecovered credits the last reminder sent about a cart with the order
+// it was checked out into
+func (s *Service) MarkRecovered(cartID, orderID string) error {
+	return s.reminderRepo.MarkRecovered(cartID, orderID)
+}
+
+// AbandonedReport sums the value of abandoned carts, and of the orders that
+// recovered them, per period and currency
+func (s *Service) AbandonedReport(filters cart.ReportFilters) ([]cart.ReportRow, error) {
+	if !filters.Interval.IsValid() || !filters.From.Before(filters.To) {
+		return nil, apperrors.ErrInvalidReportPeriod
+	}
+
+	return s.reminderRepo.Report(filters)
+}
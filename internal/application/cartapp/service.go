@@ -0,0 +1,443 @@
+package cartapp
+
+import (
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/pricingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/promotionapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/cart"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/promotion"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+// Service handles cart-related use cases
+type Service struct {
+	cartRepo     cart.Repository
+	reminderRepo cart.ReminderRepository
+	productRepo  product.Repository
+	tokens       cart.TokenService
+	notifier     cart.ReminderNotifier
+	pricing      *pricingapp.Service
+	promotions   *promotionapp.Service
+	config       Config
+}
+
+// NewService creates a new cart application service
+func NewService(
+	cartRepo cart.Repository,
+	reminderRepo cart.ReminderRepository,
+	productRepo product.Repository,
+	tokens cart.TokenService,
+	notifier cart.ReminderNotifier,
+	pricing *pricingapp.Service,
+	promotions *promotionapp.Service,
+	config Config,
+) *Service {
+	return &Service{
+		cartRepo:     cartRepo,
+		reminderRepo: reminderRepo,
+		productRepo:  productRepo,
+		tokens:       tokens,
+		notifier:     notifier,
+		pricing:      pricing,
+		promotions:   promotions,
+		config:       config,
+	}
+}
+
+// Get returns the owner's cart priced at current prices. The requested
+// currency only applies when the cart is created; after that the cart keeps
+// its currency. A guest without a cart gets an empty one that is only stored
+// once they add to it.
+func (s *Service) Get(owner Owner, currency string) (*cart.Cart, error) {
+	c, err := s.load(owner, currency, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.price(c)
+}
+
+// Find returns the stored cart of the owner, or apperrors.ErrNotFound. The
+// token of a guest cart that has since been merged finds nothing.
+func (s *Service) Find(owner Owner) (*cart.Cart, error) {
+	if owner.UserID != "" {
+		return s.cartRepo.GetCartByUserID(owner.UserID)
+	}
+	if owner.CartToken == "" {
+		return nil, apperrors.ErrNotFound
+	}
+
+	id, err := s.tokens.Parse(owner.CartToken)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.cartRepo.GetCart(id)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserID != "" {
+		return nil, apperrors.ErrNotFound
+	}
+
+	c.Token = owner.CartToken
+	return c, nil
+}
+
+// load returns the owner's cart, creating an empty one locked to the selected
+// currency on first access. Guest carts are only stored when storeGuest is
+// set; until then they have no ID or token.
+func (s *Service) load(owner Owner, currency string, storeGuest bool) (*cart.Cart, error) {
+	c, err := s.Find(owner)
+	if err == nil {
+		return c, nil
+	}
+	if err != apperrors.ErrNotFound {
+		return nil, err
+	}
+
+	sel, err := s.pricing.Select(owner.UserID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	c = &cart.Cart{UserID: owner.UserID, Currency: sel.Currency}
+	if owner.UserID == "" && !storeGuest {
+		return c, nil
+	}
+
+	if err := s.cartRepo.CreateCart(c); err != nil {
+		// Lost a race with a concurrent request creating the same cart
+		if err == apperrors.ErrDuplicateEntry && owner.UserID != "" {
+			return s.cartRepo.GetCartByUserID(owner.UserID)
+		}
+		return nil, err
+	}
+
+	if owner.UserID == "" {
+		c.Token = s.tokens.Issue(c.ID)
+	}
+	return c, nil
+}
+
+func (s *Service) AddItem(owner Owner, currency string, input AddItemInput) (*cart.Cart, error) {
+	if input.Quantity <= 0 {
+		return nil, apperrors.ErrInvalidQuantity
+	}
+
+	p, err := s.productRepo.GetProduct(input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.load(owner, currency, true)
+	if err != nil {
+		return nil, err
+	}
+
+	item := c.FindItem(input.ProductID, input.VariantID)
+	if item == nil {
+		c.Items = append(c.Items, cart.Item{
+			CartID:    c.ID,
+			ProductID: input.ProductID,
+			VariantID: input.VariantID,
+		})
+		item = &c.Items[len(c.Items)-1]
+	}
+	quantity := item.Quantity + input.Quantity
+
+	if _, err := p.CheckPurchasable(input.VariantID, quantity); err != nil {
+		return nil, err
+	}
+
+	item.Quantity = quantity
+	if err := s.cartRepo.SaveItem(item); err != nil {
+		return nil, err
+	}
+
+	return s.price(c)
+}
+
+func (s *Service) RemoveItem(owner Owner, productID, variantID string) (*cart.Cart, error) {
+	c, err := s.Find(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cartRepo.RemoveItem(c.ID, productID, variantID); err != nil {
+		return nil, err
+	}
+
+	token := c.Token
+	c, err = s.cartRepo.GetCart(c.ID)
+	if err != nil {
+		return nil, err
+	}
+	c.Token = token
+
+	return s.price(c)
+}
+
+func (s *Service) Clear(owner Owner) error {
+	c, err := s.Find(owner)
+	if err == apperrors.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.cartRepo.ClearCart(c.ID)
+}
+
+// Merge moves the items of the guest cart of the token into the user's cart
+// and deletes the guest cart. Quantities of lines for the same product and
+// variant are added up and capped at the stock available; items that can no
+// longer be bought are left out. The guest's discount code carries over when
+// the user's cart has none.
+func (s *Service) Merge(userID, token string) error {
+	guest, err := s.Find(Owner{CartToken: token})
+	if err == apperrors.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	c, err := s.load(Owner{UserID: userID}, guest.Currency, true)
+	if err != nil {
+		return err
+	}
+
+	items, err := s.copyItems(c, guest, func(current, quantity int) int {
+		return current + quantity
+	})
+	if err != nil {
+		return err
+	}
+
+	// The guest cart is deleted together with the copy, so a failed merge
+	// can be retried and a concurrent merge of the same token is a no-op
+	// instead of adding the items twice
+	err = s.cartRepo.MergeItems(c.ID, items, carriedDiscount(c, guest), guest.ID)
+	if err == apperrors.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// Restore brings back the cart of a reminder's restore link. The customer
+// signed in to the reminded account gets their cart as it is; anyone else,
+// such as the customer on a device they are not signed in on, gets its items
+// copied into their own cart, up to the quantities of the reminded cart.
+func (s *Service) Restore(owner Owner, token string) (*cart.Cart, error) {
+	id, err := s.tokens.ParseRestore(token)
+	if err != nil {
+		return nil, err
+	}
+
+	reminded, err := s.cartRepo.GetCart(id)
+	if err == apperrors.ErrNotFound {
+		return nil, apperrors.ErrInvalidRestoreLink
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if owner.UserID != "" && owner.UserID == reminded.UserID {
+		return s.price(reminded)
+	}
+
+	c, err := s.load(owner, reminded.Currency, true)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.copyItems(c, reminded, func(current, quantity int) int {
+		return max(current, quantity)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cartRepo.MergeItems(c.ID, items, carriedDiscount(c, reminded), ""); err != nil {
+		return nil, err
+	}
+
+	token = c.Token
+	if c, err = s.cartRepo.GetCart(c.ID); err != nil {
+		return nil, err
+	}
+	c.Token = token
+
+	return s.price(c)
+}
+
+// copyItems returns the lines of c that change when the items of from are
+// copied into it, combining the quantity already in c with the one copied.
+// Quantities are capped at the stock available; items that can no longer be
+// bought are left out.
+func (s *Service) copyItems(c, from *cart.Cart, combine func(current, quantity int) int) ([]cart.Item, error) {
+	var changed []cart.Item
+	for _, item := range from.Items {
+		p, err := s.productRepo.GetProduct(item.ProductID)
+		if err == apperrors.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line := c.FindItem(item.ProductID, item.VariantID)
+		current := 0
+		if line != nil {
+			current = line.Quantity
+		}
+
+		quantity := combine(current, item.Quantity)
+		if _, err := p.CheckPurchasable(item.VariantID, quantity); err != nil {
+			if err != product.ErrInsufficientStock {
+				continue
+			}
+			quantity = p.AvailableQuantity(item.VariantID)
+		}
+		if quantity <= current {
+			continue
+		}
+
+		copied := cart.Item{CartID: c.ID, ProductID: item.ProductID, VariantID: item.VariantID}
+		if line != nil {
+			copied = *line
+		}
+		copied.Quantity = quantity
+		changed = append(changed, copied)
+	}
+
+	return changed, nil
+}
+
+// carriedDiscount returns the discount code of from that carries over to c,
+// which keeps its own code if it has one
+func carriedDiscount(c, from *cart.Cart) string {
+	if c.DiscountCode != "" {
+		return ""
+	}
+	return from.DiscountCode
+}
+
+// ApplyDiscount applies a discount code to the cart. The code must apply to
+// the cart as it is now; it replaces any code applied before.
+func (s *Service) ApplyDiscount(owner Owner, currency, code string) (*cart.Cart, error) {
+	c, err := s.load(owner, currency, true)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := s.priceItems(c)
+	if err != nil {
+		return nil, err
+	}
+
+	code = promotion.NormalizeCode(code)
+	b, err := s.promotions.Evaluate(c.UserID, c.Currency, code, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cartRepo.SetDiscountCode(c.ID, code); err != nil {
+		return nil, err
+	}
+	c.DiscountCode = code
+
+	if err := c.ApplyDiscounts(b); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// RemoveDiscount removes the discount code from the cart
+func (s *Service) RemoveDiscount(owner Owner, currency string) (*cart.Cart, error) {
+	c, err := s.load(owner, currency, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.DiscountCode != "" {
+		if err := s.cartRepo.SetDiscountCode(c.ID, ""); err != nil {
+			return nil, err
+		}
+		c.DiscountCode = ""
+	}
+
+	return s.price(c)
+}
+
+// price fills in current unit prices, subtotal and discounts. A discount code
+// that no longer applies (expired, used up, or the cart changed) stays on the
+// cart but is left out of Promotions until it applies again.
+func (s *Service) price(c *cart.Cart) (*cart.Cart, error) {
+	lines, err := s.priceItems(c)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.promotions.Evaluate(c.UserID, c.Currency, c.DiscountCode, lines)
+	if c.DiscountCode != "" {
+		switch err {
+		case promotion.ErrInvalidCode, promotion.ErrUsageLimitReached, promotion.ErrNotApplicable:
+			b, err = s.promotions.Evaluate(c.UserID, c.Currency, "", lines)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.ApplyDiscounts(b); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// priceItems fills in current unit prices in the cart currency and the
+// subtotal, returning the lines promotions are evaluated against. Items whose
+// product has since been deleted are priced at zero; checkout rejects them.
+func (s *Service) priceItems(c *cart.Cart) ([]promotionapp.LineInput, error) {
+	sel, err := s.pricing.Select(c.UserID, c.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]promotionapp.LineInput, len(c.Items))
+	for i := range c.Items {
+		item := &c.Items[i]
+		lines[i] = promotionapp.LineInput{
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+		}
+
+		p, err := s.productRepo.GetProduct(item.ProductID)
+		if err == apperrors.ErrNotFound {
+			item.UnitPrice = money.Zero(c.Currency)
+			lines[i].UnitPrice = item.UnitPrice
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if item.UnitPrice, err = s.pricing.Resolve(p, p.FindVariant(item.VariantID), sel); err != nil {
+			return nil, err
+		}
+		lines[i].CategoryID = p.CategoryID
+		lines[i].UnitPrice = item.UnitPrice
+	}
+
+	if err := c.CalculateSubtotal(c.Currency); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
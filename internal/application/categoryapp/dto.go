@@ -0,0 +1,23 @@
+package categoryapp
+
+import "github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
+
+// CategoryInput represents the input for creating or updating a category. An
+// empty Slug is derived from Name; an empty ParentID makes a root category.
+type CategoryInput struct {
+	Name     string
+	Slug     string
+	ParentID string
+	SEO      seo.Metadata
+}
+
+// CreateAttributeInput represents the input for defining a category attribute
+type CreateAttributeInput struct {
+	Key      string
+	Label    string
+	Type     string
+	Unit     string
+	Options  []string
+	Required bool
+	Position int
+}
@@ -2,6 +2,7 @@ package categoryapp
 
 import (
 	"github.com/RubenRodrigo/go-tiny-store/internal/domain/category"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
 )
 
 // Service handles category-related use cases
@@ -20,12 +21,42 @@ func (s *Service) List() ([]*category.Category, error) {
 	return s.categoryRepo.ListCategories()
 }
 
+// Tree returns the root categories with their descendants nested under them.
+// Product counts include enabled products in descendant categories.
+func (s *Service) Tree() ([]*category.Category, error) {
+	categories, err := s.categoryRepo.ListCategoriesWithCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	return category.BuildTree(categories), nil
+}
+
 func (s *Service) GetByID(id string) (*category.Category, error) {
 	return s.categoryRepo.GetCategoryByID(id)
 }
 
-func (s *Service) Create(name string) (*category.Category, error) {
-	c := &category.Category{Name: name}
+func (s *Service) GetBySlug(slug string) (*category.Category, error) {
+	return s.categoryRepo.GetCategoryBySlug(slug)
+}
+
+func (s *Service) Create(input CategoryInput) (*category.Category, error) {
+	slug, err := resolveSlug(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ParentID != "" {
+		ancestors, err := s.categoryRepo.Ancestors(input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if len(ancestors)+1 > category.MaxDepth {
+			return nil, category.ErrTooDeep
+		}
+	}
+
+	c := &category.Category{Name: input.Name, Slug: slug, SEO: input.SEO, ParentID: input.ParentID}
 
 	if err := s.categoryRepo.CreateCategory(c); err != nil {
 		return nil, err
@@ -34,8 +65,33 @@ func (s *Service) Create(name string) (*category.Category, error) {
 	return c, nil
 }
 
-func (s *Service) Update(id, name string) (*category.Category, error) {
-	c := &category.Category{Name: name}
+// Update renames and optionally moves a category. A move is rejected when the
+// new parent is the category itself or one of its descendants, or when the
+// moved subtree would end up deeper than category.MaxDepth.
+func (s *Service) Update(id string, input CategoryInput) (*category.Category, error) {
+	slug, err := resolveSlug(input)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.categoryRepo.GetCategoryByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ParentID != existing.ParentID {
+		if err := s.checkMove(id, input.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &category.Category{
+		Name:      input.Name,
+		Slug:      slug,
+		SEO:       input.SEO,
+		ParentID:  input.ParentID,
+		CreatedAt: existing.CreatedAt,
+	}
 
 	if err := s.categoryRepo.UpdateCategory(id, c); err != nil {
 		return nil, err
@@ -44,6 +100,129 @@ func (s *Service) Update(id, name string) (*category.Category, error) {
 	return c, nil
 }
 
-func (s *Service) Delete(id string) error {
-	return s.categoryRepo.DeleteCategory(id)
+func (s *Service) checkMove(id, parentID string) error {
+	parentLevel := 0
+	if parentID != "" {
+		ancestors, err := s.categoryRepo.Ancestors(parentID)
+		if err != nil {
+			return err
+		}
+		for _, a := range ancestors {
+			if a.ID == id {
+				return category.ErrCycle
+			}
+		}
+		parentLevel = len(ancestors)
+	}
+
+	categories, err := s.categoryRepo.ListCategories()
+	if err != nil {
+		return err
+	}
+
+	for _, root := range category.BuildTree(categories) {
+		if subtree := findCategory(root, id); subtree != nil {
+			if parentLevel+category.Height(subtree) > category.MaxDepth {
+				return category.ErrTooDeep
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+func findCategory(c *category.Category, id string) *category.Category {
+	if c.ID == id {
+		return c
+	}
+	for _, child := range c.Children {
+		if found := findCategory(child, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Delete removes a category, moving its products to reassignTo. Products of
+// descendant categories stay where they are; the children move up a level.
+func (s *Service) Delete(id, reassignTo string) (*category.DeleteResult, error) {
+	if reassignTo == id {
+		return nil, category.ErrInvalidReassignTarget
+	}
+
+	return s.categoryRepo.DeleteCategory(id, reassignTo)
+}
+
+// Attributes returns the attribute schema that applies to products of the
+// category, including attributes inherited from its ancestors
+func (s *Service) Attributes(categoryID string) ([]*category.Attribute, error) {
+	path, err := s.categoryRepo.Ancestors(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIDs := make([]string, len(path))
+	for i, c := range path {
+		categoryIDs[i] = c.ID
+	}
+
+	attributes, err := s.categoryRepo.ListAttributes(categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return category.Schema(path, attributes), nil
+}
+
+// CreateAttribute defines an attribute on a category. Redefining a key of an
+// ancestor overrides it for this subtree.
+func (s *Service) CreateAttribute(categoryID string, input CreateAttributeInput) (*category.Attribute, error) {
+	if _, err := s.categoryRepo.GetCategoryByID(categoryID); err != nil {
+		return nil, err
+	}
+
+	a := &category.Attribute{
+		CategoryID: categoryID,
+		Key:        input.Key,
+		Label:      input.Label,
+		Type:       category.AttributeType(input.Type),
+		Unit:       input.Unit,
+		Options:    input.Options,
+		Required:   input.Required,
+		Position:   input.Position,
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.categoryRepo.CreateAttribute(a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// DeleteAttribute removes an attribute and the values products had for it
+func (s *Service) DeleteAttribute(categoryID, attributeID string) error {
+	return s.categoryRepo.DeleteAttribute(categoryID, attributeID)
+}
+
+func resolveSlug(input CategoryInput) (string, error) {
+	if err := input.SEO.Validate(); err != nil {
+		return "", err
+	}
+
+	if input.Slug == "" {
+		slug := seo.Slugify(input.Name)
+		if slug == "" {
+			return "", seo.ErrInvalidSlug
+		}
+		return slug, nil
+	}
+
+	if !seo.ValidSlug(input.Slug) {
+		return "", seo.ErrInvalidSlug
+	}
+	return input.Slug, nil
 }
@@ -0,0 +1,55 @@
+package checkoutapp
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+)
+
+// Config holds checkout settings
+type Config struct {
+	ReservationTTL   time.Duration
+	PricesIncludeTax bool // prices already include tax, which is then only broken out
+}
+
+// AddressInput represents a postal address given at checkout
+type AddressInput struct {
+	Name       string
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string
+	Phone      string
+}
+
+// ShippingRatesInput represents the input for quoting shipping for the cart
+type ShippingRatesInput struct {
+	ShippingAddress   *AddressInput // takes precedence over ShippingAddressID
+	ShippingAddressID string        // address book entry; the default shipping address when both are empty
+}
+
+// CreateInput represents the input for starting a checkout
+type CreateInput struct {
+	ShippingAddress   *AddressInput // takes precedence over ShippingAddressID
+	ShippingAddressID string        // address book entry; the default shipping address when both are empty
+	BillingAddress    *AddressInput // takes precedence over BillingAddressID
+	BillingAddressID  string        // address book entry; the default billing address, then the shipping address, when both are empty
+	ShippingMethodID  string        // ID of a quote returned by ShippingRates
+	Email             string        // contact address of a guest order; required for guests
+	GiftCardCodes     []string      // redeemed in the order given before the card payment
+	UseStoreCredit    bool          // spend the customer's store credit after the gift cards; ignored for guests
+}
+
+// CheckoutResultDTO represents a started checkout awaiting payment. When
+// gift cards and store credit cover the whole total the order is already
+// paid, and there is no payment to complete.
+type CheckoutResultDTO struct {
+	Order        *order.Order `json:"order"`
+	AmountDue    money.Money  `json:"amount_due"` // left for the card payment
+	PaymentID    string       `json:"payment_id"`
+	ClientSecret string       `json:"client_secret"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+}
@@ -0,0 +1,608 @@
+package checkoutapp
+
+import (
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/addressapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/cartapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/giftcardapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/inventoryapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/invoiceapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/pricingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/promotionapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/shippingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/address"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/cart"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/inventory"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/payment"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/pricing"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/promotion"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/shipping"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/tax"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+// Service handles checkout and payment use cases
+type Service struct {
+	cartRepo         cart.Repository
+	productRepo      product.Repository
+	orderRepo        order.Repository
+	reservationRepo  inventory.ReservationRepository
+	paymentGateway   payment.Gateway
+	taxCalculator    tax.Calculator
+	inventoryService *inventoryapp.Service
+	pricingService   *pricingapp.Service
+	promotionService *promotionapp.Service
+	shippingService  *shippingapp.Service
+	addressService   *addressapp.Service
+	invoiceService   *invoiceapp.Service
+	cartService      *cartapp.Service
+	giftCardService  *giftcardapp.Service
+	config           Config
+}
+
+// NewService creates a new checkout application service
+func NewService(
+	cartRepo cart.Repository,
+	productRepo product.Repository,
+	orderRepo order.Repository,
+	reservationRepo inventory.ReservationRepository,
+	paymentGateway payment.Gateway,
+	taxCalculator tax.Calculator,
+	inventoryService *inventoryapp.Service,
+	pricingService *pricingapp.Service,
+	promotionService *promotionapp.Service,
+	shippingService *shippingapp.Service,
+	addressService *addressapp.Service,
+	invoiceService *invoiceapp.Service,
+	cartService *cartapp.Service,
+	giftCardService *giftcardapp.Service,
+	config Config,
+) *Service {
+	return &Service{
+		cartRepo:         cartRepo,
+		productRepo:      productRepo,
+		orderRepo:        orderRepo,
+		reservationRepo:  reservationRepo,
+		paymentGateway:   paymentGateway,
+		taxCalculator:    taxCalculator,
+		inventoryService: inventoryService,
+		pricingService:   pricingService,
+		promotionService: promotionService,
+		shippingService:  shippingService,
+		addressService:   addressService,
+		invoiceService:   invoiceService,
+		cartService:      cartService,
+		giftCardService:  giftCardService,
+		config:           config,
+	}
+}
+
+// ShippingRates returns the shipping options for the owner's cart and the
+// address, with any free shipping promotion already applied
+func (s *Service) ShippingRates(owner cartapp.Owner, input ShippingRatesInput) ([]shipping.Quote, error) {
+	shippingAddress, _, err := s.resolveAddresses(owner.UserID, input.ShippingAddress, input.ShippingAddressID, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.prepare(owner, shippingAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, err := s.shippingService.Quote(rateRequest(d))
+	if err != nil {
+		return nil, err
+	}
+	if d.discounts.FreeShipping {
+		for i := range quotes {
+			quotes[i].Amount = money.Zero(quotes[i].Amount.Currency)
+		}
+	}
+
+	return quotes, nil
+}
+
+// Create turns the owner's cart into a pending order in the cart currency,
+// applies its promotions, the chosen shipping method and the tax of the
+// shipping address, reserves its stock for the reservation TTL, redeems the
+// gift cards and store credit given and starts the card payment for the rest.
+// An order the balances pay in full is paid straight away. The order keeps a
+// copy of its addresses. A discount code on the cart that no longer applies
+// fails the checkout so the customer is never charged more than shown. Guests
+// check out with an email address and addresses given inline.
+func (s *Service) Create(owner cartapp.Owner, input CreateInput) (*CheckoutResultDTO, error) {
+	if owner.UserID == "" && input.Email == "" {
+		return nil, apperrors.ErrGuestEmailRequired
+	}
+
+	shippingAddress, billingAddress, err := s.resolveAddresses(owner.UserID,
+		input.ShippingAddress, input.ShippingAddressID, input.BillingAddress, input.BillingAddressID)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.prepare(owner, shippingAddress)
+	if err != nil {
+		return nil, err
+	}
+	c, o, discounts := d.cart, d.order, d.discounts
+	if owner.UserID == "" {
+		o.Email = input.Email
+	}
+	o.BillingAddress = billingAddress
+
+	quote, err := s.shippingService.Choose(rateRequest(d), input.ShippingMethodID)
+	if err != nil {
+		return nil, err
+	}
+	o.ShippingMethod = quote.Name
+	o.Shipping = quote.Amount
+	if discounts.FreeShipping {
+		o.Shipping = money.Zero(c.Currency)
+	}
+
+	if err := s.applyTax(o, d.taxClasses, d.customerGroup); err != nil {
+		return nil, err
+	}
+	if err := o.CalculateTotal(); err != nil {
+		return nil, err
+	}
+
+	if err := s.orderRepo.CreateOrder(o); err != nil {
+		return nil, err
+	}
+
+	if err := s.promotionService.Redeem(owner.UserID, o.ID, discounts); err != nil {
+		s.cancel(o.ID)
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.config.ReservationTTL)
+	reservations := make([]*inventory.Reservation, len(o.Lines))
+	for i, line := range o.Lines {
+		reservations[i] = &inventory.Reservation{
+			ProductID: line.ProductID,
+			VariantID: line.VariantID,
+			OrderID:   o.ID,
+			Quantity:  line.Quantity,
+			ExpiresAt: expiresAt,
+		}
+	}
+
+	if err := s.reservationRepo.Reserve(reservations); err != nil {
+		s.cancel(o.ID)
+		return nil, err
+	}
+
+	if o.Redeemed, err = s.giftCardService.Redeem(o, input.GiftCardCodes, input.UseStoreCredit); err != nil {
+		s.cancel(o.ID)
+		return nil, err
+	}
+
+	result := &CheckoutResultDTO{
+		Order:     o,
+		AmountDue: o.CardAmount(),
+		ExpiresAt: expiresAt,
+	}
+	if result.AmountDue.Amount > 0 {
+		intent, err := s.paymentGateway.CreateIntent(o.ID, result.AmountDue)
+		if err != nil {
+			s.cancel(o.ID)
+			return nil, err
+		}
+
+		if err := s.orderRepo.SetPaymentID(o.ID, intent.ID); err != nil {
+			// An order without its payment ID cannot be refunded; drop both
+			// before the customer gets a chance to pay
+			if err := s.paymentGateway.CancelIntent(intent.ID); err != nil {
+				log.Printf("ERROR: Failed to cancel payment intent. OrderID: %s, PaymentID: %s, Error: %v", o.ID, intent.ID, err)
+			}
+			s.cancel(o.ID)
+			return nil, err
+		}
+		o.PaymentID = intent.ID
+		result.PaymentID = intent.ID
+		result.ClientSecret = intent.ClientSecret
+	} else {
+		// Nothing is left to charge. Sales that cannot be recorded yet are
+		// retried by the sweeper once the reservations expire.
+		paid, err := s.markPaid(o.ID)
+		if !paid {
+			if err == nil {
+				err = apperrors.ErrCheckoutExpired
+			}
+			return nil, err
+		}
+		if err != nil {
+			log.Printf("ERROR: Failed to convert reservations of paid order %s: %v", o.ID, err)
+		}
+		o.Status = order.StatusPaid
+	}
+
+	// The order now holds the items; a failure here only leaves a stale cart
+	if err := s.cartRepo.ClearCart(c.ID); err != nil {
+		log.Printf("Warning: failed to clear cart %s after checkout: %v", c.ID, err)
+	}
+	if c.DiscountCode != "" {
+		if err := s.cartRepo.SetDiscountCode(c.ID, ""); err != nil {
+			log.Printf("Warning: failed to remove discount code from cart %s after checkout: %v", c.ID, err)
+		}
+	}
+	if err := s.cartService.MarkRecovered(c.ID, o.ID); err != nil {
+		log.Printf("Warning: failed to credit cart reminders of cart %s with order %s: %v", c.ID, o.ID, err)
+	}
+
+	return result, nil
+}
+
+// HandlePaymentWebhook applies a payment provider notification. It is safe to
+// receive the same event more than once.
+func (s *Service) HandlePaymentWebhook(payload []byte, signature string) error {
+	event, err := s.paymentGateway.ParseWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	if event.OrderID == "" {
+		return nil
+	}
+
+	switch event.Type {
+	case payment.EventSucceeded:
+		paid, err := s.markPaid(event.OrderID)
+		if err != nil || paid {
+			return err
+		}
+		return s.refundCancelled(event)
+	case payment.EventFailed:
+		return s.cancel(event.OrderID)
+	}
+
+	return nil
+}
+
+// ReleaseExpired cancels pending orders whose reservations have expired
+func (s *Service) ReleaseExpired() error {
+	orderIDs, err := s.reservationRepo.ListExpiredOrderIDs(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, id := range orderIDs {
+		if err := s.expire(id); err != nil {
+			log.Printf("Warning: failed to release expired reservations for order %s: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// expire cancels a pending order whose reservations ran out. A paid order
+// still holding reservations failed to convert them, so that is retried.
+func (s *Service) expire(orderID string) error {
+	o, err := s.orderRepo.GetOrder(orderID)
+	if err != nil {
+		return err
+	}
+
+	if o.Status.Paid() {
+		_, err := s.markPaid(orderID)
+		return err
+	}
+	return s.cancel(orderID)
+}
+
+// markPaid moves a pending order to paid, then turns its reservations into
+// sales. The status moves first so a concurrent cancel cannot release stock
+// that is being sold; it reports false when the order was cancelled first.
+func (s *Service) markPaid(orderID string) (bool, error) {
+	ok, err := s.orderRepo.TransitionStatus(orderID, order.StatusPending, order.StatusPaid)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		o, err := s.orderRepo.GetOrder(orderID)
+		if err != nil {
+			return false, err
+		}
+		if o.Status == order.StatusCancelled {
+			return false, nil
+		}
+	}
+
+	// Conversion only touches active reservations, so a retried webhook
+	// finishes what a failed attempt left and is otherwise a no-op
+	sales, err := s.reservationRepo.ConvertReservations(orderID)
+	if err != nil {
+		return true, err
+	}
+	if !ok && len(sales) == 0 {
+		return true, nil
+	}
+
+	for _, sale := range sales {
+		s.inventoryService.CheckLowStock(sale)
+	}
+
+	// The payment is already recorded; a missing invoice is issued on first download
+	if _, err := s.invoiceService.IssueInvoice(orderID); err != nil {
+		log.Printf("ERROR: Failed to issue invoice. OrderID: %s, Error: %v", orderID, err)
+	}
+
+	// Missing gift cards are issued again by a manager
+	if _, err := s.giftCardService.IssueForOrder(orderID); err != nil {
+		log.Printf("ERROR: Failed to issue gift cards. OrderID: %s, Error: %v", orderID, err)
+	}
+
+	return true, nil
+}
+
+// refundCancelled gives back a payment that succeeded after its order was
+// cancelled, e.g. by the reservation sweeper while the customer was paying
+func (s *Service) refundCancelled(event *payment.Event) error {
+	log.Printf("Warning: payment %s succeeded for cancelled order %s, refunding it", event.PaymentID, event.OrderID)
+
+	_, err := s.paymentGateway.Refund(event.PaymentID, event.Amount, "cancelled-"+event.OrderID)
+	return err
+}
+
+// cancel moves a pending order to cancelled and returns its reserved stock,
+// promotion uses and redeemed balances
+func (s *Service) cancel(orderID string) error {
+	ok, err := s.orderRepo.TransitionStatus(orderID, order.StatusPending, order.StatusCancelled)
+	if err != nil || !ok {
+		return err
+	}
+
+	if err := s.reservationRepo.ReleaseReservations(orderID); err != nil {
+		return err
+	}
+
+	if err := s.promotionService.Release(orderID); err != nil {
+		return err
+	}
+
+	return s.giftCardService.Release(orderID)
+}
+
+// draft is an order priced from a cart, before shipping, tax and storage
+type draft struct {
+	cart          *cart.Cart
+	order         *order.Order
+	discounts     *promotion.Breakdown
+	taxClasses    []string // tax class of each line
+	customerGroup string
+	weightGrams   int
+}
+
+// prepare prices the owner's cart into an order for the shipping address and
+// applies its promotions
+func (s *Service) prepare(owner cartapp.Owner, address order.Address) (*draft, error) {
+	c, err := s.cartService.Find(owner)
+	if err != nil {
+		if err == apperrors.ErrNotFound {
+			return nil, apperrors.ErrCartEmpty
+		}
+		return nil, err
+	}
+	if len(c.Items) == 0 {
+		return nil, apperrors.ErrCartEmpty
+	}
+
+	sel, err := s.pricingService.Select(owner.UserID, c.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &draft{
+		cart: c,
+		order: &order.Order{
+			UserID:          owner.UserID,
+			Status:          order.StatusPending,
+			Fulfilment:      order.FulfilmentUnfulfilled,
+			Total:           money.Zero(c.Currency),
+			DiscountCode:    c.DiscountCode,
+			ShippingAddress: address,
+			TaxInclusive:    s.config.PricesIncludeTax,
+		},
+		taxClasses:    make([]string, len(c.Items)),
+		customerGroup: sel.CustomerGroup,
+	}
+	promotionLines := make([]promotionapp.LineInput, len(c.Items))
+	for i, item := range c.Items {
+		line, p, err := s.buildLine(item, sel)
+		if err != nil {
+			return nil, err
+		}
+		d.order.Lines = append(d.order.Lines, *line)
+		promotionLines[i] = promotionapp.LineInput{
+			ProductID:  line.ProductID,
+			VariantID:  line.VariantID,
+			CategoryID: p.CategoryID,
+			UnitPrice:  line.UnitPrice,
+			Quantity:   line.Quantity,
+		}
+		d.taxClasses[i] = p.TaxClass
+		d.weightGrams += p.WeightGrams * line.Quantity
+	}
+
+	d.discounts, err = s.promotionService.Evaluate(owner.UserID, c.Currency, c.DiscountCode, promotionLines)
+	if err != nil {
+		return nil, err
+	}
+	for i := range d.order.Lines {
+		d.order.Lines[i].Discount = d.discounts.Discounts[i]
+	}
+
+	return d, nil
+}
+
+// resolveAddresses picks the shipping and billing addresses of a checkout:
+// an address given inline, else the address book entry with the given ID,
+// else the user's default. Billing falls back to the shipping address. Guests
+// have no address book, so only inline addresses apply to them.
+func (s *Service) resolveAddresses(
+	userID string,
+	shippingInput *AddressInput,
+	shippingID string,
+	billingInput *AddressInput,
+	billingID string,
+) (order.Address, order.Address, error) {
+	var defaultShipping, defaultBilling *address.Address
+	if userID != "" && ((shippingInput == nil && shippingID == "") || (billingInput == nil && billingID == "")) {
+		var err error
+		if defaultShipping, defaultBilling, err = s.addressService.Defaults(userID); err != nil {
+			return order.Address{}, order.Address{}, err
+		}
+	}
+
+	shippingAddress, ok, err := s.resolveAddress(userID, shippingInput, shippingID, defaultShipping)
+	if err != nil {
+		return order.Address{}, order.Address{}, err
+	}
+	if !ok {
+		return order.Address{}, order.Address{}, order.ErrInvalidAddress
+	}
+
+	billingAddress, ok, err := s.resolveAddress(userID, billingInput, billingID, defaultBilling)
+	if err != nil {
+		return order.Address{}, order.Address{}, err
+	}
+	if !ok {
+		billingAddress = shippingAddress
+	}
+
+	return shippingAddress, billingAddress, nil
+}
+
+// resolveAddress returns the validated address a checkout refers to, and
+// false when it refers to none
+func (s *Service) resolveAddress(userID string, input *AddressInput, id string, fallback *address.Address) (order.Address, bool, error) {
+	var a order.Address
+	switch {
+	case input != nil:
+		a = order.Address(*input)
+	case id != "":
+		entry, err := s.addressService.Get(userID, id)
+		if err != nil {
+			return order.Address{}, false, err
+		}
+		a = snapshot(entry)
+	case fallback != nil:
+		a = snapshot(fallback)
+	default:
+		return order.Address{}, false, nil
+	}
+
+	a.Normalize()
+	if err := a.Validate(); err != nil {
+		return order.Address{}, false, err
+	}
+	return a, true, nil
+}
+
+// snapshot copies an address book entry for an order
+func snapshot(a *address.Address) order.Address {
+	return order.Address{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+		Phone:      a.Phone,
+	}
+}
+
+// rateRequest describes the parcel of a draft order for shipping quotes
+func rateRequest(d *draft) shipping.RateRequest {
+	subtotal := money.Zero(d.cart.Currency)
+	for i := range d.order.Lines {
+		subtotal.Amount += d.order.Lines[i].LineTotal().Amount
+	}
+
+	return shipping.RateRequest{
+		Destination: shipping.Destination{
+			Country:    d.order.ShippingAddress.Country,
+			Region:     d.order.ShippingAddress.Region,
+			PostalCode: d.order.ShippingAddress.PostalCode,
+		},
+		WeightGrams: d.weightGrams,
+		Subtotal:    subtotal,
+	}
+}
+
+// applyTax sets the tax of each discounted order line for the shipping
+// address of the order
+func (s *Service) applyTax(o *order.Order, taxClasses []string, customerGroup string) error {
+	req := tax.Request{
+		Currency: o.Total.Currency,
+		Lines:    make([]tax.Line, len(o.Lines)),
+		Address: tax.Address{
+			Country:    o.ShippingAddress.Country,
+			Region:     o.ShippingAddress.Region,
+			PostalCode: o.ShippingAddress.PostalCode,
+		},
+		Customer:         tax.Customer{UserID: o.UserID, CustomerGroup: customerGroup},
+		PricesIncludeTax: o.TaxInclusive,
+	}
+	for i := range o.Lines {
+		req.Lines[i] = tax.Line{
+			ProductID: o.Lines[i].ProductID,
+			TaxClass:  taxClasses[i],
+			Amount:    o.Lines[i].LineTotal(),
+		}
+	}
+
+	result, err := s.taxCalculator.Calculate(req)
+	if err != nil {
+		return err
+	}
+
+	for i, lineTax := range result.Lines {
+		o.Lines[i].Tax = lineTax.Amount
+		o.Lines[i].TaxRate = tax.FormatPercent(lineTax.Rate)
+	}
+	return nil
+}
+
+// buildLine snapshots a cart item into an order line, also returning the
+// product for the rules that depend on it
+func (s *Service) buildLine(item cart.Item, sel pricing.Selection) (*order.Line, *product.Product, error) {
+	p, err := s.productRepo.GetProduct(item.ProductID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, err := p.CheckPurchasable(item.VariantID, item.Quantity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unitPrice, err := s.pricingService.Resolve(p, v, sel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	line := &order.Line{
+		ProductID: p.ID,
+		VariantID: item.VariantID,
+		Name:      p.Name,
+		UnitPrice: unitPrice,
+		Discount:  money.Zero(unitPrice.Currency),
+		Quantity:  item.Quantity,
+		GiftCard:  p.GiftCard,
+	}
+	if v != nil {
+		line.SKU = v.SKU
+	}
+
+	return line, p, nil
+}
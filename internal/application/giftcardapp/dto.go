@@ -0,0 +1,33 @@
+package giftcardapp
+
+import (
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/giftcard"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// IssueInput represents a gift card issued by a manager, e.g. as a goodwill
+// gesture. The code is emailed when Email is set.
+type IssueInput struct {
+	Amount   string // decimal amount in Currency
+	Currency string
+	Email    string
+}
+
+// GiftCardDTO represents a gift card with its ledger
+type GiftCardDTO struct {
+	GiftCard *giftcard.GiftCard `json:"gift_card"`
+	Entries  []*giftcard.Entry  `json:"entries"`
+}
+
+// BalanceDTO represents what is left on a gift card, as shown to whoever
+// holds the code
+type BalanceDTO struct {
+	Code    string      `json:"code"`
+	Balance money.Money `json:"balance"`
+}
+
+// StoreCreditDTO represents a customer's store credit with its ledger
+type StoreCreditDTO struct {
+	Balances []money.Money     `json:"balances"` // one per currency
+	Entries  []*giftcard.Entry `json:"entries"`
+}
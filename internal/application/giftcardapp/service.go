@@ -0,0 +1,337 @@
+package giftcardapp
+
+import (
+	"log"
+	"strings"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/giftcard"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/user"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+// maxCodeAttempts bounds the codes tried when a generated one is taken
+const maxCodeAttempts = 5
+
+// Service handles gift card and store credit use cases
+type Service struct {
+	giftCardRepo giftcard.Repository
+	orderRepo    order.Repository
+	userRepo     user.Repository
+	codes        giftcard.CodeGenerator
+	notifier     giftcard.Notifier
+}
+
+// NewService creates a new gift card application service
+func NewService(
+	giftCardRepo giftcard.Repository,
+	orderRepo order.Repository,
+	userRepo user.Repository,
+	codes giftcard.CodeGenerator,
+	notifier giftcard.Notifier,
+) *Service {
+	return &Service{
+		giftCardRepo: giftCardRepo,
+		orderRepo:    orderRepo,
+		userRepo:     userRepo,
+		codes:        codes,
+		notifier:     notifier,
+	}
+}
+
+// Issue creates a gift card for a manager and emails its code when an
+// address is given
+func (s *Service) Issue(input IssueInput, actorID string) (*giftcard.GiftCard, error) {
+	amount, err := money.Parse(input.Amount, strings.ToUpper(input.Currency))
+	if err != nil {
+		return nil, err
+	}
+	if amount.Amount <= 0 {
+		return nil, money.ErrInvalidAmount
+	}
+
+	card := &giftcard.GiftCard{
+		Initial: amount,
+		Email:   strings.TrimSpace(input.Email),
+	}
+	if err := s.create(card, actorID); err != nil {
+		return nil, err
+	}
+
+	s.notify(card)
+	return card, nil
+}
+
+// IssueForOrder creates and emails the gift cards bought with a paid order
+// and returns all of them. Each card is worth what was paid for it, after
+// promotions. It is safe to call again; lines that already have their cards
+// are skipped.
+func (s *Service) IssueForOrder(orderID string) ([]*giftcard.GiftCard, error) {
+	o, err := s.orderRepo.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !o.HasGiftCards() {
+		return nil, nil
+	}
+	if !o.Status.Paid() {
+		return nil, giftcard.ErrOrderNotPaid
+	}
+
+	cards, err := s.giftCardRepo.ListOrderGiftCards(o.ID)
+	if err != nil {
+		return nil, err
+	}
+	issued := make(map[string]int, len(cards))
+	for _, card := range cards {
+		issued[card.OrderLineID]++
+	}
+
+	email := o.Email
+	if o.UserID != "" {
+		u, err := s.userRepo.GetUserByID(o.UserID)
+		if err != nil {
+			return nil, err
+		}
+		email = u.Email
+	}
+
+	for _, line := range o.Lines {
+		if !line.GiftCard {
+			continue
+		}
+		values := line.CardValues()
+		for i := issued[line.ID]; i < len(values); i++ {
+			card := &giftcard.GiftCard{
+				Initial:     values[i],
+				OrderID:     o.ID,
+				OrderLineID: line.ID,
+				Email:       email,
+			}
+			if err := s.create(card, ""); err != nil {
+				return nil, err
+			}
+			s.notify(card)
+			cards = append(cards, card)
+		}
+	}
+
+	return cards, nil
+}
+
+// create stores a card under a new code, drawing again if the code is taken
+func (s *Service) create(card *giftcard.GiftCard, actorID string) error {
+	card.Balance = card.Initial
+
+	var err error
+	for attempt := 0; attempt < maxCodeAttempts; attempt++ {
+		if card.Code, err = s.codes.Generate(); err != nil {
+			log.Printf("ERROR: Failed to generate gift card code. Error: %v", err)
+			return err
+		}
+		if err = s.giftCardRepo.CreateGiftCard(card, actorID); err != apperrors.ErrDuplicateEntry {
+			return err
+		}
+	}
+	return err
+}
+
+// notify emails the code of a card; the card exists either way, and its code
+// can be looked up by a manager
+func (s *Service) notify(card *giftcard.GiftCard) {
+	if card.Email == "" {
+		return
+	}
+
+	notice := giftcard.IssuedNotice{To: card.Email, Code: card.Code, Balance: card.Balance}
+	if err := s.notifier.NotifyIssued(notice); err != nil {
+		log.Printf("Warning: failed to email gift card %s: %v", card.ID, err)
+	}
+}
+
+// Get returns a gift card with its ledger
+func (s *Service) Get(id string) (*GiftCardDTO, error) {
+	card, err := s.giftCardRepo.GetGiftCard(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.giftCardRepo.ListEntries(giftcard.EntryFilters{GiftCardID: card.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiftCardDTO{GiftCard: card, Entries: entries}, nil
+}
+
+// Balance returns what is left on the gift card with the code
+func (s *Service) Balance(code string) (*BalanceDTO, error) {
+	card, err := s.giftCardRepo.GetGiftCardByCode(giftcard.NormalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	return &BalanceDTO{Code: card.Code, Balance: card.Balance}, nil
+}
+
+// StoreCredit returns the user's store credit with its ledger
+func (s *Service) StoreCredit(userID string) (*StoreCreditDTO, error) {
+	credits, err := s.giftCardRepo.ListStoreCredit(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.giftCardRepo.ListEntries(giftcard.EntryFilters{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]money.Money, len(credits))
+	for i, credit := range credits {
+		balances[i] = credit.Balance
+	}
+	return &StoreCreditDTO{Balances: balances, Entries: entries}, nil
+}
+
+// Redeem pays as much of a pending order as the gift cards with the codes
+// cover, in the order given, then the customer's store credit when asked
+// for. It returns the amount redeemed; the rest is left for the card
+// payment. Guests have no store credit, and orders buying gift cards cannot
+// be paid with a balance.
+func (s *Service) Redeem(o *order.Order, codes []string, useStoreCredit bool) (money.Money, error) {
+	userID := ""
+	if useStoreCredit {
+		userID = o.UserID
+	}
+	if len(codes) == 0 && userID == "" {
+		return money.Zero(o.Total.Currency), nil
+	}
+	if o.HasGiftCards() {
+		return money.Money{}, giftcard.ErrPurchaseNotAllowed
+	}
+
+	var ids []string
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		code = giftcard.NormalizeCode(code)
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		card, err := s.giftCardRepo.GetGiftCardByCode(code)
+		if err != nil {
+			if err == apperrors.ErrNotFound {
+				return money.Money{}, giftcard.ErrInvalidCode
+			}
+			return money.Money{}, err
+		}
+		if card.Balance.Currency != o.Total.Currency {
+			return money.Money{}, giftcard.ErrCurrencyMismatch
+		}
+		if card.Balance.Amount == 0 {
+			return money.Money{}, giftcard.ErrEmpty
+		}
+		ids = append(ids, card.ID)
+	}
+
+	return s.giftCardRepo.Redeem(o.ID, ids, userID, o.Total)
+}
+
+// Release gives back what a cancelled order redeemed
+func (s *Service) Release(orderID string) error {
+	return s.giftCardRepo.Release(orderID)
+}
+
+// Voidable picks the gift cards to void when quantities of the order's gift
+// card lines, by line ID, are refunded. Only cards not spent from can be
+// taken back, so it fails with giftcard.ErrNotRefundable when a line has
+// fewer of them than the quantity.
+func (s *Service) Voidable(orderID string, quantities map[string]int) ([]*giftcard.GiftCard, error) {
+	if len(quantities) == 0 {
+		return nil, nil
+	}
+
+	cards, err := s.giftCardRepo.ListOrderGiftCards(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var voidable []*giftcard.GiftCard
+	picked := make(map[string]int, len(quantities))
+	for _, card := range cards {
+		if picked[card.OrderLineID] >= quantities[card.OrderLineID] {
+			continue
+		}
+		if card.VoidedAt != nil || card.Balance != card.Initial {
+			continue
+		}
+		voidable = append(voidable, card)
+		picked[card.OrderLineID]++
+	}
+
+	for lineID, quantity := range quantities {
+		if picked[lineID] < quantity {
+			return nil, giftcard.ErrNotRefundable
+		}
+	}
+	return voidable, nil
+}
+
+// Outstanding returns what the gift cards bought with the order are worth,
+// leaving out those voided; cards not issued yet count at their line value
+func (s *Service) Outstanding(o *order.Order) (money.Money, error) {
+	outstanding := money.Zero(o.Total.Currency)
+	if !o.HasGiftCards() {
+		return outstanding, nil
+	}
+
+	for _, line := range o.Lines {
+		if line.GiftCard {
+			outstanding.Amount += line.LineTotal().Amount
+		}
+	}
+
+	cards, err := s.giftCardRepo.ListOrderGiftCards(o.ID)
+	if err != nil {
+		return money.Money{}, err
+	}
+	for _, card := range cards {
+		if card.VoidedAt != nil {
+			outstanding.Amount -= card.Initial.Amount
+		}
+	}
+
+	return outstanding, nil
+}
+
+// Void takes back the gift cards of a refund, see Voidable
+func (s *Service) Void(orderID string, cards []*giftcard.GiftCard, refundID, actorID string) error {
+	if len(cards) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(cards))
+	for i, card := range cards {
+		ids[i] = card.ID
+	}
+	return s.giftCardRepo.Void(orderID, ids, refundID, actorID)
+}
+
+// Restore gives back the gift cards voided by a refund that failed
+func (s *Service) Restore(refundID string) error {
+	return s.giftCardRepo.Restore(refundID)
+}
+
+// Credit issues a refund as store credit to the customer
+func (s *Service) Credit(userID string, amount money.Money, refundID, actorID string) error {
+	if amount.Amount <= 0 {
+		return nil
+	}
+	if userID == "" {
+		return giftcard.ErrStoreCreditUnavailable
+	}
+
+	return s.giftCardRepo.Credit(userID, amount, refundID, actorID)
+}
@@ -0,0 +1,13 @@
+package inventoryapp
+
+import "github.com/RubenRodrigo/go-tiny-store/internal/domain/inventory"
+
+// AdjustmentInput represents a manager-recorded stock movement
+type AdjustmentInput struct {
+	ProductID string
+	VariantID string
+	Type      inventory.MovementType
+	Quantity  int
+	Reason    string
+	Reference string
+}
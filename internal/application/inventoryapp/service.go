@@ -0,0 +1,139 @@
+package inventoryapp
+
+import (
+	"log"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/inventory"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+)
+
+// Service handles stock ledger use cases
+type Service struct {
+	inventoryRepo    inventory.Repository
+	notifier         inventory.LowStockNotifier
+	defaultThreshold int
+}
+
+// NewService creates a new inventory application service
+func NewService(inventoryRepo inventory.Repository, notifier inventory.LowStockNotifier, defaultThreshold int) *Service {
+	return &Service{
+		inventoryRepo:    inventoryRepo,
+		notifier:         notifier,
+		defaultThreshold: defaultThreshold,
+	}
+}
+
+// RecordAdjustment records a receipt, return or adjustment entered by a manager
+func (s *Service) RecordAdjustment(actorID string, input AdjustmentInput) (*inventory.Movement, error) {
+	switch input.Type {
+	case inventory.MovementReceipt, inventory.MovementReturn:
+		if input.Quantity <= 0 {
+			return nil, apperrors.ErrInvalidQuantity
+		}
+	case inventory.MovementAdjustment:
+		if input.Quantity == 0 {
+			return nil, apperrors.ErrInvalidQuantity
+		}
+		if input.Reason == "" {
+			return nil, apperrors.ErrAdjustmentReasonRequired
+		}
+	default:
+		// Sales, reservations and their releases are recorded by checkout,
+		// never by hand
+		return nil, apperrors.ErrInvalidMovementType
+	}
+
+	m := &inventory.Movement{
+		ProductID: input.ProductID,
+		VariantID: input.VariantID,
+		Type:      input.Type,
+		Quantity:  input.Quantity,
+		Reason:    input.Reason,
+		ActorID:   actorID,
+		Reference: input.Reference,
+	}
+
+	if err := s.Record(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Record appends a movement to the ledger and raises a low-stock alert when
+// it brings stock down to the threshold
+func (s *Service) Record(m *inventory.Movement) error {
+	if err := s.inventoryRepo.RecordMovement(m); err != nil {
+		return err
+	}
+
+	s.CheckLowStock(m)
+	return nil
+}
+
+func (s *Service) ListMovements(params pagination.Params, filters inventory.Filters) (pagination.Result[*inventory.Movement], error) {
+	movements, count, err := s.inventoryRepo.ListMovements(params, filters)
+	if err != nil {
+		return pagination.Result[*inventory.Movement]{}, err
+	}
+
+	return pagination.BuildPagedResult(params, count, movements, movementCursor), nil
+}
+
+func (s *Service) Reconcile(productID, variantID string) (*inventory.ReconcileResult, error) {
+	return s.inventoryRepo.Reconcile(productID, variantID)
+}
+
+// SetThreshold overrides the low-stock threshold for a product or variant; nil restores the default
+func (s *Service) SetThreshold(productID, variantID string, threshold *int) error {
+	if threshold != nil && *threshold < 0 {
+		return apperrors.ErrInvalidQuantity
+	}
+	return s.inventoryRepo.SetLowStockThreshold(productID, variantID, threshold)
+}
+
+func (s *Service) ListLowStock() ([]*inventory.LowStockItem, error) {
+	return s.inventoryRepo.ListLowStock(s.defaultThreshold)
+}
+
+// CheckLowStock raises a low-stock alert when the movement brought stock down
+// to the threshold. Movements recorded elsewhere (e.g. checkout sales) are
+// passed here once committed.
+func (s *Service) CheckLowStock(m *inventory.Movement) {
+	threshold := s.threshold(m.ProductID, m.VariantID)
+	before := m.StockAfter - m.Quantity
+
+	if !inventory.CrossedThreshold(before, m.StockAfter, threshold) {
+		return
+	}
+
+	alert := inventory.LowStockAlert{
+		ProductID: m.ProductID,
+		VariantID: m.VariantID,
+		Stock:     m.StockAfter,
+		Threshold: threshold,
+	}
+
+	// Alerts are best effort and never fail the movement
+	if err := s.notifier.NotifyLowStock(alert); err != nil {
+		log.Printf("Warning: failed to send low stock alert for product %s: %v", m.ProductID, err)
+	}
+}
+
+// threshold resolves variant, then product, then store-wide threshold
+func (s *Service) threshold(productID, variantID string) int {
+	if variantID != "" {
+		if t, err := s.inventoryRepo.GetLowStockThreshold(productID, variantID); err == nil && t != nil {
+			return *t
+		}
+	}
+	if t, err := s.inventoryRepo.GetLowStockThreshold(productID, ""); err == nil && t != nil {
+		return *t
+	}
+	return s.defaultThreshold
+}
+
+func movementCursor(m *inventory.Movement) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: m.CreatedAt, ID: m.ID}
+}
@@ -0,0 +1,262 @@
+package invoiceapp
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/blob"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/invoice"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+const pdfContentType = "application/pdf"
+
+// Config holds the seller details printed on documents
+type Config struct {
+	SellerName    string
+	SellerAddress []string
+	SellerTaxID   string
+}
+
+// Service handles invoice and credit note use cases
+type Service struct {
+	invoiceRepo invoice.Repository
+	orderRepo   order.Repository
+	refundRepo  order.RefundRepository
+	renderer    invoice.Renderer
+	blobs       blob.Store
+	config      Config
+}
+
+// NewService creates a new invoice application service
+func NewService(
+	invoiceRepo invoice.Repository,
+	orderRepo order.Repository,
+	refundRepo order.RefundRepository,
+	renderer invoice.Renderer,
+	blobs blob.Store,
+	config Config,
+) *Service {
+	return &Service{
+		invoiceRepo: invoiceRepo,
+		orderRepo:   orderRepo,
+		refundRepo:  refundRepo,
+		renderer:    renderer,
+		blobs:       blobs,
+		config:      config,
+	}
+}
+
+// IssueInvoice numbers the invoice of a paid order and stores its PDF. It is
+// safe to call again; the order keeps its first invoice.
+func (s *Service) IssueInvoice(orderID string) (*invoice.Document, error) {
+	o, err := s.orderRepo.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refunded orders were paid for, so they are still invoiced
+	if !o.Status.Paid() && o.Status != order.StatusRefunded {
+		return nil, invoice.ErrNotInvoiceable
+	}
+
+	doc := &invoice.Document{
+		Type:     invoice.TypeInvoice,
+		OrderID:  o.ID,
+		Amount:   o.Total,
+		IssuedAt: time.Now(),
+	}
+	if _, err := s.invoiceRepo.Issue(doc); err != nil {
+		return nil, err
+	}
+
+	if doc.BlobKey == "" {
+		s.storeLogged(doc, o)
+	}
+	return doc, nil
+}
+
+// IssueCreditNote numbers the credit note of a succeeded refund and stores
+// its PDF, issuing the order's invoice first if it is missing
+func (s *Service) IssueCreditNote(refundID string) (*invoice.Document, error) {
+	refund, err := s.refundRepo.GetRefund(refundID)
+	if err != nil {
+		return nil, err
+	}
+	if refund.Status != order.RefundSucceeded {
+		return nil, invoice.ErrNotInvoiceable
+	}
+
+	if _, err := s.IssueInvoice(refund.OrderID); err != nil {
+		return nil, err
+	}
+
+	o, err := s.orderRepo.GetOrder(refund.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &invoice.Document{
+		Type:     invoice.TypeCreditNote,
+		OrderID:  refund.OrderID,
+		RefundID: refund.ID,
+		Amount:   refund.Amount,
+		IssuedAt: time.Now(),
+	}
+	if _, err := s.invoiceRepo.Issue(doc); err != nil {
+		return nil, err
+	}
+
+	if doc.BlobKey == "" {
+		s.storeLogged(doc, o)
+	}
+	return doc, nil
+}
+
+// ListDocuments returns the invoice and credit notes of an order
+func (s *Service) ListDocuments(orderID string) ([]*invoice.Document, error) {
+	if _, err := s.orderRepo.GetOrder(orderID); err != nil {
+		return nil, err
+	}
+	return s.invoiceRepo.ListDocuments(orderID)
+}
+
+// ListDocumentsForUser returns the documents of one of the user's orders
+func (s *Service) ListDocumentsForUser(orderID, userID string) ([]*invoice.Document, error) {
+	if err := s.checkOwner(orderID, userID); err != nil {
+		return nil, err
+	}
+	return s.invoiceRepo.ListDocuments(orderID)
+}
+
+// InvoicePDF returns the order's invoice and its PDF. Paid orders whose
+// invoice was not issued yet are invoiced now.
+func (s *Service) InvoicePDF(orderID string) (*invoice.Document, []byte, error) {
+	doc, err := s.invoiceRepo.GetInvoice(orderID)
+	if err == invoice.ErrNotFound {
+		doc, err = s.IssueInvoice(orderID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.pdf(doc)
+}
+
+// InvoicePDFForUser returns the invoice of one of the user's orders
+func (s *Service) InvoicePDFForUser(orderID, userID string) (*invoice.Document, []byte, error) {
+	if err := s.checkOwner(orderID, userID); err != nil {
+		return nil, nil, err
+	}
+	return s.InvoicePDF(orderID)
+}
+
+// CreditNotePDF returns a credit note of the order and its PDF
+func (s *Service) CreditNotePDF(orderID, id string) (*invoice.Document, []byte, error) {
+	doc, err := s.invoiceRepo.GetDocument(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if doc.OrderID != orderID || doc.Type != invoice.TypeCreditNote {
+		return nil, nil, invoice.ErrNotFound
+	}
+
+	return s.pdf(doc)
+}
+
+// CreditNotePDFForUser returns a credit note of one of the user's orders
+func (s *Service) CreditNotePDFForUser(orderID, id, userID string) (*invoice.Document, []byte, error) {
+	if err := s.checkOwner(orderID, userID); err != nil {
+		return nil, nil, err
+	}
+	return s.CreditNotePDF(orderID, id)
+}
+
+func (s *Service) checkOwner(orderID, userID string) error {
+	o, err := s.orderRepo.GetOrder(orderID)
+	if err != nil {
+		return err
+	}
+
+	// Don't reveal other customers' orders
+	if o.UserID != userID {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+// pdf reads the stored PDF of a document, rendering it again when it was
+// never stored or has gone missing
+func (s *Service) pdf(doc *invoice.Document) (*invoice.Document, []byte, error) {
+	if doc.BlobKey != "" {
+		data, err := s.blobs.Get(doc.BlobKey)
+		if err == nil {
+			return doc, data, nil
+		}
+		if err != blob.ErrNotFound {
+			return nil, nil, err
+		}
+	}
+
+	o, err := s.orderRepo.GetOrder(doc.OrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := s.store(doc, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	return doc, data, nil
+}
+
+// store renders the document, saves the PDF and records where it is
+func (s *Service) store(doc *invoice.Document, o *order.Order) ([]byte, error) {
+	content := invoice.Content{
+		Document: doc,
+		Seller: invoice.Party{
+			Name:    s.config.SellerName,
+			Address: s.config.SellerAddress,
+			TaxID:   s.config.SellerTaxID,
+		},
+		Order: o,
+	}
+	if doc.Type == invoice.TypeCreditNote {
+		refund, err := s.refundRepo.GetRefund(doc.RefundID)
+		if err != nil {
+			return nil, err
+		}
+		inv, err := s.invoiceRepo.GetInvoice(doc.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		content.Refund = refund
+		content.Invoice = inv
+	}
+
+	data, err := s.renderer.Render(content)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("documents/%d/%s.pdf", doc.IssuedAt.Year(), doc.Number)
+	if err := s.blobs.Put(key, pdfContentType, data); err != nil {
+		return nil, err
+	}
+	if err := s.invoiceRepo.SetBlobKey(doc.ID, key); err != nil {
+		return nil, err
+	}
+
+	doc.BlobKey = key
+	return data, nil
+}
+
+// storeLogged stores a freshly issued document. The number is already taken,
+// so a failure is only logged and the PDF is rendered on first download.
+func (s *Service) storeLogged(doc *invoice.Document, o *order.Order) {
+	if _, err := s.store(doc, o); err != nil {
+		log.Printf("ERROR: Failed to store document. Number: %s, Error: %v", doc.Number, err)
+	}
+}
@@ -0,0 +1,59 @@
+package orderapp
+
+import (
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+)
+
+// Service handles order-related use cases
+type Service struct {
+	orderRepo order.Repository
+}
+
+// NewService creates a new order application service
+func NewService(orderRepo order.Repository) *Service {
+	return &Service{
+		orderRepo: orderRepo,
+	}
+}
+
+func (s *Service) ListForUser(userID string, params pagination.Params) (pagination.Result[*order.Order], error) {
+	return s.list(params, order.Filters{UserID: userID})
+}
+
+func (s *Service) List(params pagination.Params, status order.Status) (pagination.Result[*order.Order], error) {
+	return s.list(params, order.Filters{Status: status})
+}
+
+func (s *Service) list(params pagination.Params, filters order.Filters) (pagination.Result[*order.Order], error) {
+	orders, count, err := s.orderRepo.ListOrders(params, filters)
+	if err != nil {
+		return pagination.Result[*order.Order]{}, err
+	}
+
+	return pagination.BuildPagedResult(params, count, orders, orderCursor), nil
+}
+
+func (s *Service) Get(id string) (*order.Order, error) {
+	return s.orderRepo.GetOrder(id)
+}
+
+// GetForUser returns the order only if it belongs to the user
+func (s *Service) GetForUser(id, userID string) (*order.Order, error) {
+	o, err := s.orderRepo.GetOrder(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't reveal other customers' orders
+	if o.UserID != userID {
+		return nil, apperrors.ErrNotFound
+	}
+
+	return o, nil
+}
+
+func orderCursor(o *order.Order) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: o.CreatedAt, ID: o.ID}
+}
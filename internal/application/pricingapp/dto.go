@@ -0,0 +1,22 @@
+package pricingapp
+
+// CreatePriceListInput represents the input for creating a price list
+type CreatePriceListInput struct {
+	Name          string
+	Currency      string
+	CustomerGroup string
+}
+
+// SetPriceInput represents the input for overriding a product price in a price list
+type SetPriceInput struct {
+	ProductID string
+	VariantID string
+	Amount    string // decimal amount in the price list currency
+}
+
+// SetRateInput represents the input for setting an exchange rate
+type SetRateInput struct {
+	Base  string
+	Quote string
+	Rate  string // units of Quote per unit of Base, e.g. "0.9215"
+}
@@ -0,0 +1,350 @@
+package pricingapp
+
+import (
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/pricing"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/user"
+)
+
+// Service handles price lists, exchange rates and price resolution
+type Service struct {
+	pricingRepo pricing.Repository
+	rateRepo    pricing.RateRepository
+	productRepo product.Repository
+	userRepo    user.Repository
+	currency    string
+}
+
+// NewService creates a new pricing application service. Product base prices
+// are in the store currency.
+func NewService(
+	pricingRepo pricing.Repository,
+	rateRepo pricing.RateRepository,
+	productRepo product.Repository,
+	userRepo user.Repository,
+	currency string,
+) *Service {
+	return &Service{
+		pricingRepo: pricingRepo,
+		rateRepo:    rateRepo,
+		productRepo: productRepo,
+		userRepo:    userRepo,
+		currency:    currency,
+	}
+}
+
+// Select picks the currency and customer group for a request. An explicitly
+// requested currency wins, then the user's preference, then the store currency.
+// userID may be empty for anonymous requests.
+func (s *Service) Select(userID, requested string) (pricing.Selection, error) {
+	sel := pricing.Selection{Currency: strings.ToUpper(requested)}
+
+	if userID != "" {
+		u, err := s.userRepo.GetUserByID(userID)
+		if err != nil {
+			return pricing.Selection{}, err
+		}
+		sel.CustomerGroup = u.CustomerGroup
+		if sel.Currency == "" {
+			sel.Currency = u.PreferredCurrency
+		}
+	}
+
+	if sel.Currency == "" {
+		sel.Currency = s.currency
+	}
+
+	if err := s.checkSupported(sel.Currency); err != nil {
+		return pricing.Selection{}, err
+	}
+
+	return sel, nil
+}
+
+// Resolve returns the price of a product (or variant) for the selection: a
+// price list entry when one applies, otherwise the effective base price
+// (including any running sale) converted at the current exchange rate
+func (s *Service) Resolve(p *product.Product, v *product.Variant, sel pricing.Selection) (money.Money, error) {
+	book, err := s.book(sel, []string{p.ID})
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	price, _, err := book.resolve(p, v, time.Now())
+	return price, err
+}
+
+// Convert expresses an amount in the currency at the current exchange rate
+func (s *Service) Convert(base money.Money, currency string) (money.Money, error) {
+	if base.Currency == currency {
+		return base, nil
+	}
+
+	rate, err := s.rate(base.Currency, currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return base.Convert(currency, rate), nil
+}
+
+// rate returns the exchange rate from base to quote
+func (s *Service) rate(base, quote string) (*big.Rat, error) {
+	rate, err := s.rateRepo.GetRate(base, quote)
+	if err != nil {
+		if err == pricing.ErrRateNotFound {
+			return nil, pricing.ErrUnsupportedCurrency
+		}
+		return nil, err
+	}
+
+	return rate.Rate, nil
+}
+
+// Apply replaces the product and variant prices with the prices resolved for
+// the selection, for display. CompareAtPrice is set while a sale applies.
+// Price lists and exchange rates are read once for all the products.
+func (s *Service) Apply(sel pricing.Selection, products ...*product.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+
+	book, err := s.book(sel, ids)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, p := range products {
+		for i := range p.Variants {
+			price, compareAt, err := book.resolve(p, &p.Variants[i], now)
+			if err != nil {
+				return err
+			}
+			p.Variants[i].Price = &price
+			p.Variants[i].CompareAtPrice = compareAt
+		}
+
+		price, compareAt, err := book.resolve(p, nil, now)
+		if err != nil {
+			return err
+		}
+		p.Price = price
+		p.CompareAtPrice = compareAt
+	}
+
+	return nil
+}
+
+// priceBook holds what resolving prices for one selection reads, so pricing
+// a page of products takes one price list query and one rate lookup
+type priceBook struct {
+	service  *Service
+	currency string
+	lists    []*pricing.PriceList
+	rates    map[string]*big.Rat // by base currency
+}
+
+// book loads the price lists of the products for the selection
+func (s *Service) book(sel pricing.Selection, productIDs []string) (*priceBook, error) {
+	lists, err := s.pricingRepo.FindPriceLists(productIDs, sel.Currency, sel.CustomerGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &priceBook{
+		service:  s,
+		currency: sel.Currency,
+		lists:    lists,
+		rates:    make(map[string]*big.Rat),
+	}, nil
+}
+
+// resolve returns the price for the selection and, while a sale lowers it,
+// the regular price it replaces
+func (b *priceBook) resolve(p *product.Product, v *product.Variant, now time.Time) (money.Money, *money.Money, error) {
+	variantID := ""
+	if v != nil {
+		variantID = v.ID
+	}
+	for _, pl := range b.lists {
+		if price := pl.FindPrice(p.ID, variantID); price != nil {
+			return price.Amount, nil, nil
+		}
+	}
+
+	effective := p.EffectiveUnitPrice(v, now)
+	price, err := b.convert(effective)
+	if err != nil {
+		return money.Money{}, nil, err
+	}
+
+	regular := p.UnitPrice(v)
+	if regular == effective {
+		return price, nil, nil
+	}
+
+	compareAt, err := b.convert(regular)
+	if err != nil {
+		return money.Money{}, nil, err
+	}
+	return price, &compareAt, nil
+}
+
+// convert is Convert to the selected currency, looking each rate up once
+func (b *priceBook) convert(base money.Money) (money.Money, error) {
+	if base.Currency == b.currency {
+		return base, nil
+	}
+
+	rate, ok := b.rates[base.Currency]
+	if !ok {
+		var err error
+		if rate, err = b.service.rate(base.Currency, b.currency); err != nil {
+			return money.Money{}, err
+		}
+		b.rates[base.Currency] = rate
+	}
+
+	return base.Convert(b.currency, rate), nil
+}
+
+func (s *Service) CreatePriceList(input CreatePriceListInput) (*pricing.PriceList, error) {
+	currency := strings.ToUpper(input.Currency)
+	if err := s.checkSupported(currency); err != nil {
+		return nil, err
+	}
+
+	pl := &pricing.PriceList{
+		Name:          input.Name,
+		Currency:      currency,
+		CustomerGroup: input.CustomerGroup,
+	}
+
+	if err := s.pricingRepo.CreatePriceList(pl); err != nil {
+		return nil, err
+	}
+
+	return pl, nil
+}
+
+func (s *Service) GetPriceList(id string) (*pricing.PriceList, error) {
+	return s.pricingRepo.GetPriceList(id)
+}
+
+func (s *Service) ListPriceLists() ([]*pricing.PriceList, error) {
+	return s.pricingRepo.ListPriceLists()
+}
+
+// SetPrice overrides the converted price of a product or variant in a price list
+func (s *Service) SetPrice(priceListID string, input SetPriceInput) (*pricing.Price, error) {
+	pl, err := s.pricingRepo.GetPriceList(priceListID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.productRepo.GetProduct(input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if input.VariantID != "" && p.FindVariant(input.VariantID) == nil {
+		return nil, product.ErrVariantNotFound
+	}
+
+	amount, err := money.Parse(input.Amount, pl.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if amount.IsNegative() {
+		return nil, money.ErrInvalidAmount
+	}
+
+	price := &pricing.Price{
+		PriceListID: pl.ID,
+		ProductID:   p.ID,
+		VariantID:   input.VariantID,
+		Amount:      amount,
+	}
+
+	if err := s.pricingRepo.SetPrice(price); err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
+
+func (s *Service) DeletePrice(priceListID, productID, variantID string) error {
+	return s.pricingRepo.DeletePrice(priceListID, productID, variantID)
+}
+
+func (s *Service) SetRate(input SetRateInput) (*pricing.ExchangeRate, error) {
+	base, quote := strings.ToUpper(input.Base), strings.ToUpper(input.Quote)
+	if len(base) != 3 || len(quote) != 3 || base == quote {
+		return nil, money.ErrInvalidCurrency
+	}
+
+	rate, ok := new(big.Rat).SetString(input.Rate)
+	if !ok || rate.Sign() <= 0 {
+		return nil, pricing.ErrInvalidExchangeRate
+	}
+
+	r := &pricing.ExchangeRate{
+		Base:  base,
+		Quote: quote,
+		Rate:  rate,
+	}
+
+	if err := s.rateRepo.SetRate(r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (s *Service) ListRates() ([]*pricing.ExchangeRate, error) {
+	return s.rateRepo.ListRates()
+}
+
+// SetPreferredCurrency stores the currency used when the user does not ask for one
+func (s *Service) SetPreferredCurrency(userID, currency string) error {
+	currency = strings.ToUpper(currency)
+	if err := s.checkSupported(currency); err != nil {
+		return err
+	}
+
+	return s.userRepo.UpdatePreferredCurrency(userID, currency)
+}
+
+// SetCustomerGroup assigns the user to a customer group; an empty group clears it
+func (s *Service) SetCustomerGroup(userID, customerGroup string) error {
+	return s.userRepo.UpdateCustomerGroup(userID, customerGroup)
+}
+
+// checkSupported accepts the store currency and currencies it can be converted to
+func (s *Service) checkSupported(currency string) error {
+	if currency == s.currency {
+		return nil
+	}
+	if len(currency) != 3 {
+		return pricing.ErrUnsupportedCurrency
+	}
+
+	if _, err := s.rateRepo.GetRate(s.currency, currency); err != nil {
+		if err == pricing.ErrRateNotFound {
+			return pricing.ErrUnsupportedCurrency
+		}
+		return err
+	}
+
+	return nil
+}
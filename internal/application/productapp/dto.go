@@ -1,9 +1,137 @@
 package productapp
 
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/importjob"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
+)
+
+// Config holds product service settings
+type Config struct {
+	Currency string // store currency prices are entered in
+	StoreURL string // storefront base URL for sitemap links
+
+	ImportBatchSize  int           // rows upserted per transaction
+	ImportInlineRows int           // imports up to this many rows run during the request
+	ImportMaxBytes   int64         // largest accepted import file
+	ImportStaleAfter time.Duration // running jobs without progress for this long are resumed
+}
+
 // ProductFilters represents filters for product queries
 type ProductFilters struct {
 	CategoryID string
-	MinPrice   *float64
-	MaxPrice   *float64
+	MinPrice   *string // decimal amount in the store currency
+	MaxPrice   *string
 	Disabled   *bool
+	MinRating  *float64
+	Brand      string
+	Attributes []AttributeFilter
+	Sort       string // "newest" (default), "most_liked" or "top_rated"
+}
+
+// AttributeFilter filters products by a specification value (attr.<key>=value)
+// or, for number attributes, a range (attr.<key>.min / attr.<key>.max)
+type AttributeFilter struct {
+	Key   string
+	Value *string
+	Min   *float64
+	Max   *float64
+}
+
+// ProductDetails represents the descriptive fields shared by product create and
+// update. Description is rich text and is sanitized before it is stored;
+// Specifications maps category attribute keys to values.
+type ProductDetails struct {
+	Description    string
+	Brand          string
+	TaxClass       string // empty uses product.DefaultTaxClass
+	WeightGrams    int
+	LengthMM       int
+	WidthMM        int
+	HeightMM       int
+	GiftCard       bool // sold as gift cards worth the unit price
+	Specifications map[string]string
+}
+
+// SaleInput represents a sale price with optional start and end times
+type SaleInput struct {
+	Price    string // decimal amount in the store currency
+	StartsAt *time.Time
+	EndsAt   *time.Time
+}
+
+// CreateProductInput represents the input for creating a product. An empty
+// Slug is generated from Name.
+type CreateProductInput struct {
+	SKU        string // optional
+	Name       string
+	Price      string // decimal amount in the store currency
+	Sale       *SaleInput
+	Stock      int
+	CategoryID string
+	Slug       string
+	SEO        seo.Metadata
+	Details    ProductDetails
+}
+
+// UpdateProductInput represents the input for updating a product. An empty
+// Slug keeps the current slug, or regenerates it when Name changes.
+type UpdateProductInput struct {
+	SKU      string // empty keeps the current SKU
+	Name     string
+	Price    string
+	Sale     *SaleInput // nil ends any sale
+	Disabled bool
+	Slug     string
+	SEO      seo.Metadata
+	Details  ProductDetails
+}
+
+// SitemapURL is one <url> entry of the XML sitemap
+type SitemapURL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// ImportInput represents an uploaded product import file. Rows are upserted by
+// SKU; a dry run only validates them.
+type ImportInput struct {
+	Format  importjob.Format
+	DryRun  bool
+	Payload []byte
+	UserID  string
+}
+
+// SchedulePriceInput represents a future change of a product's regular price
+type SchedulePriceInput struct {
+	Price       string // decimal amount in the store currency
+	EffectiveAt time.Time
+}
+
+// CreateOptionTypeInput represents the input for adding an option type to a product
+type CreateOptionTypeInput struct {
+	Name   string
+	Values []string
+}
+
+// CreateVariantInput represents the input for creating a product variant
+type CreateVariantInput struct {
+	SKU            string
+	Price          *string // decimal amount in the store currency; nil inherits the product price
+	Stock          int
+	OptionValueIDs []string
+}
+
+// UpdateVariantInput represents the input for updating a product variant
+type UpdateVariantInput struct {
+	SKU      string
+	Price    *string
+	Disabled bool
+}
+
+// AddImageInput represents the input for attaching an image
+type AddImageInput struct {
+	URL     string
+	AltText string
 }
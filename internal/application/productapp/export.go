@@ -0,0 +1,188 @@
+package productapp
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/importjob"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+)
+
+// exportBatchSize is the number of products read per query while exporting
+const exportBatchSize = 500
+
+// Export writes the filtered products to w in the import format, so an export
+// can be edited and imported again. Prices are in the store currency. Errors
+// returned before anything is written leave w untouched.
+func (s *Service) Export(filters ProductFilters, format importjob.Format, w io.Writer) error {
+	if !importjob.ValidFormat(format) {
+		return importjob.ErrInvalidFormat
+	}
+
+	domainFilters, err := s.toDomainFilters(filters)
+	if err != nil {
+		return err
+	}
+	domainFilters.Sort = product.SortNewest
+
+	categories, err := s.categoryRepo.ListCategories()
+	if err != nil {
+		return err
+	}
+	categorySlugs := make(map[string]string, len(categories))
+	categoryIDs := make([]string, len(categories))
+	for i, c := range categories {
+		categorySlugs[c.ID] = c.Slug
+		categoryIDs[i] = c.ID
+	}
+
+	if format == importjob.FormatNDJSON {
+		encoder := json.NewEncoder(w)
+		return s.productRepo.StreamProducts(domainFilters, exportBatchSize, func(products []*product.Product) error {
+			for _, p := range products {
+				if err := encoder.Encode(exportRecord(p, categorySlugs)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	// CSV needs every specification column up front
+	attributes, err := s.categoryRepo.ListAttributes(categoryIDs)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	var specKeys []string
+	for _, a := range attributes {
+		if !seen[a.Key] {
+			seen[a.Key] = true
+			specKeys = append(specKeys, a.Key)
+		}
+	}
+	sort.Strings(specKeys)
+
+	writer := csv.NewWriter(w)
+	header := append([]string{}, productColumns...)
+	for _, key := range specKeys {
+		header = append(header, specColumnPrefix+key)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	err = s.productRepo.StreamProducts(domainFilters, exportBatchSize, func(products []*product.Product) error {
+		for _, p := range products {
+			fields := exportFields(p, categorySlugs)
+			specs := make(map[string]string, len(p.Specifications))
+			for _, spec := range p.Specifications {
+				specs[spec.Key] = spec.Value
+			}
+
+			record := make([]string, 0, len(header))
+			for _, column := range productColumns {
+				record = append(record, fields[column])
+			}
+			for _, key := range specKeys {
+				record = append(record, specs[key])
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// exportFields returns the text value of each product column
+func exportFields(p *product.Product, categorySlugs map[string]string) map[string]string {
+	salePrice, saleStartsAt, saleEndsAt := exportSale(p.Sale)
+
+	return map[string]string{
+		"sku":              p.SKU,
+		"name":             p.Name,
+		"price":            p.Price.String(),
+		"sale_price":       salePrice,
+		"sale_starts_at":   saleStartsAt,
+		"sale_ends_at":     saleEndsAt,
+		"category":         categorySlugs[p.CategoryID],
+		"stock":            strconv.Itoa(p.Stock),
+		"disabled":         strconv.FormatBool(p.Disabled),
+		"slug":             p.Slug,
+		"brand":            p.Brand,
+		"tax_class":        p.TaxClass,
+		"description":      p.Description,
+		"weight_grams":     strconv.Itoa(p.WeightGrams),
+		"length_mm":        strconv.Itoa(p.Dimensions.LengthMM),
+		"width_mm":         strconv.Itoa(p.Dimensions.WidthMM),
+		"height_mm":        strconv.Itoa(p.Dimensions.HeightMM),
+		"gift_card":        strconv.FormatBool(p.GiftCard),
+		"meta_title":       p.SEO.MetaTitle,
+		"meta_description": p.SEO.MetaDescription,
+		"canonical_url":    p.SEO.CanonicalURL,
+	}
+}
+
+// exportRecord returns the NDJSON object for a product, keeping numbers and
+// booleans typed
+func exportRecord(p *product.Product, categorySlugs map[string]string) map[string]interface{} {
+	specs := make(map[string]string, len(p.Specifications))
+	for _, spec := range p.Specifications {
+		specs[spec.Key] = spec.Value
+	}
+
+	salePrice, saleStartsAt, saleEndsAt := exportSale(p.Sale)
+
+	return map[string]interface{}{
+		"sku":              p.SKU,
+		"name":             p.Name,
+		"price":            p.Price.String(),
+		"sale_price":       salePrice,
+		"sale_starts_at":   saleStartsAt,
+		"sale_ends_at":     saleEndsAt,
+		"category":         categorySlugs[p.CategoryID],
+		"stock":            p.Stock,
+		"disabled":         p.Disabled,
+		"slug":             p.Slug,
+		"brand":            p.Brand,
+		"tax_class":        p.TaxClass,
+		"description":      p.Description,
+		"weight_grams":     p.WeightGrams,
+		"length_mm":        p.Dimensions.LengthMM,
+		"width_mm":         p.Dimensions.WidthMM,
+		"height_mm":        p.Dimensions.HeightMM,
+		"gift_card":        p.GiftCard,
+		"meta_title":       p.SEO.MetaTitle,
+		"meta_description": p.SEO.MetaDescription,
+		"canonical_url":    p.SEO.CanonicalURL,
+		specificationsKey:  specs,
+	}
+}
+
+// exportSale returns the sale columns as text, blank when there is no sale
+func exportSale(sale *product.Sale) (price, startsAt, endsAt string) {
+	if sale == nil {
+		return "", "", ""
+	}
+
+	price = sale.Price.String()
+	if sale.StartsAt != nil {
+		startsAt = sale.StartsAt.UTC().Format(time.RFC3339)
+	}
+	if sale.EndsAt != nil {
+		endsAt = sale.EndsAt.UTC().Format(time.RFC3339)
+	}
+	return price, startsAt, endsAt
+}
@@ -0,0 +1,621 @@
+package productapp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/category"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/importjob"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+// productColumns are the CSV columns, and NDJSON keys, shared by import and
+// export. Category is a category slug; stock only applies to new products.
+var productColumns = []string{
+	"sku", "name", "price", "sale_price", "sale_starts_at", "sale_ends_at",
+	"category", "stock", "disabled", "slug",
+	"brand", "tax_class", "description", "weight_grams", "length_mm", "width_mm", "height_mm",
+	"gift_card", "meta_title", "meta_description", "canonical_url",
+}
+
+// specColumnPrefix marks CSV columns holding specification values
+const specColumnPrefix = "spec."
+
+// specificationsKey is the NDJSON object holding specification values
+const specificationsKey = "specifications"
+
+// maxNDJSONLine bounds a single NDJSON record
+const maxNDJSONLine = 1 << 20
+
+// importRow is one data row of an import file. Err is set when the row itself
+// could not be decoded.
+type importRow struct {
+	Number int
+	Fields map[string]string
+	Specs  map[string]string
+	Err    string
+}
+
+// importItem is a validated row ready to be written
+type importItem struct {
+	row     importRow
+	product *product.Product
+	stock   int
+}
+
+// importState carries lookups and duplicate detection across the batches of
+// one run
+type importState struct {
+	categories map[string]*category.Category // by slug; nil when missing
+	schemas    map[string][]*category.Attribute
+	skus       map[string]int // row that first used each SKU
+	slugs      map[string]bool
+}
+
+func newImportState() *importState {
+	return &importState{
+		categories: make(map[string]*category.Category),
+		schemas:    make(map[string][]*category.Attribute),
+		skus:       make(map[string]int),
+		slugs:      make(map[string]bool),
+	}
+}
+
+// Import validates an uploaded file and upserts its rows by SKU. Files with up
+// to ImportInlineRows rows are imported during the call; larger files are
+// queued and the returned job is pending until the worker picks it up.
+func (s *Service) Import(input ImportInput) (*importjob.Job, error) {
+	if !importjob.ValidFormat(input.Format) {
+		return nil, importjob.ErrInvalidFormat
+	}
+
+	if int64(len(input.Payload)) > s.config.ImportMaxBytes {
+		return nil, apperrors.ErrImportTooLarge
+	}
+
+	rows, err := parseImport(input.Format, input.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &importjob.Job{
+		Format:    input.Format,
+		DryRun:    input.DryRun,
+		Status:    importjob.StatusPending,
+		TotalRows: len(rows),
+		CreatedBy: input.UserID,
+	}
+
+	if len(rows) > s.config.ImportInlineRows {
+		if err := s.importRepo.CreateJob(job, input.Payload); err != nil {
+			return nil, err
+		}
+		return job, nil
+	}
+
+	now := time.Now()
+	job.Status = importjob.StatusRunning
+	job.StartedAt = &now
+	if err := s.importRepo.CreateJob(job, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.runImport(job, rows); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ImportMaxBytes is the largest import file accepted
+func (s *Service) ImportMaxBytes() int64 {
+	return s.config.ImportMaxBytes
+}
+
+// GetImportJob returns an import job with its progress and row errors
+func (s *Service) GetImportJob(id string) (*importjob.Job, error) {
+	return s.importRepo.GetJob(id)
+}
+
+// ProcessImports runs queued import jobs until none are left. Jobs whose
+// worker stopped mid-way are resumed after the last saved batch.
+func (s *Service) ProcessImports() error {
+	for {
+		job, payload, err := s.importRepo.ClaimNext(time.Now().Add(-s.config.ImportStaleAfter))
+		if err == importjob.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		rows, err := parseImport(job.Format, payload)
+		if err != nil {
+			now := time.Now()
+			job.Status = importjob.StatusFailed
+			job.Message = err.Error()
+			job.FinishedAt = &now
+			if err := s.importRepo.SaveProgress(job); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.runImport(job, rows); err != nil {
+			return err
+		}
+	}
+}
+
+// runImport processes the rows after job.ProcessedRows in batches, saving
+// progress after each one, and completes the job
+func (s *Service) runImport(job *importjob.Job, rows []importRow) error {
+	state := newImportState()
+
+	batchSize := s.config.ImportBatchSize
+	if batchSize <= 0 {
+		batchSize = len(rows)
+	}
+	for start := job.ProcessedRows; start < len(rows); start += batchSize {
+		end := min(start+batchSize, len(rows))
+		s.importBatch(job, rows[start:end], state)
+
+		job.ProcessedRows = end
+		if end < len(rows) {
+			if err := s.importRepo.SaveProgress(job); err != nil {
+				return err
+			}
+		}
+	}
+
+	now := time.Now()
+	job.Status = importjob.StatusCompleted
+	job.FinishedAt = &now
+	return s.importRepo.SaveProgress(job)
+}
+
+// importBatch validates a batch of rows and writes the valid ones in a single
+// transaction. Rows that fail are recorded on the job; a failed write fails
+// every row of the batch.
+func (s *Service) importBatch(job *importjob.Job, rows []importRow, state *importState) {
+	var items []*importItem
+	for _, row := range rows {
+		item, err := s.productFromRow(row, state)
+		if err != nil {
+			job.AddError(rowError(row, err))
+			continue
+		}
+
+		sku := item.product.SKU
+		if first, ok := state.skus[sku]; ok {
+			job.AddError(rowError(row, fmt.Errorf("SKU already used in row %d", first)))
+			continue
+		}
+		state.skus[sku] = row.Number
+		items = append(items, item)
+	}
+
+	skus := make([]string, len(items))
+	for i, item := range items {
+		skus[i] = item.product.SKU
+	}
+	existing, err := s.productRepo.ListProductsBySKU(skus)
+	if err != nil {
+		for _, item := range items {
+			job.AddError(rowError(item.row, err))
+		}
+		return
+	}
+	bySKU := make(map[string]*product.Product, len(existing))
+	for _, p := range existing {
+		bySKU[p.SKU] = p
+	}
+
+	var creates, updates []*importItem
+	for _, item := range items {
+		p := item.product
+		requested := strings.TrimSpace(item.row.Fields["slug"])
+
+		current, found := bySKU[p.SKU]
+		if found {
+			p.ID = current.ID
+		}
+
+		slug := ""
+		if found && requested == "" && p.Name == current.Name {
+			slug = current.Slug
+		} else {
+			slug, err = s.resolveSlug(requested, p.Name, p.ID, state.slugs)
+			if err != nil {
+				job.AddError(rowError(item.row, err))
+				continue
+			}
+		}
+		p.Slug = slug
+		state.slugs[slug] = true
+
+		if found {
+			updates = append(updates, item)
+		} else {
+			creates = append(creates, item)
+		}
+	}
+
+	if job.DryRun {
+		job.Created += len(creates)
+		job.Updated += len(updates)
+		return
+	}
+
+	if err := s.productRepo.ImportProducts(importProducts(creates), importProducts(updates)); err != nil {
+		for _, item := range append(creates, updates...) {
+			job.AddError(rowError(item.row, err))
+		}
+		return
+	}
+	job.Created += len(creates)
+	job.Updated += len(updates)
+
+	for _, item := range creates {
+		if err := s.receiveInitialStock(item.product.ID, "", item.stock); err != nil {
+			log.Printf("ERROR: Failed to record opening stock for imported product. ID: %s, Error: %v", item.product.ID, err)
+			job.AddError(rowError(item.row, errors.New("product created but its opening stock was not recorded")))
+		}
+	}
+}
+
+func importProducts(items []*importItem) []*product.Product {
+	products := make([]*product.Product, len(items))
+	for i, item := range items {
+		products[i] = item.product
+	}
+	return products
+}
+
+// productFromRow validates a row and builds the product it describes. The
+// slug is resolved later, once the row is known to be a create or an update.
+func (s *Service) productFromRow(row importRow, state *importState) (*importItem, error) {
+	if row.Err != "" {
+		return nil, errors.New(row.Err)
+	}
+	f := row.Fields
+
+	sku := strings.TrimSpace(f["sku"])
+	if sku == "" {
+		return nil, errors.New("sku is required")
+	}
+	if len(sku) > 64 {
+		return nil, errors.New("sku must be at most 64 characters")
+	}
+
+	name := strings.TrimSpace(f["name"])
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	if len(name) > 255 {
+		return nil, errors.New("name must be at most 255 characters")
+	}
+
+	price, err := s.parsePrice(strings.TrimSpace(f["price"]))
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.importCategory(strings.TrimSpace(f["category"]), state)
+	if err != nil {
+		return nil, err
+	}
+
+	ints := make(map[string]int)
+	for _, column := range []string{"stock", "weight_grams", "length_mm", "width_mm", "height_mm"} {
+		raw := strings.TrimSpace(f[column])
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%s must be a whole number of zero or more", column)
+		}
+		ints[column] = n
+	}
+
+	disabled := false
+	if raw := strings.TrimSpace(f["disabled"]); raw != "" {
+		disabled, err = strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.New("disabled must be true or false")
+		}
+	}
+
+	giftCard := false
+	if raw := strings.TrimSpace(f["gift_card"]); raw != "" {
+		giftCard, err = strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.New("gift_card must be true or false")
+		}
+	}
+
+	metadata := seo.Metadata{
+		MetaTitle:       strings.TrimSpace(f["meta_title"]),
+		MetaDescription: strings.TrimSpace(f["meta_description"]),
+		CanonicalURL:    strings.TrimSpace(f["canonical_url"]),
+	}
+	if err := metadata.Validate(); err != nil {
+		return nil, err
+	}
+
+	schema, ok := state.schemas[c.ID]
+	if !ok {
+		schema, err = s.attributeSchema(c.ID)
+		if err != nil {
+			return nil, err
+		}
+		state.schemas[c.ID] = schema
+	}
+
+	p := &product.Product{
+		SKU:        sku,
+		Name:       name,
+		SEO:        metadata,
+		Price:      price,
+		Disabled:   disabled,
+		CategoryID: c.ID,
+	}
+	sale, err := saleFromRow(f)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applySale(p, sale); err != nil {
+		return nil, err
+	}
+	details := ProductDetails{
+		Description:    f["description"],
+		Brand:          f["brand"],
+		TaxClass:       f["tax_class"],
+		WeightGrams:    ints["weight_grams"],
+		LengthMM:       ints["length_mm"],
+		WidthMM:        ints["width_mm"],
+		HeightMM:       ints["height_mm"],
+		GiftCard:       giftCard,
+		Specifications: row.Specs,
+	}
+	if err := applyDetails(p, details, schema); err != nil {
+		return nil, err
+	}
+
+	return &importItem{row: row, product: p, stock: ints["stock"]}, nil
+}
+
+// saleFromRow reads the sale columns; a blank sale_price means no sale.
+// Times are RFC 3339.
+func saleFromRow(f map[string]string) (*SaleInput, error) {
+	price := strings.TrimSpace(f["sale_price"])
+	if price == "" {
+		return nil, nil
+	}
+
+	sale := &SaleInput{Price: price}
+	for column, target := range map[string]**time.Time{"sale_starts_at": &sale.StartsAt, "sale_ends_at": &sale.EndsAt} {
+		raw := strings.TrimSpace(f[column])
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an RFC 3339 time", column)
+		}
+		*target = &t
+	}
+	return sale, nil
+}
+
+// importCategory looks up a category by slug, reading each slug once per run
+func (s *Service) importCategory(slug string, state *importState) (*category.Category, error) {
+	if slug == "" {
+		return nil, errors.New("category is required")
+	}
+
+	c, ok := state.categories[slug]
+	if !ok {
+		var err error
+		c, err = s.categoryRepo.GetCategoryBySlug(slug)
+		if err != nil && err != category.ErrNotFound {
+			return nil, err
+		}
+		state.categories[slug] = c
+	}
+	if c == nil {
+		return nil, fmt.Errorf("category %q does not exist", slug)
+	}
+	return c, nil
+}
+
+// rowError reports err against a row, using the message of application errors
+func rowError(row importRow, err error) importjob.RowError {
+	message := err.Error()
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		message = appErr.Message
+	}
+
+	return importjob.RowError{
+		Row:     row.Number,
+		SKU:     strings.TrimSpace(row.Fields["sku"]),
+		Message: message,
+	}
+}
+
+// parseImport decodes an import file into rows. Rows that cannot be decoded
+// are kept with an error so they are reported like validation failures; only
+// an unreadable or empty file fails as a whole.
+func parseImport(format importjob.Format, payload []byte) ([]importRow, error) {
+	var rows []importRow
+	var err error
+	switch format {
+	case importjob.FormatCSV:
+		rows, err = parseCSV(payload)
+	case importjob.FormatNDJSON:
+		rows, err = parseNDJSON(payload)
+	default:
+		return nil, importjob.ErrInvalidFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, importjob.ErrInvalidFile
+	}
+	return rows, nil
+}
+
+func parseCSV(payload []byte) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(payload, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, importjob.ErrInvalidFile
+	}
+
+	known := make(map[string]bool, len(productColumns))
+	for _, column := range productColumns {
+		known[column] = true
+	}
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !known[column] && !strings.HasPrefix(column, specColumnPrefix) {
+			return nil, importjob.ErrInvalidFile
+		}
+		header[i] = column
+	}
+
+	var rows []importRow
+	for number := 1; ; number++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// A malformed quote leaves the reader unable to find the next row
+			return nil, importjob.ErrInvalidFile
+		}
+
+		row := importRow{Number: number, Fields: make(map[string]string), Specs: make(map[string]string)}
+		if len(record) > len(header) {
+			row.Err = fmt.Sprintf("row has %d fields but the header has %d", len(record), len(header))
+		}
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+			if key, ok := strings.CutPrefix(header[i], specColumnPrefix); ok {
+				// Exports have a column for every attribute, so blanks are skipped
+				if strings.TrimSpace(value) != "" {
+					row.Specs[key] = value
+				}
+				continue
+			}
+			row.Fields[header[i]] = value
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseNDJSON(payload []byte) ([]importRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(payload))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+
+	var rows []importRow
+	for number := 0; scanner.Scan(); {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		number++
+
+		row := importRow{Number: number, Fields: make(map[string]string), Specs: make(map[string]string)}
+		if err := decodeNDJSONRow(line, &row); err != nil {
+			row.Err = err.Error()
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, importjob.ErrInvalidFile
+	}
+
+	return rows, nil
+}
+
+func decodeNDJSONRow(line []byte, row *importRow) error {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+
+	var record map[string]interface{}
+	if err := decoder.Decode(&record); err != nil {
+		return errors.New("line is not a JSON object")
+	}
+
+	known := make(map[string]bool, len(productColumns))
+	for _, column := range productColumns {
+		known[column] = true
+	}
+
+	for key, value := range record {
+		if key == specificationsKey {
+			specs, ok := value.(map[string]interface{})
+			if !ok && value != nil {
+				return errors.New("specifications must be an object")
+			}
+			for specKey, specValue := range specs {
+				text, ok := scalarString(specValue)
+				if !ok {
+					return fmt.Errorf("specification %q must be a string, number or boolean", specKey)
+				}
+				if strings.TrimSpace(text) != "" {
+					row.Specs[specKey] = text
+				}
+			}
+			continue
+		}
+
+		if !known[key] {
+			return fmt.Errorf("unknown field %q", key)
+		}
+		text, ok := scalarString(value)
+		if !ok {
+			return fmt.Errorf("%s must be a string, number or boolean", key)
+		}
+		row.Fields[key] = text
+	}
+
+	return nil
+}
+
+// scalarString renders a decoded JSON scalar as import text; null is empty
+func scalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", true
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
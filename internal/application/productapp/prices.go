@@ -0,0 +1,113 @@
+package productapp
+
+import (
+	"log"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+)
+
+// scheduleBatchSize is the number of due price schedules applied per run
+const scheduleBatchSize = 100
+
+// applySale parses the sale of a create or update input onto p; a nil input
+// leaves the product without a sale
+func (s *Service) applySale(p *product.Product, input *SaleInput) error {
+	if input == nil {
+		p.Sale = nil
+		return nil
+	}
+
+	price, err := s.parsePrice(input.Price)
+	if err != nil {
+		return err
+	}
+
+	p.Sale = &product.Sale{
+		Price:    price,
+		StartsAt: input.StartsAt,
+		EndsAt:   input.EndsAt,
+	}
+	return p.ValidateSale()
+}
+
+// SchedulePrice queues a change of the product's regular price at a future time
+func (s *Service) SchedulePrice(productID string, input SchedulePriceInput) (*product.PriceSchedule, error) {
+	if !input.EffectiveAt.After(time.Now()) {
+		return nil, product.ErrInvalidPriceSchedule
+	}
+
+	price, err := s.parsePrice(input.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.productRepo.GetProduct(productID); err != nil {
+		return nil, err
+	}
+
+	schedule := &product.PriceSchedule{
+		ProductID:   productID,
+		Price:       price,
+		EffectiveAt: input.EffectiveAt,
+		Status:      product.SchedulePending,
+	}
+	if err := s.priceRepo.CreateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// ListPriceSchedules returns the product's scheduled price changes, latest first
+func (s *Service) ListPriceSchedules(productID string) ([]*product.PriceSchedule, error) {
+	if _, err := s.productRepo.GetProduct(productID); err != nil {
+		return nil, err
+	}
+
+	return s.priceRepo.ListSchedules(productID)
+}
+
+// CancelPriceSchedule cancels a scheduled price change that has not been applied yet
+func (s *Service) CancelPriceSchedule(productID, scheduleID string) error {
+	return s.priceRepo.CancelSchedule(productID, scheduleID)
+}
+
+// PriceHistory returns the product's price changes, most recent first. Amounts
+// are in the store currency.
+func (s *Service) PriceHistory(productID string, params pagination.Params) (pagination.Result[*product.PriceChange], error) {
+	if _, err := s.productRepo.GetProduct(productID); err != nil {
+		return pagination.Result[*product.PriceChange]{}, err
+	}
+
+	changes, count, err := s.priceRepo.ListPriceHistory(productID, params)
+	if err != nil {
+		return pagination.Result[*product.PriceChange]{}, err
+	}
+
+	return pagination.BuildPagedResult(params, count, changes, priceChangeCursor), nil
+}
+
+func priceChangeCursor(c *product.PriceChange) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: c.CreatedAt, ID: c.ID}
+}
+
+// ApplyScheduledPrices applies price schedules that have come due; it runs as a
+// background job and leaves any beyond the batch size for the next run.
+// Schedules applied concurrently elsewhere are skipped.
+func (s *Service) ApplyScheduledPrices() error {
+	now := time.Now()
+	due, err := s.priceRepo.ListDueSchedules(now, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range due {
+		if err := s.priceRepo.ApplySchedule(schedule.ID, now); err != nil && err != product.ErrNotFound {
+			log.Printf("Warning: failed to apply price schedule %s for product %s: %v", schedule.ID, schedule.ProductID, err)
+		}
+	}
+
+	return nil
+}
@@ -1,73 +1,660 @@
 package productapp
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/pricingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/category"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/importjob"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/inventory"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
 	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
 	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/sanitize"
 )
 
 // Service handles product-related use cases
 type Service struct {
-	productRepo product.Repository
+	productRepo   product.Repository
+	variantRepo   product.VariantRepository
+	likeRepo      product.LikeRepository
+	inventoryRepo inventory.Repository
+	categoryRepo  category.Repository
+	priceRepo     product.PriceRepository
+	importRepo    importjob.Repository
+	pricing       *pricingapp.Service
+	currency      string
+	storeURL      string
+	config        Config
 }
 
-// NewService creates a new product application service
-func NewService(productRepo product.Repository) *Service {
+// NewService creates a new product application service. Prices are entered
+// in the store currency.
+func NewService(
+	productRepo product.Repository,
+	variantRepo product.VariantRepository,
+	likeRepo product.LikeRepository,
+	inventoryRepo inventory.Repository,
+	categoryRepo category.Repository,
+	priceRepo product.PriceRepository,
+	importRepo importjob.Repository,
+	pricing *pricingapp.Service,
+	config Config,
+) *Service {
 	return &Service{
-		productRepo: productRepo,
+		productRepo:   productRepo,
+		variantRepo:   variantRepo,
+		likeRepo:      likeRepo,
+		inventoryRepo: inventoryRepo,
+		categoryRepo:  categoryRepo,
+		priceRepo:     priceRepo,
+		importRepo:    importRepo,
+		pricing:       pricing,
+		currency:      config.Currency,
+		storeURL:      strings.TrimSuffix(config.StoreURL, "/"),
+		config:        config,
 	}
 }
 
-func (s *Service) List(params pagination.Params, filters ProductFilters) (pagination.Result[*product.Product], error) {
-	// Convert application filters to domain filters
-	domainFilters := product.Filters{
-		CategoryID: filters.CategoryID,
-		MinPrice:   filters.MinPrice,
-		MaxPrice:   filters.MaxPrice,
-		Disabled:   filters.Disabled,
+// List returns products priced in the requested currency. Price filters are
+// always in the store currency.
+func (s *Service) List(params pagination.Params, filters ProductFilters, currency string) (pagination.Result[*product.Product], error) {
+	sel, err := s.pricing.Select("", currency)
+	if err != nil {
+		return pagination.Result[*product.Product]{}, err
+	}
+
+	domainFilters, err := s.toDomainFilters(filters)
+	if err != nil {
+		return pagination.Result[*product.Product]{}, err
+	}
+
+	sort := product.SortNewest
+	switch product.Sort(filters.Sort) {
+	case product.SortMostLiked, product.SortTopRated:
+		// Cursors only encode (created_at, id), so popularity orders need page numbers
+		if params.IsCursor() {
+			return pagination.Result[*product.Product]{}, product.ErrUnsupportedSort
+		}
+		sort = product.Sort(filters.Sort)
 	}
+	domainFilters.Sort = sort
 
 	products, count, err := s.productRepo.ListProducts(params, domainFilters)
 	if err != nil {
 		return pagination.Result[*product.Product]{}, err
 	}
 
-	return pagination.BuildResult(params, count, products), nil
+	if err := s.pricing.Apply(sel, products...); err != nil {
+		return pagination.Result[*product.Product]{}, err
+	}
+
+	if err := s.attachBreadcrumbs(products...); err != nil {
+		return pagination.Result[*product.Product]{}, err
+	}
+
+	return pagination.BuildPagedResult(params, count, products, productCursor), nil
 }
 
-func (s *Service) Get(id string) (*product.Product, error) {
-	return s.productRepo.GetProduct(id)
+// toDomainFilters converts application filters to domain filters, parsing
+// price bounds in the store currency. Sort is left to the caller.
+func (s *Service) toDomainFilters(filters ProductFilters) (product.Filters, error) {
+	minPrice, err := s.parseOptionalPrice(filters.MinPrice)
+	if err != nil {
+		return product.Filters{}, err
+	}
+	maxPrice, err := s.parseOptionalPrice(filters.MaxPrice)
+	if err != nil {
+		return product.Filters{}, err
+	}
+
+	attributeFilters := make([]product.AttributeFilter, len(filters.Attributes))
+	for i, f := range filters.Attributes {
+		attributeFilters[i] = product.AttributeFilter{Key: f.Key, Value: f.Value, Min: f.Min, Max: f.Max}
+	}
+
+	return product.Filters{
+		CategoryID: filters.CategoryID,
+		MinPrice:   minPrice,
+		MaxPrice:   maxPrice,
+		Disabled:   filters.Disabled,
+		MinRating:  filters.MinRating,
+		Brand:      filters.Brand,
+		Attributes: attributeFilters,
+	}, nil
+}
+
+func productCursor(p *product.Product) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: p.CreatedAt, ID: p.ID}
+}
+
+// Get returns a product priced in the requested currency
+func (s *Service) Get(id, currency string) (*product.Product, error) {
+	sel, err := s.pricing.Select("", currency)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.productRepo.GetProduct(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.pricing.Apply(sel, p); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachBreadcrumbs(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
 }
 
-func (s *Service) Create(name string, price float64, stock int, categoryID string) (*product.Product, error) {
+// GetBySlug returns a product by its current or a historical slug. current is
+// false when the slug is historical and clients should be sent to p.Slug.
+func (s *Service) GetBySlug(slug, currency string) (p *product.Product, current bool, err error) {
+	sel, err := s.pricing.Select("", currency)
+	if err != nil {
+		return nil, false, err
+	}
+
+	current = true
+	p, err = s.productRepo.GetProductBySlug(slug)
+	if err == product.ErrNotFound {
+		current = false
+		id, lookupErr := s.productRepo.FindProductIDBySlugHistory(slug)
+		if lookupErr != nil {
+			return nil, false, lookupErr
+		}
+		p, err = s.productRepo.GetProduct(id)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := s.pricing.Apply(sel, p); err != nil {
+		return nil, false, err
+	}
+
+	if err := s.attachBreadcrumbs(p); err != nil {
+		return nil, false, err
+	}
+
+	return p, current, nil
+}
+
+func (s *Service) Create(input CreateProductInput) (*product.Product, error) {
+	amount, err := s.parsePrice(input.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.SEO.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.categoryRepo.GetCategoryByID(input.CategoryID); err != nil {
+		return nil, err
+	}
+
+	slug, err := s.resolveSlug(input.Slug, input.Name, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := s.attributeSchema(input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
 	p := &product.Product{
-		Name:       name,
-		Price:      price,
-		Stock:      stock,
-		CategoryID: categoryID,
+		SKU:        input.SKU,
+		Name:       input.Name,
+		Slug:       slug,
+		SEO:        input.SEO,
+		Price:      amount,
+		CategoryID: input.CategoryID,
 		Disabled:   false,
 	}
+	if err := s.applySale(p, input.Sale); err != nil {
+		return nil, err
+	}
+	if err := applyDetails(p, input.Details, schema); err != nil {
+		return nil, err
+	}
 
-	err := s.productRepo.CreateProduct(p)
+	err = s.productRepo.CreateProduct(p)
 	if err != nil {
 		return nil, err
 	}
 
+	// Opening stock goes through the ledger like any other receipt
+	if err := s.receiveInitialStock(p.ID, "", input.Stock); err != nil {
+		return nil, err
+	}
+	p.Stock = input.Stock
+	p.Available = input.Stock
+
 	return p, nil
 }
 
-func (s *Service) Update(id, name string, price float64, stock int, disabled bool) (*product.Product, error) {
+// Update changes product details; stock is only changed through inventory
+// movements. Renamed slugs keep redirecting to the product.
+func (s *Service) Update(id string, input UpdateProductInput) (*product.Product, error) {
+	amount, err := s.parsePrice(input.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.SEO.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.productRepo.GetProduct(id)
+	if err != nil {
+		return nil, err
+	}
+
+	slug := existing.Slug
+	if input.Slug != "" || input.Name != existing.Name {
+		slug, err = s.resolveSlug(input.Slug, input.Name, id, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sku := existing.SKU
+	if input.SKU != "" {
+		sku = input.SKU
+	}
+
+	schema, err := s.attributeSchema(existing.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
 	p := &product.Product{
-		ID:       id,
-		Name:     name,
-		Price:    price,
-		Stock:    stock,
-		Disabled: disabled,
+		ID:         id,
+		SKU:        sku,
+		Name:       input.Name,
+		Slug:       slug,
+		SEO:        input.SEO,
+		Price:      amount,
+		Disabled:   input.Disabled,
+		CategoryID: existing.CategoryID,
+	}
+	if err := s.applySale(p, input.Sale); err != nil {
+		return nil, err
+	}
+	if err := applyDetails(p, input.Details, schema); err != nil {
+		return nil, err
 	}
 
-	err := s.productRepo.UpdateProduct(p)
+	err = s.productRepo.UpdateProduct(p)
 	if err != nil {
 		return nil, err
 	}
 
-	return p, nil
+	return s.productRepo.GetProduct(id)
+}
+
+// attributeSchema returns the attributes that apply to products of the category
+func (s *Service) attributeSchema(categoryID string) ([]*category.Attribute, error) {
+	path, err := s.categoryRepo.Ancestors(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIDs := make([]string, len(path))
+	for i, c := range path {
+		categoryIDs[i] = c.ID
+	}
+
+	attributes, err := s.categoryRepo.ListAttributes(categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return category.Schema(path, attributes), nil
+}
+
+// applyDetails sets the descriptive fields of p, checking specifications
+// against the attribute schema of the product's category
+func applyDetails(p *product.Product, details ProductDetails, schema []*category.Attribute) error {
+	if details.WeightGrams < 0 || details.LengthMM < 0 || details.WidthMM < 0 || details.HeightMM < 0 {
+		return product.ErrInvalidMeasurement
+	}
+
+	known := make(map[string]bool, len(schema))
+	for _, a := range schema {
+		known[a.Key] = true
+	}
+	for key := range details.Specifications {
+		if !known[key] {
+			return category.ErrUnknownAttribute
+		}
+	}
+
+	var specs []product.Specification
+	for _, a := range schema {
+		raw, ok := details.Specifications[a.Key]
+		if !ok || strings.TrimSpace(raw) == "" {
+			if a.Required {
+				return category.ErrAttributeRequired
+			}
+			continue
+		}
+
+		value, number, err := a.Normalize(raw)
+		if err != nil {
+			return err
+		}
+		specs = append(specs, product.Specification{
+			AttributeID: a.ID,
+			Key:         a.Key,
+			Label:       a.Label,
+			Type:        a.Type,
+			Unit:        a.Unit,
+			Value:       value,
+			NumberValue: number,
+		})
+	}
+
+	p.Description = sanitize.HTML(details.Description)
+	p.Brand = strings.TrimSpace(details.Brand)
+	p.TaxClass = strings.ToLower(strings.TrimSpace(details.TaxClass))
+	if p.TaxClass == "" {
+		p.TaxClass = product.DefaultTaxClass
+	}
+	p.WeightGrams = details.WeightGrams
+	p.Dimensions = product.Dimensions{
+		LengthMM: details.LengthMM,
+		WidthMM:  details.WidthMM,
+		HeightMM: details.HeightMM,
+	}
+	p.GiftCard = details.GiftCard
+	p.Specifications = specs
+	return nil
+}
+
+// maxSlugSuffix bounds the numbered candidates tried for a generated slug
+const maxSlugSuffix = 100
+
+// resolveSlug validates an explicit slug, or generates a free one from the
+// name by appending -2, -3, ... on collision. Slugs in reserved count as taken;
+// bulk imports use it for slugs claimed earlier in the same batch.
+func (s *Service) resolveSlug(slug, name, productID string, reserved map[string]bool) (string, error) {
+	if slug != "" {
+		if !seo.ValidSlug(slug) {
+			return "", seo.ErrInvalidSlug
+		}
+		if reserved[slug] {
+			return "", product.ErrSlugTaken
+		}
+		taken, err := s.productRepo.SlugTaken(slug, productID)
+		if err != nil {
+			return "", err
+		}
+		if taken {
+			return "", product.ErrSlugTaken
+		}
+		return slug, nil
+	}
+
+	base := seo.Slugify(name)
+	if base == "" {
+		return "", seo.ErrInvalidSlug
+	}
+
+	for i := 1; i <= maxSlugSuffix; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, i)
+		}
+		if reserved[candidate] {
+			continue
+		}
+		taken, err := s.productRepo.SlugTaken(candidate, productID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+
+	return "", product.ErrSlugTaken
+}
+
+// maxSitemapURLs is the limit on URLs in a single sitemap file
+const maxSitemapURLs = 50000
+
+// Sitemap lists enabled products for the XML sitemap, most recently updated
+// first. Products without a canonical URL link to their storefront page.
+func (s *Service) Sitemap() ([]SitemapURL, error) {
+	entries, err := s.productRepo.ListSitemapEntries(maxSitemapURLs)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]SitemapURL, len(entries))
+	for i, e := range entries {
+		loc := e.CanonicalURL
+		if loc == "" {
+			loc = s.storeURL + "/products/" + e.Slug
+		}
+		urls[i] = SitemapURL{Loc: loc, LastMod: e.UpdatedAt}
+	}
+	return urls, nil
+}
+
+// Like adds the product to the user's wishlist; liking twice has no effect
+func (s *Service) Like(userID, productID string) error {
+	if _, err := s.productRepo.GetProduct(productID); err != nil {
+		return err
+	}
+
+	return s.likeRepo.Like(userID, productID)
+}
+
+// Unlike removes the product from the user's wishlist; it is a no-op when not liked
+func (s *Service) Unlike(userID, productID string) error {
+	return s.likeRepo.Unlike(userID, productID)
+}
+
+// ListLikes returns the user's wishlist, most recently liked first
+func (s *Service) ListLikes(userID string, params pagination.Params, currency string) (pagination.Result[*product.Like], error) {
+	sel, err := s.pricing.Select(userID, currency)
+	if err != nil {
+		return pagination.Result[*product.Like]{}, err
+	}
+
+	likes, count, err := s.likeRepo.ListLikes(userID, params)
+	if err != nil {
+		return pagination.Result[*product.Like]{}, err
+	}
+
+	var products []*product.Product
+	for _, like := range likes {
+		if like.Product != nil {
+			products = append(products, like.Product)
+		}
+	}
+	if err := s.pricing.Apply(sel, products...); err != nil {
+		return pagination.Result[*product.Like]{}, err
+	}
+
+	return pagination.BuildPagedResult(params, count, likes, likeCursor), nil
+}
+
+func likeCursor(l *product.Like) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: l.CreatedAt, ID: l.ID}
+}
+
+func (s *Service) AddOptionType(productID string, input CreateOptionTypeInput) (*product.OptionType, error) {
+	p, err := s.productRepo.GetProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adding a dimension would leave existing variants without a value for it
+	if p.HasVariants() {
+		return nil, product.ErrInvalidVariantOptions
+	}
+
+	ot := &product.OptionType{
+		ProductID: productID,
+		Name:      input.Name,
+		Position:  len(p.Options),
+	}
+	for i, value := range input.Values {
+		ot.Values = append(ot.Values, product.OptionValue{Value: value, Position: i})
+	}
+
+	if err := s.variantRepo.CreateOptionType(ot); err != nil {
+		return nil, err
+	}
+
+	return ot, nil
+}
+
+func (s *Service) CreateVariant(productID string, input CreateVariantInput) (*product.Variant, error) {
+	p, err := s.productRepo.GetProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.ValidateVariantOptions(input.OptionValueIDs); err != nil {
+		return nil, err
+	}
+
+	price, err := s.parseOptionalPrice(input.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &product.Variant{
+		ProductID: productID,
+		SKU:       input.SKU,
+		Price:     price,
+	}
+
+	if err := s.variantRepo.CreateVariant(v, input.OptionValueIDs); err != nil {
+		return nil, err
+	}
+
+	if err := s.receiveInitialStock(productID, v.ID, input.Stock); err != nil {
+		return nil, err
+	}
+	v.Stock = input.Stock
+
+	return v, nil
+}
+
+func (s *Service) UpdateVariant(productID, variantID string, input UpdateVariantInput) (*product.Variant, error) {
+	price, err := s.parseOptionalPrice(input.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &product.Variant{
+		ID:        variantID,
+		ProductID: productID,
+		SKU:       input.SKU,
+		Price:     price,
+		Disabled:  input.Disabled,
+	}
+
+	if err := s.variantRepo.UpdateVariant(v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (s *Service) DeleteVariant(productID, variantID string) error {
+	return s.variantRepo.DeleteVariant(productID, variantID)
+}
+
+func (s *Service) AddVariantImage(productID, variantID string, input AddImageInput) (*product.ProductImage, error) {
+	img := &product.ProductImage{
+		ProductID: productID,
+		VariantID: variantID,
+		URL:       input.URL,
+		AltText:   input.AltText,
+	}
+
+	if err := s.variantRepo.AddVariantImage(img); err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+func (s *Service) receiveInitialStock(productID, variantID string, quantity int) error {
+	if quantity <= 0 {
+		return nil
+	}
+
+	return s.inventoryRepo.RecordMovement(&inventory.Movement{
+		ProductID: productID,
+		VariantID: variantID,
+		Type:      inventory.MovementReceipt,
+		Quantity:  quantity,
+		Reason:    "initial stock",
+	})
+}
+
+// attachBreadcrumbs sets each product's category path, reading every distinct
+// category once
+func (s *Service) attachBreadcrumbs(products ...*product.Product) error {
+	paths := make(map[string][]category.Breadcrumb)
+	for _, p := range products {
+		if p.CategoryID == "" {
+			continue
+		}
+
+		path, ok := paths[p.CategoryID]
+		if !ok {
+			ancestors, err := s.categoryRepo.Ancestors(p.CategoryID)
+			if err != nil && err != category.ErrNotFound {
+				return err
+			}
+
+			path = make([]category.Breadcrumb, len(ancestors))
+			for i, c := range ancestors {
+				path[i] = category.Breadcrumb{ID: c.ID, Name: c.Name, Slug: c.Slug}
+			}
+			paths[p.CategoryID] = path
+		}
+		p.Breadcrumbs = path
+	}
+	return nil
+}
+
+// parsePrice reads a decimal price in the store currency; prices cannot be negative
+func (s *Service) parsePrice(amount string) (money.Money, error) {
+	price, err := money.Parse(amount, s.currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if price.IsNegative() {
+		return money.Money{}, money.ErrInvalidAmount
+	}
+	return price, nil
+}
+
+func (s *Service) parseOptionalPrice(amount *string) (*money.Money, error) {
+	if amount == nil {
+		return nil, nil
+	}
+
+	price, err := s.parsePrice(*amount)
+	if err != nil {
+		return nil, err
+	}
+	return &price, nil
 }
@@ -0,0 +1,41 @@
+package promotionapp
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/promotion"
+)
+
+// Config holds promotion settings
+type Config struct {
+	Currency string // store currency promotion amounts are set in
+}
+
+// PromotionInput represents the input for creating or replacing a promotion
+type PromotionInput struct {
+	Name             string
+	Code             string // empty for an automatic promotion
+	Type             promotion.Type
+	Percent          int
+	Amount           string // decimal amount in the store currency
+	BuyQuantity      int
+	GetQuantity      int
+	MinOrderValue    string // decimal amount in the store currency; empty for none
+	UsageLimit       int
+	PerCustomerLimit int
+	ProductIDs       []string
+	CategoryIDs      []string
+	StartsAt         *time.Time
+	EndsAt           *time.Time
+	Active           bool
+}
+
+// LineInput represents a priced cart line promotions are evaluated against
+type LineInput struct {
+	ProductID  string
+	VariantID  string
+	CategoryID string
+	UnitPrice  money.Money
+	Quantity   int
+}
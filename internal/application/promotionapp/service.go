@@ -0,0 +1,304 @@
+package promotionapp
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/pricingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/category"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/promotion"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+// Service handles discount codes, automatic promotions and their redemption
+type Service struct {
+	promotionRepo promotion.Repository
+	productRepo   product.Repository
+	categoryRepo  category.Repository
+	pricing       *pricingapp.Service
+	config        Config
+}
+
+// NewService creates a new promotion application service
+func NewService(
+	promotionRepo promotion.Repository,
+	productRepo product.Repository,
+	categoryRepo category.Repository,
+	pricing *pricingapp.Service,
+	config Config,
+) *Service {
+	return &Service{
+		promotionRepo: promotionRepo,
+		productRepo:   productRepo,
+		categoryRepo:  categoryRepo,
+		pricing:       pricing,
+		config:        config,
+	}
+}
+
+func (s *Service) CreatePromotion(input PromotionInput) (*promotion.Promotion, error) {
+	p, err := s.build(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.promotionRepo.CreatePromotion(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// UpdatePromotion replaces the rules of a promotion, keeping its usage count
+func (s *Service) UpdatePromotion(id string, input PromotionInput) (*promotion.Promotion, error) {
+	existing, err := s.promotionRepo.GetPromotion(id)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.build(input)
+	if err != nil {
+		return nil, err
+	}
+	p.ID = existing.ID
+	p.UsageCount = existing.UsageCount
+
+	if err := s.promotionRepo.UpdatePromotion(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *Service) GetPromotion(id string) (*promotion.Promotion, error) {
+	return s.promotionRepo.GetPromotion(id)
+}
+
+func (s *Service) ListPromotions() ([]*promotion.Promotion, error) {
+	return s.promotionRepo.ListPromotions()
+}
+
+func (s *Service) DeletePromotion(id string) error {
+	return s.promotionRepo.DeletePromotion(id)
+}
+
+// build validates the input into a promotion, checking that targeted
+// products and categories exist
+func (s *Service) build(input PromotionInput) (*promotion.Promotion, error) {
+	p := &promotion.Promotion{
+		Name:             input.Name,
+		Code:             promotion.NormalizeCode(input.Code),
+		Type:             input.Type,
+		Percent:          input.Percent,
+		BuyQuantity:      input.BuyQuantity,
+		GetQuantity:      input.GetQuantity,
+		UsageLimit:       input.UsageLimit,
+		PerCustomerLimit: input.PerCustomerLimit,
+		ProductIDs:       input.ProductIDs,
+		CategoryIDs:      input.CategoryIDs,
+		StartsAt:         input.StartsAt,
+		EndsAt:           input.EndsAt,
+		Active:           input.Active,
+	}
+
+	if input.Amount != "" {
+		amount, err := money.Parse(input.Amount, s.config.Currency)
+		if err != nil {
+			return nil, err
+		}
+		p.Amount = &amount
+	}
+	if input.MinOrderValue != "" {
+		minOrderValue, err := money.Parse(input.MinOrderValue, s.config.Currency)
+		if err != nil {
+			return nil, err
+		}
+		p.MinOrderValue = &minOrderValue
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range p.ProductIDs {
+		if _, err := s.productRepo.GetProduct(id); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range p.CategoryIDs {
+		if _, err := s.categoryRepo.GetCategoryByID(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// Evaluate applies the running automatic promotions and the discount code
+// (empty for none) to the lines of a cart in currency. It fails with
+// promotion.ErrInvalidCode, ErrUsageLimitReached or ErrNotApplicable when the
+// code cannot be used. userID may be empty for anonymous carts, which skips
+// promotions with a per-customer limit.
+func (s *Service) Evaluate(userID, currency, code string, lines []LineInput) (*promotion.Breakdown, error) {
+	now := time.Now()
+
+	var coupon *promotion.Promotion
+	if code != "" {
+		var err error
+		if coupon, err = s.coupon(userID, code, now); err != nil {
+			return nil, err
+		}
+		if err := s.convert(coupon, currency); err != nil {
+			return nil, err
+		}
+	}
+
+	running, err := s.promotionRepo.ListAutomatic(now)
+	if err != nil {
+		return nil, err
+	}
+
+	var automatic []*promotion.Promotion
+	for _, p := range running {
+		available, err := s.available(p, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !available {
+			continue
+		}
+
+		if err := s.convert(p, currency); err != nil {
+			return nil, err
+		}
+		automatic = append(automatic, p)
+	}
+
+	domainLines, err := s.lines(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return promotion.Apply(currency, domainLines, automatic, coupon)
+}
+
+// coupon returns the running promotion for a code the customer can still use
+func (s *Service) coupon(userID, code string, now time.Time) (*promotion.Promotion, error) {
+	p, err := s.promotionRepo.GetPromotionByCode(promotion.NormalizeCode(code))
+	if err != nil {
+		if err == promotion.ErrNotFound {
+			return nil, promotion.ErrInvalidCode
+		}
+		return nil, err
+	}
+	if !p.Running(now) {
+		return nil, promotion.ErrInvalidCode
+	}
+
+	available, err := s.available(p, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		return nil, promotion.ErrUsageLimitReached
+	}
+
+	return p, nil
+}
+
+// available reports whether the promotion has redemptions left for the user
+func (s *Service) available(p *promotion.Promotion, userID string) (bool, error) {
+	if p.Exhausted() {
+		return false, nil
+	}
+	if p.PerCustomerLimit == 0 {
+		return true, nil
+	}
+	if userID == "" {
+		return false, nil
+	}
+
+	used, err := s.promotionRepo.CountRedemptions(p.ID, userID)
+	if err != nil {
+		return false, err
+	}
+	return used < p.PerCustomerLimit, nil
+}
+
+// convert expresses the promotion amounts in the cart currency
+func (s *Service) convert(p *promotion.Promotion, currency string) error {
+	if p.Amount != nil {
+		amount, err := s.pricing.Convert(*p.Amount, currency)
+		if err != nil {
+			return err
+		}
+		p.Amount = &amount
+	}
+	if p.MinOrderValue != nil {
+		minOrderValue, err := s.pricing.Convert(*p.MinOrderValue, currency)
+		if err != nil {
+			return err
+		}
+		p.MinOrderValue = &minOrderValue
+	}
+	return nil
+}
+
+// lines builds the promotion lines, adding the ancestors of each product
+// category so category targets include their subcategories
+func (s *Service) lines(inputs []LineInput) ([]promotion.Line, error) {
+	categories := make(map[string][]string)
+	lines := make([]promotion.Line, len(inputs))
+	for i, input := range inputs {
+		categoryIDs, ok := categories[input.CategoryID]
+		if !ok && input.CategoryID != "" {
+			ancestors, err := s.categoryRepo.Ancestors(input.CategoryID)
+			if err != nil && err != apperrors.ErrNotFound {
+				return nil, err
+			}
+			categoryIDs = []string{input.CategoryID}
+			for _, c := range ancestors {
+				if c.ID != input.CategoryID {
+					categoryIDs = append(categoryIDs, c.ID)
+				}
+			}
+			categories[input.CategoryID] = categoryIDs
+		}
+
+		lines[i] = promotion.Line{
+			ProductID:   input.ProductID,
+			VariantID:   input.VariantID,
+			CategoryIDs: categoryIDs,
+			UnitPrice:   input.UnitPrice,
+			Quantity:    input.Quantity,
+		}
+	}
+	return lines, nil
+}
+
+// Redeem counts the promotions applied to an order against their limits. It
+// fails with promotion.ErrUsageLimitReached, recording nothing, when another
+// order used up a promotion since the cart was priced.
+func (s *Service) Redeem(userID, orderID string, b *promotion.Breakdown) error {
+	if len(b.Applied) == 0 {
+		return nil
+	}
+
+	redemptions := make([]*promotion.Redemption, len(b.Applied))
+	for i, applied := range b.Applied {
+		redemptions[i] = &promotion.Redemption{
+			PromotionID: applied.PromotionID,
+			OrderID:     orderID,
+			UserID:      userID,
+			Amount:      applied.Amount,
+		}
+	}
+
+	return s.promotionRepo.Redeem(redemptions)
+}
+
+// Release gives back the promotion uses of an order that will not be paid
+func (s *Service) Release(orderID string) error {
+	return s.promotionRepo.ReleaseRedemptions(orderID)
+}
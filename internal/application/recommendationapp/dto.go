@@ -0,0 +1,20 @@
+package recommendationapp
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/recommendation"
+)
+
+// Config holds recommendation settings
+type Config struct {
+	Window    time.Duration // how far back paid orders count as co-purchases
+	MinOrders int           // orders a pair needs before it is recommended
+}
+
+// CreateLinkInput represents a curated link from one product to another
+type CreateLinkInput struct {
+	TargetID string
+	Type     recommendation.LinkType
+	Position int
+}
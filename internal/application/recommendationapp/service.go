@@ -0,0 +1,181 @@
+package recommendationapp
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/pricingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/recommendation"
+)
+
+// DefaultLimit is the number of recommendations returned when none is requested
+const DefaultLimit = 10
+
+// Service handles curated product links and precomputed recommendations
+type Service struct {
+	recommendationRepo recommendation.Repository
+	productRepo        product.Repository
+	pricing            *pricingapp.Service
+	config             Config
+}
+
+// NewService creates a new recommendation application service
+func NewService(
+	recommendationRepo recommendation.Repository,
+	productRepo product.Repository,
+	pricing *pricingapp.Service,
+	config Config,
+) *Service {
+	return &Service{
+		recommendationRepo: recommendationRepo,
+		productRepo:        productRepo,
+		pricing:            pricing,
+		config:             config,
+	}
+}
+
+// Recommendations returns the precomputed recommendations of a product that
+// are currently enabled and in stock, priced in the requested currency. limit
+// is clamped to 1..recommendation.MaxPerProduct; 0 uses DefaultLimit.
+func (s *Service) Recommendations(productID, currency string, limit int) ([]*recommendation.Recommendation, error) {
+	sel, err := s.pricing.Select("", currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.productRepo.GetProduct(productID); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case limit <= 0:
+		limit = DefaultLimit
+	case limit > recommendation.MaxPerProduct:
+		limit = recommendation.MaxPerProduct
+	}
+
+	recs, err := s.recommendationRepo.ListRecommendations(productID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*product.Product, len(recs))
+	for i, rec := range recs {
+		products[i] = rec.Product
+	}
+	if err := s.pricing.Apply(sel, products...); err != nil {
+		return nil, err
+	}
+
+	if recs == nil {
+		recs = []*recommendation.Recommendation{}
+	}
+	return recs, nil
+}
+
+// ListLinks returns the curated links of a product
+func (s *Service) ListLinks(productID string) ([]*recommendation.Link, error) {
+	if _, err := s.productRepo.GetProduct(productID); err != nil {
+		return nil, err
+	}
+
+	return s.recommendationRepo.ListLinks(productID)
+}
+
+// CreateLink adds a curated link and refreshes the product's recommendations
+// so the link shows without waiting for the next batch run
+func (s *Service) CreateLink(productID string, input CreateLinkInput) (*recommendation.Link, error) {
+	if !recommendation.ValidLinkType(input.Type) || input.TargetID == productID {
+		return nil, recommendation.ErrInvalidLink
+	}
+
+	if _, err := s.productRepo.GetProduct(productID); err != nil {
+		return nil, err
+	}
+	if _, err := s.productRepo.GetProduct(input.TargetID); err != nil {
+		return nil, err
+	}
+
+	link := &recommendation.Link{
+		ProductID: productID,
+		TargetID:  input.TargetID,
+		Type:      input.Type,
+		Position:  input.Position,
+	}
+	if err := s.recommendationRepo.CreateLink(link); err != nil {
+		return nil, err
+	}
+
+	if err := s.refresh(productID); err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// DeleteLink removes a curated link and refreshes the product's recommendations
+func (s *Service) DeleteLink(productID, linkID string) error {
+	if err := s.recommendationRepo.DeleteLink(productID, linkID); err != nil {
+		return err
+	}
+
+	return s.refresh(productID)
+}
+
+// refresh rebuilds one product's list from its links and the co-purchases of
+// the last batch run
+func (s *Service) refresh(productID string) error {
+	links, err := s.recommendationRepo.ListLinks(productID)
+	if err != nil {
+		return err
+	}
+
+	pairs, err := s.recommendationRepo.ListCoPurchases(productID)
+	if err != nil {
+		return err
+	}
+
+	return s.recommendationRepo.ReplaceRecommendations(map[string][]*recommendation.Recommendation{
+		productID: recommendation.Merge(productID, links, pairs),
+	})
+}
+
+// Rebuild recomputes co-purchase pairs from recent paid orders and every
+// product's recommendation list; it runs as a background job
+func (s *Service) Rebuild() error {
+	since := time.Now().Add(-s.config.Window)
+	pairs, err := s.recommendationRepo.ComputeCoPurchases(since, s.config.MinOrders, recommendation.MaxPerProduct)
+	if err != nil {
+		return err
+	}
+
+	if err := s.recommendationRepo.ReplaceCoPurchases(pairs); err != nil {
+		return err
+	}
+
+	links, err := s.recommendationRepo.ListAllLinks()
+	if err != nil {
+		return err
+	}
+
+	linksByProduct := make(map[string][]*recommendation.Link)
+	for _, l := range links {
+		linksByProduct[l.ProductID] = append(linksByProduct[l.ProductID], l)
+	}
+	pairsByProduct := make(map[string][]recommendation.CoPurchase)
+	for _, p := range pairs {
+		pairsByProduct[p.ProductID] = append(pairsByProduct[p.ProductID], p)
+	}
+
+	lists := make(map[string][]*recommendation.Recommendation)
+	for productID, productLinks := range linksByProduct {
+		lists[productID] = recommendation.Merge(productID, productLinks, pairsByProduct[productID])
+	}
+	for productID, productPairs := range pairsByProduct {
+		if _, ok := lists[productID]; !ok {
+			lists[productID] = recommendation.Merge(productID, nil, productPairs)
+		}
+	}
+
+	return s.recommendationRepo.ReplaceAllRecommendations(lists)
+}
@@ -0,0 +1,9 @@
+package reviewapp
+
+// CreateReviewInput represents the input for reviewing a purchased product
+type CreateReviewInput struct {
+	OrderID string
+	Rating  int
+	Title   string
+	Body    string
+}
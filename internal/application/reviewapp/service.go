@@ -0,0 +1,144 @@
+package reviewapp
+
+import (
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/review"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+)
+
+// Service handles review-related use cases
+type Service struct {
+	reviewRepo  review.Repository
+	orderRepo   order.Repository
+	productRepo product.Repository
+}
+
+// NewService creates a new review application service
+func NewService(reviewRepo review.Repository, orderRepo order.Repository, productRepo product.Repository) *Service {
+	return &Service{
+		reviewRepo:  reviewRepo,
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+	}
+}
+
+// Create submits a review for moderation. The order must be a paid order of
+// the user that contains the product.
+func (s *Service) Create(userID, productID string, input CreateReviewInput) (*review.Review, error) {
+	if !review.ValidRating(input.Rating) {
+		return nil, review.ErrInvalidRating
+	}
+
+	if _, err := s.productRepo.GetProduct(productID); err != nil {
+		return nil, err
+	}
+
+	if err := s.verifyPurchase(userID, productID, input.OrderID); err != nil {
+		return nil, err
+	}
+
+	rv := &review.Review{
+		ProductID: productID,
+		UserID:    userID,
+		OrderID:   input.OrderID,
+		Rating:    input.Rating,
+		Title:     input.Title,
+		Body:      input.Body,
+		Status:    review.StatusPending,
+	}
+
+	if err := s.reviewRepo.CreateReview(rv); err != nil {
+		return nil, err
+	}
+
+	return rv, nil
+}
+
+func (s *Service) verifyPurchase(userID, productID, orderID string) error {
+	o, err := s.orderRepo.GetOrder(orderID)
+	if err != nil {
+		if err == apperrors.ErrNotFound {
+			return review.ErrNotVerifiedPurchase
+		}
+		return err
+	}
+
+	if o.UserID != userID || !o.Status.Paid() {
+		return review.ErrNotVerifiedPurchase
+	}
+
+	for _, line := range o.Lines {
+		if line.ProductID == productID {
+			return nil
+		}
+	}
+
+	return review.ErrNotVerifiedPurchase
+}
+
+// ListForProduct returns the approved reviews of a product, newest first
+func (s *Service) ListForProduct(productID string, params pagination.Params) (pagination.Result[*review.Review], error) {
+	return s.list(params, review.Filters{ProductID: productID, Status: review.StatusApproved})
+}
+
+// ListForModeration returns reviews in the given status; the queue defaults to pending
+func (s *Service) ListForModeration(params pagination.Params, status review.Status) (pagination.Result[*review.Review], error) {
+	if status == "" {
+		status = review.StatusPending
+	}
+	return s.list(params, review.Filters{Status: status})
+}
+
+func (s *Service) list(params pagination.Params, filters review.Filters) (pagination.Result[*review.Review], error) {
+	reviews, count, err := s.reviewRepo.ListReviews(params, filters)
+	if err != nil {
+		return pagination.Result[*review.Review]{}, err
+	}
+
+	return pagination.BuildPagedResult(params, count, reviews, reviewCursor), nil
+}
+
+func reviewCursor(rv *review.Review) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: rv.CreatedAt, ID: rv.ID}
+}
+
+func (s *Service) Approve(id, moderatorID string) (*review.Review, error) {
+	return s.moderate(id, review.StatusApproved, moderatorID)
+}
+
+func (s *Service) Reject(id, moderatorID string) (*review.Review, error) {
+	return s.moderate(id, review.StatusRejected, moderatorID)
+}
+
+func (s *Service) Hide(id, moderatorID string) (*review.Review, error) {
+	return s.moderate(id, review.StatusHidden, moderatorID)
+}
+
+func (s *Service) moderate(id string, to review.Status, moderatorID string) (*review.Review, error) {
+	moved, err := s.reviewRepo.Moderate(id, review.ModerationSources(to), to, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+	if !moved {
+		return nil, review.ErrInvalidTransition
+	}
+
+	return s.reviewRepo.GetReview(id)
+}
+
+// MarkHelpful records the user's helpful vote on an approved review
+func (s *Service) MarkHelpful(reviewID, userID string) error {
+	rv, err := s.reviewRepo.GetReview(reviewID)
+	if err != nil {
+		return err
+	}
+
+	// Unpublished reviews are not visible to customers
+	if rv.Status != review.StatusApproved {
+		return review.ErrNotFound
+	}
+
+	return s.reviewRepo.AddHelpfulVote(reviewID, userID)
+}
@@ -0,0 +1,28 @@
+package rmaapp
+
+import "github.com/RubenRodrigo/go-tiny-store/internal/domain/rma"
+
+// RequestReturnInput represents a customer's request to return order lines
+type RequestReturnInput struct {
+	Comment string
+	Lines   []ReturnLineInput
+}
+
+// ReturnLineInput is a quantity of an order line to return
+type ReturnLineInput struct {
+	OrderLineID string
+	Quantity    int
+	Reason      rma.Reason
+}
+
+// RefundInput represents a refund issued by a manager. An empty amount
+// refunds the returned lines, or everything not yet refunded for a refund
+// outside a return. Refunds go back to the card up to what it was charged,
+// and the rest, paid with gift cards or store credit, becomes store credit.
+// Gift cards bought with the order are only refunded by returning them
+// unspent; the refund voids them.
+type RefundInput struct {
+	Amount      string
+	Reason      string
+	StoreCredit bool // issue the whole refund as store credit
+}
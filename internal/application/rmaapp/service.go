@@ -0,0 +1,462 @@
+package rmaapp
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/giftcardapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/inventoryapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/invoiceapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/giftcard"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/inventory"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/payment"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/rma"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+)
+
+// Service handles return and refund use cases
+type Service struct {
+	returnRepo       rma.Repository
+	orderRepo        order.Repository
+	refundRepo       order.RefundRepository
+	paymentGateway   payment.Gateway
+	inventoryService *inventoryapp.Service
+	invoiceService   *invoiceapp.Service
+	giftCardService  *giftcardapp.Service
+}
+
+// NewService creates a new return application service
+func NewService(
+	returnRepo rma.Repository,
+	orderRepo order.Repository,
+	refundRepo order.RefundRepository,
+	paymentGateway payment.Gateway,
+	inventoryService *inventoryapp.Service,
+	invoiceService *invoiceapp.Service,
+	giftCardService *giftcardapp.Service,
+) *Service {
+	return &Service{
+		returnRepo:       returnRepo,
+		orderRepo:        orderRepo,
+		refundRepo:       refundRepo,
+		paymentGateway:   paymentGateway,
+		inventoryService: inventoryService,
+		invoiceService:   invoiceService,
+		giftCardService:  giftCardService,
+	}
+}
+
+// Request opens a return for lines of one of the user's paid orders
+func (s *Service) Request(userID, orderID string, input RequestReturnInput) (*rma.Return, error) {
+	o, err := s.orderRepo.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	// Don't reveal other customers' orders
+	if o.UserID != userID {
+		return nil, apperrors.ErrNotFound
+	}
+	if !o.Status.Paid() {
+		return nil, rma.ErrOrderNotReturnable
+	}
+
+	if len(input.Lines) == 0 {
+		return nil, rma.ErrInvalidReturn
+	}
+
+	orderLines := make(map[string]order.Line, len(o.Lines))
+	for _, l := range o.Lines {
+		orderLines[l.ID] = l
+	}
+
+	ret := &rma.Return{
+		OrderID: orderID,
+		UserID:  userID,
+		Status:  rma.StatusRequested,
+		Comment: strings.TrimSpace(input.Comment),
+	}
+	seen := make(map[string]bool, len(input.Lines))
+	for _, in := range input.Lines {
+		l, ok := orderLines[in.OrderLineID]
+		if !ok || seen[in.OrderLineID] || in.Quantity <= 0 || !in.Reason.IsValid() {
+			return nil, rma.ErrInvalidReturn
+		}
+		seen[in.OrderLineID] = true
+
+		ret.Lines = append(ret.Lines, rma.Line{
+			OrderLineID: l.ID,
+			ProductID:   l.ProductID,
+			VariantID:   l.VariantID,
+			Quantity:    in.Quantity,
+			Reason:      in.Reason,
+		})
+	}
+
+	if err := s.returnRepo.CreateReturn(ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *Service) ListForUser(userID string, params pagination.Params) (pagination.Result[*rma.Return], error) {
+	return s.list(params, rma.Filters{UserID: userID})
+}
+
+// List returns returns in the given status, or all of them
+func (s *Service) List(params pagination.Params, status rma.Status) (pagination.Result[*rma.Return], error) {
+	return s.list(params, rma.Filters{Status: status})
+}
+
+func (s *Service) list(params pagination.Params, filters rma.Filters) (pagination.Result[*rma.Return], error) {
+	returns, count, err := s.returnRepo.ListReturns(params, filters)
+	if err != nil {
+		return pagination.Result[*rma.Return]{}, err
+	}
+
+	return pagination.BuildPagedResult(params, count, returns, returnCursor), nil
+}
+
+func returnCursor(ret *rma.Return) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: ret.CreatedAt, ID: ret.ID}
+}
+
+func (s *Service) Get(id string) (*rma.Return, error) {
+	return s.returnRepo.GetReturn(id)
+}
+
+// GetForUser returns the return only if it belongs to the user
+func (s *Service) GetForUser(id, userID string) (*rma.Return, error) {
+	ret, err := s.returnRepo.GetReturn(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret.UserID != userID {
+		return nil, rma.ErrNotFound
+	}
+
+	return ret, nil
+}
+
+func (s *Service) Approve(id, resolution string) (*rma.Return, error) {
+	return s.resolve(id, rma.StatusApproved, resolution)
+}
+
+func (s *Service) Reject(id, resolution string) (*rma.Return, error) {
+	return s.resolve(id, rma.StatusRejected, resolution)
+}
+
+func (s *Service) resolve(id string, to rma.Status, resolution string) (*rma.Return, error) {
+	ret, err := s.returnRepo.GetReturn(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.Status = to
+	ret.Resolution = strings.TrimSpace(resolution)
+	return s.transition(ret)
+}
+
+// Receive records that the returned goods arrived and puts them back in
+// stock, whether or not the return was refunded already
+func (s *Service) Receive(id, actorID string) (*rma.Return, error) {
+	received, err := s.returnRepo.MarkReceived(id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := s.returnRepo.GetReturn(id)
+	if err != nil {
+		return nil, err
+	}
+	if !received {
+		return nil, rma.ErrInvalidTransition
+	}
+
+	for _, line := range ret.Lines {
+		m := &inventory.Movement{
+			ProductID: line.ProductID,
+			VariantID: line.VariantID,
+			Type:      inventory.MovementReturn,
+			Quantity:  line.Quantity,
+			Reason:    "return received",
+			ActorID:   actorID,
+			Reference: ret.ID,
+		}
+		// The return is already received; a failed restock is fixed with a
+		// manual movement rather than undoing the receipt
+		if err := s.inventoryService.Record(m); err != nil {
+			log.Printf("ERROR: Failed to restock returned line. ReturnID: %s, ProductID: %s, Error: %v", ret.ID, line.ProductID, err)
+		}
+	}
+
+	return ret, nil
+}
+
+// Refund refunds an approved or received return through the payment gateway
+func (s *Service) Refund(id, actorID string, input RefundInput) (*rma.Return, error) {
+	ret, err := s.returnRepo.GetReturn(id)
+	if err != nil {
+		return nil, err
+	}
+	if !statusIn(ret.Status, rma.TransitionSources(rma.StatusRefunded)) {
+		return nil, rma.ErrInvalidTransition
+	}
+
+	o, err := s.orderRepo.GetOrder(ret.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := returnValue(o, ret)
+	if input.Amount != "" {
+		if amount, err = money.Parse(input.Amount, o.Total.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	reason := input.Reason
+	if reason == "" {
+		reason = "return"
+	}
+
+	refund, err := s.refund(o, amount, input.StoreCredit, reason, ret.ID, actorID, returnedGiftCards(o, ret))
+	if err != nil {
+		return nil, err
+	}
+
+	ret.Status = rma.StatusRefunded
+	ret.RefundID = refund.ID
+	return s.transition(ret)
+}
+
+// RefundOrder refunds part or all of a paid order outside a return
+func (s *Service) RefundOrder(orderID, actorID string, input RefundInput) (*order.Refund, error) {
+	o, err := s.orderRepo.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := o.Refundable()
+	if input.Amount != "" {
+		if amount, err = money.Parse(input.Amount, o.Total.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.refund(o, amount, input.StoreCredit, input.Reason, "", actorID, nil)
+}
+
+func (s *Service) ListRefunds(orderID string) ([]*order.Refund, error) {
+	if _, err := s.orderRepo.GetOrder(orderID); err != nil {
+		return nil, err
+	}
+	return s.refundRepo.ListRefunds(orderID)
+}
+
+// refund reserves the amount against the order total, voids the gift cards
+// being returned, asks the provider to pay back the card part, credits the
+// store credit part and records the outcome. Gift cards bought with the
+// order are only paid back by returning them: the refund may not reach into
+// the value of those not voided.
+func (s *Service) refund(o *order.Order, amount money.Money, toStoreCredit bool, reason, returnID, actorID string, giftCards map[string]int) (*order.Refund, error) {
+	if !o.Status.Paid() {
+		return nil, order.ErrNotRefundable
+	}
+	if amount.Amount <= 0 {
+		return nil, money.ErrInvalidAmount
+	}
+
+	credit, err := s.storeCreditPart(o, amount, toStoreCredit)
+	if err != nil {
+		return nil, err
+	}
+	card := money.Money{Amount: amount.Amount - credit.Amount, Currency: amount.Currency}
+	if credit.Amount > 0 && o.UserID == "" {
+		return nil, giftcard.ErrStoreCreditUnavailable
+	}
+	if card.Amount > 0 && o.PaymentID == "" {
+		return nil, order.ErrNotRefundable
+	}
+
+	voids, err := s.giftCardService.Voidable(o.ID, giftCards)
+	if err != nil {
+		return nil, err
+	}
+	outstanding, err := s.giftCardService.Outstanding(o)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range voids {
+		outstanding.Amount -= c.Initial.Amount
+	}
+	if refundable := o.Refundable().Amount; amount.Amount <= refundable && amount.Amount > refundable-outstanding.Amount {
+		return nil, giftcard.ErrNotRefundable
+	}
+
+	refund := &order.Refund{
+		OrderID:     o.ID,
+		ReturnID:    returnID,
+		Amount:      amount,
+		StoreCredit: credit,
+		Reason:      reason,
+		Status:      order.RefundPending,
+		ActorID:     actorID,
+	}
+	if err := s.refundRepo.CreateRefund(refund); err != nil {
+		return nil, err
+	}
+
+	// Voiding before paying out keeps the cards from being spent meanwhile
+	if err := s.giftCardService.Void(o.ID, voids, refund.ID, actorID); err != nil {
+		s.failRefund(refund.ID)
+		return nil, err
+	}
+
+	providerID := ""
+	if card.Amount > 0 {
+		// The refund ID keys the provider request, so a retried refund is
+		// never paid out twice
+		result, err := s.paymentGateway.Refund(o.PaymentID, card, fmt.Sprintf("refund-%s", refund.ID))
+		if err != nil {
+			s.failRefund(refund.ID)
+			return nil, err
+		}
+		providerID = result.ID
+	}
+
+	if err := s.giftCardService.Credit(o.UserID, credit, refund.ID, actorID); err != nil {
+		// Once the card part is paid back the refund stays pending, holding
+		// its amount against the total, for the credit to be looked into
+		if card.Amount == 0 {
+			s.failRefund(refund.ID)
+		} else {
+			log.Printf("ERROR: Failed to credit store credit of a refund paid back to the card. RefundID: %s, Error: %v", refund.ID, err)
+		}
+		return nil, err
+	}
+
+	if err := s.refundRepo.CompleteRefund(refund.ID, providerID); err != nil {
+		return nil, err
+	}
+
+	refund.Status = order.RefundSucceeded
+	refund.ProviderID = providerID
+
+	// The money is already on its way back; a missing credit note is
+	// issued again from the order's documents
+	if _, err := s.invoiceService.IssueCreditNote(refund.ID); err != nil {
+		log.Printf("ERROR: Failed to issue credit note. RefundID: %s, Error: %v", refund.ID, err)
+	}
+
+	return refund, nil
+}
+
+// storeCreditPart returns the part of a refund given as store credit: all of
+// it when asked for, else what is left once the card payment, less what
+// earlier refunds paid back to the card, is refunded in full
+func (s *Service) storeCreditPart(o *order.Order, amount money.Money, all bool) (money.Money, error) {
+	if all {
+		return amount, nil
+	}
+
+	refunds, err := s.refundRepo.ListRefunds(o.ID)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	card := o.CardAmount().Amount
+	for _, r := range refunds {
+		if r.Status != order.RefundFailed {
+			card -= r.Amount.Amount - r.StoreCredit.Amount
+		}
+	}
+
+	return money.Money{Amount: max(amount.Amount-max(card, 0), 0), Currency: amount.Currency}, nil
+}
+
+// failRefund gives back the gift cards the refund voided and releases its
+// amount
+func (s *Service) failRefund(id string) {
+	if err := s.giftCardService.Restore(id); err != nil {
+		log.Printf("ERROR: Failed to restore gift cards of failed refund. ID: %s, Error: %v", id, err)
+	}
+	if err := s.refundRepo.FailRefund(id); err != nil {
+		log.Printf("ERROR: Failed to release refund. ID: %s, Error: %v", id, err)
+	}
+}
+
+func (s *Service) transition(ret *rma.Return) (*rma.Return, error) {
+	moved, err := s.returnRepo.Transition(ret, rma.TransitionSources(ret.Status))
+	if err != nil {
+		return nil, err
+	}
+	if !moved {
+		return nil, rma.ErrInvalidTransition
+	}
+
+	return s.returnRepo.GetReturn(ret.ID)
+}
+
+// returnValue is what the customer paid for the returned units: each line's
+// discounted amount, plus tax when it was charged on top, in proportion to
+// the quantity returned. It never exceeds what is left to refund.
+func returnValue(o *order.Order, ret *rma.Return) money.Money {
+	orderLines := make(map[string]order.Line, len(o.Lines))
+	for _, l := range o.Lines {
+		orderLines[l.ID] = l
+	}
+
+	value := money.Zero(o.Total.Currency)
+	for _, line := range ret.Lines {
+		l, ok := orderLines[line.OrderLineID]
+		if !ok || l.Quantity == 0 {
+			continue
+		}
+		paid := l.LineTotal()
+		if !o.TaxInclusive {
+			paid.Amount += l.Tax.Amount
+		}
+		value.Amount += paid.MulRat(big.NewRat(int64(line.Quantity), int64(l.Quantity))).Amount
+	}
+
+	if refundable := o.Refundable(); value.Amount > refundable.Amount {
+		return refundable
+	}
+	return value
+}
+
+// returnedGiftCards returns the quantities of gift card lines in the return,
+// by order line ID
+func returnedGiftCards(o *order.Order, ret *rma.Return) map[string]int {
+	giftCardLines := make(map[string]bool, len(o.Lines))
+	for _, l := range o.Lines {
+		if l.GiftCard {
+			giftCardLines[l.ID] = true
+		}
+	}
+
+	quantities := make(map[string]int)
+	for _, line := range ret.Lines {
+		if giftCardLines[line.OrderLineID] {
+			quantities[line.OrderLineID] += line.Quantity
+		}
+	}
+	return quantities
+}
+
+func statusIn(status rma.Status, statuses []rma.Status) bool {
+	for _, s := range statuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package shippingapp
+
+import "github.com/RubenRodrigo/go-tiny-store/internal/domain/shipping"
+
+// Config holds shipping settings
+type Config struct {
+	Currency string // store currency method prices are set in
+}
+
+// ZoneInput represents the input for creating or replacing a shipping zone
+type ZoneInput struct {
+	Name      string
+	Countries []string // empty for the rest of the world
+}
+
+// MethodInput represents the input for creating or replacing a shipping method
+type MethodInput struct {
+	Name       string
+	Carrier    string
+	Type       shipping.MethodType
+	Price      string // decimal amount in the store currency
+	PricePerKg string // decimal amount in the store currency; weight-based methods only
+	FreeOver   string // decimal amount in the store currency; free-over-threshold methods only
+	Active     bool
+}
+
+// ShipmentInput represents the input for recording a shipment
+type ShipmentInput struct {
+	Carrier        string
+	TrackingNumber string
+}
@@ -0,0 +1,395 @@
+package shippingapp
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/pricingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/shipping"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+// Service handles shipping zones, rate quotes and shipments
+type Service struct {
+	shippingRepo shipping.Repository
+	shipmentRepo shipping.ShipmentRepository
+	orderRepo    order.Repository
+	carriers     []shipping.CarrierRates
+	pricing      *pricingapp.Service
+	config       Config
+}
+
+// NewService creates a new shipping application service. Carriers are
+// quoted alongside the store's own shipping methods; there may be none.
+func NewService(
+	shippingRepo shipping.Repository,
+	shipmentRepo shipping.ShipmentRepository,
+	orderRepo order.Repository,
+	carriers []shipping.CarrierRates,
+	pricing *pricingapp.Service,
+	config Config,
+) *Service {
+	return &Service{
+		shippingRepo: shippingRepo,
+		shipmentRepo: shipmentRepo,
+		orderRepo:    orderRepo,
+		carriers:     carriers,
+		pricing:      pricing,
+		config:       config,
+	}
+}
+
+func (s *Service) ListZones() ([]*shipping.Zone, error) {
+	return s.shippingRepo.ListZones()
+}
+
+func (s *Service) GetZone(id string) (*shipping.Zone, error) {
+	return s.shippingRepo.GetZone(id)
+}
+
+func (s *Service) CreateZone(input ZoneInput) (*shipping.Zone, error) {
+	z := &shipping.Zone{Name: input.Name, Countries: input.Countries}
+	if err := s.checkZone(z); err != nil {
+		return nil, err
+	}
+
+	if err := s.shippingRepo.CreateZone(z); err != nil {
+		return nil, err
+	}
+
+	return z, nil
+}
+
+// UpdateZone replaces the name and countries of a zone, keeping its methods
+func (s *Service) UpdateZone(id string, input ZoneInput) (*shipping.Zone, error) {
+	z := &shipping.Zone{ID: id, Name: input.Name, Countries: input.Countries}
+	if err := s.checkZone(z); err != nil {
+		return nil, err
+	}
+
+	if err := s.shippingRepo.UpdateZone(z); err != nil {
+		return nil, err
+	}
+
+	return z, nil
+}
+
+func (s *Service) DeleteZone(id string) error {
+	return s.shippingRepo.DeleteZone(id)
+}
+
+// checkZone validates the zone and checks that no other zone lists its
+// countries, or also covers the rest of the world
+func (s *Service) checkZone(z *shipping.Zone) error {
+	if err := z.Validate(); err != nil {
+		return err
+	}
+
+	zones, err := s.shippingRepo.ListZones()
+	if err != nil {
+		return err
+	}
+
+	for _, other := range zones {
+		if other.ID == z.ID {
+			continue
+		}
+		if len(z.Countries) == 0 && len(other.Countries) == 0 {
+			return shipping.ErrZoneOverlap
+		}
+		for _, country := range z.Countries {
+			if other.Covers(country) {
+				return shipping.ErrZoneOverlap
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) CreateMethod(zoneID string, input MethodInput) (*shipping.Method, error) {
+	if _, err := s.shippingRepo.GetZone(zoneID); err != nil {
+		return nil, err
+	}
+
+	m, err := s.buildMethod(input)
+	if err != nil {
+		return nil, err
+	}
+	m.ZoneID = zoneID
+
+	if err := s.shippingRepo.CreateMethod(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (s *Service) UpdateMethod(id string, input MethodInput) (*shipping.Method, error) {
+	existing, err := s.shippingRepo.GetMethod(id)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := s.buildMethod(input)
+	if err != nil {
+		return nil, err
+	}
+	m.ID = existing.ID
+	m.ZoneID = existing.ZoneID
+
+	if err := s.shippingRepo.UpdateMethod(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (s *Service) DeleteMethod(id string) error {
+	return s.shippingRepo.DeleteMethod(id)
+}
+
+func (s *Service) buildMethod(input MethodInput) (*shipping.Method, error) {
+	m := &shipping.Method{
+		Name:    strings.TrimSpace(input.Name),
+		Carrier: strings.TrimSpace(input.Carrier),
+		Type:    input.Type,
+		Active:  input.Active,
+	}
+
+	price, err := money.Parse(input.Price, s.config.Currency)
+	if err != nil {
+		return nil, err
+	}
+	m.Price = price
+
+	if input.PricePerKg != "" {
+		pricePerKg, err := money.Parse(input.PricePerKg, s.config.Currency)
+		if err != nil {
+			return nil, err
+		}
+		m.PricePerKg = &pricePerKg
+	}
+	if input.FreeOver != "" {
+		freeOver, err := money.Parse(input.FreeOver, s.config.Currency)
+		if err != nil {
+			return nil, err
+		}
+		m.FreeOver = &freeOver
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Quote returns the shipping options for a parcel, cheapest first, in the
+// currency of the subtotal. Carriers that fail to quote are left out.
+func (s *Service) Quote(req shipping.RateRequest) ([]shipping.Quote, error) {
+	currency := req.Subtotal.Currency
+
+	zones, err := s.shippingRepo.ListZones()
+	if err != nil {
+		return nil, err
+	}
+
+	var quotes []shipping.Quote
+	if zone := shipping.MatchZone(zones, req.Destination.Country); zone != nil {
+		for i := range zone.Methods {
+			m := &zone.Methods[i]
+			if !m.Active {
+				continue
+			}
+			if err := s.convert(m, currency); err != nil {
+				return nil, err
+			}
+			quotes = append(quotes, shipping.Quote{
+				ID:      m.ID,
+				Name:    m.Name,
+				Carrier: m.Carrier,
+				Amount:  m.Cost(req.WeightGrams, req.Subtotal),
+			})
+		}
+	}
+
+	for _, carrier := range s.carriers {
+		carrierQuotes, err := carrier.Rates(req)
+		if err != nil {
+			log.Printf("Warning: failed to get carrier shipping rates: %v", err)
+			continue
+		}
+		for _, q := range carrierQuotes {
+			amount, err := s.pricing.Convert(q.Amount, currency)
+			if err != nil {
+				log.Printf("Warning: failed to convert carrier shipping rate %s: %v", q.ID, err)
+				continue
+			}
+			q.Amount = amount
+			quotes = append(quotes, q)
+		}
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].Amount.Amount < quotes[j].Amount.Amount
+	})
+	return quotes, nil
+}
+
+// Choose returns the quote for the shipping option with the given ID
+func (s *Service) Choose(req shipping.RateRequest, id string) (*shipping.Quote, error) {
+	quotes, err := s.Quote(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range quotes {
+		if quotes[i].ID == id {
+			return &quotes[i], nil
+		}
+	}
+
+	return nil, shipping.ErrMethodUnavailable
+}
+
+// convert expresses the method prices in currency
+func (s *Service) convert(m *shipping.Method, currency string) error {
+	price, err := s.pricing.Convert(m.Price, currency)
+	if err != nil {
+		return err
+	}
+	m.Price = price
+
+	if m.PricePerKg != nil {
+		pricePerKg, err := s.pricing.Convert(*m.PricePerKg, currency)
+		if err != nil {
+			return err
+		}
+		m.PricePerKg = &pricePerKg
+	}
+	if m.FreeOver != nil {
+		freeOver, err := s.pricing.Convert(*m.FreeOver, currency)
+		if err != nil {
+			return err
+		}
+		m.FreeOver = &freeOver
+	}
+	return nil
+}
+
+// CreateShipment records a parcel sent for a paid order and marks the order
+// shipped. Partially refunded orders can still ship what was kept.
+func (s *Service) CreateShipment(orderID string, input ShipmentInput) (*shipping.Shipment, error) {
+	shipment := &shipping.Shipment{
+		OrderID:        orderID,
+		Carrier:        strings.TrimSpace(input.Carrier),
+		TrackingNumber: strings.TrimSpace(input.TrackingNumber),
+		Status:         shipping.ShipmentShipped,
+		ShippedAt:      time.Now(),
+	}
+	if shipment.Carrier == "" || shipment.TrackingNumber == "" {
+		return nil, shipping.ErrInvalidShipment
+	}
+
+	o, err := s.orderRepo.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != order.StatusPaid && o.Status != order.StatusShipped && o.Status != order.StatusPartiallyRefunded {
+		return nil, order.ErrNotShippable
+	}
+
+	if err := s.shipmentRepo.CreateShipment(shipment); err != nil {
+		return nil, err
+	}
+
+	// Any new shipment leaves the order shipped until it is delivered too
+	if err := s.orderRepo.SetFulfilment(orderID, order.FulfilmentShipped); err != nil {
+		return nil, err
+	}
+
+	// Refunds replace the status, so only a paid order moves to shipped; the
+	// fulfilment above records the shipment either way
+	if _, err := s.orderRepo.TransitionStatus(orderID, order.StatusPaid, order.StatusShipped); err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// MarkDelivered records the delivery of a shipment. Once every shipment of
+// the order is delivered, the order is marked delivered.
+func (s *Service) MarkDelivered(orderID, shipmentID string) (*shipping.Shipment, error) {
+	shipment, err := s.shipmentRepo.GetShipment(shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.OrderID != orderID {
+		return nil, shipping.ErrNotFound
+	}
+
+	// Only the first delivery is recorded, so a repeated request is a no-op
+	ok, err := s.shipmentRepo.MarkDelivered(shipmentID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return shipment, nil
+	}
+
+	shipments, err := s.shipmentRepo.ListShipments(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivered := true
+	for _, sh := range shipments {
+		if sh.Status != shipping.ShipmentDelivered {
+			delivered = false
+		}
+		if sh.ID == shipmentID {
+			shipment = sh
+		}
+	}
+
+	if delivered {
+		if err := s.orderRepo.SetFulfilment(orderID, order.FulfilmentDelivered); err != nil {
+			return nil, err
+		}
+		// As with shipping, a refunded order keeps its refund status
+		if _, err := s.orderRepo.TransitionStatus(orderID, order.StatusShipped, order.StatusDelivered); err != nil {
+			return nil, err
+		}
+	}
+
+	return shipment, nil
+}
+
+func (s *Service) ListShipments(orderID string) ([]*shipping.Shipment, error) {
+	if _, err := s.orderRepo.GetOrder(orderID); err != nil {
+		return nil, err
+	}
+
+	return s.shipmentRepo.ListShipments(orderID)
+}
+
+// ListShipmentsForUser returns the shipments of the order only if it belongs
+// to the user
+func (s *Service) ListShipmentsForUser(orderID, userID string) ([]*shipping.Shipment, error) {
+	o, err := s.orderRepo.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't reveal other customers' orders
+	if o.UserID != userID {
+		return nil, apperrors.ErrNotFound
+	}
+
+	return s.shipmentRepo.ListShipments(orderID)
+}
@@ -0,0 +1,10 @@
+package taxapp
+
+// SetRateInput represents the input for setting a tax rate
+type SetRateInput struct {
+	Country  string
+	Region   string // empty for the whole country
+	TaxClass string
+	Name     string
+	Percent  string // decimal percentage, e.g. "7.25"
+}
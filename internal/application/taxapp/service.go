@@ -0,0 +1,59 @@
+package taxapp
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/tax"
+)
+
+// Service handles tax rate management
+type Service struct {
+	rateRepo tax.RateRepository
+}
+
+// NewService creates a new tax application service
+func NewService(rateRepo tax.RateRepository) *Service {
+	return &Service{
+		rateRepo: rateRepo,
+	}
+}
+
+func (s *Service) ListRates() ([]*tax.Rate, error) {
+	return s.rateRepo.ListRates()
+}
+
+// SetRate creates or replaces the rate for a country, region and tax class
+func (s *Service) SetRate(input SetRateInput) (*tax.Rate, error) {
+	country := strings.ToUpper(strings.TrimSpace(input.Country))
+	class := strings.ToLower(strings.TrimSpace(input.TaxClass))
+	if len(country) != 2 || class == "" {
+		return nil, tax.ErrInvalidRate
+	}
+
+	percent, ok := new(big.Rat).SetString(strings.TrimSpace(input.Percent))
+	if !ok || percent.Sign() < 0 || percent.Cmp(big.NewRat(100, 1)) > 0 {
+		return nil, tax.ErrInvalidRate
+	}
+
+	rate := &tax.Rate{
+		Country:  country,
+		Region:   strings.TrimSpace(input.Region),
+		TaxClass: class,
+		Name:     strings.TrimSpace(input.Name),
+		Rate:     percent.Quo(percent, big.NewRat(100, 1)),
+	}
+	if rate.Name == "" {
+		rate.Name = "Tax"
+	}
+
+	if err := s.rateRepo.SetRate(rate); err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+func (s *Service) DeleteRate(id string) error {
+	return s.rateRepo.DeleteRate(id)
+}
@@ -2,6 +2,7 @@ package userapp
 
 import (
 	"github.com/RubenRodrigo/go-tiny-store/internal/domain/user"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
 )
 
 // Service handles user-related use cases
@@ -41,6 +42,20 @@ func (s *Service) GetByEmail(email string) (*user.User, error) {
 	return s.userRepo.GetUserByEmail(email)
 }
 
-func (s *Service) ListUsers() ([]*user.User, error) {
-	return s.userRepo.ListUsers()
+func (s *Service) ListUsers(params pagination.Params) (pagination.Result[*user.User], error) {
+	users, count, err := s.userRepo.ListUsers(params)
+	if err != nil {
+		return pagination.Result[*user.User]{}, err
+	}
+
+	return pagination.BuildPagedResult(params, count, users, userCursor), nil
+}
+
+// HasRole reports whether the user has been granted the role
+func (s *Service) HasRole(userID, role string) (bool, error) {
+	return s.userRepo.HasRole(userID, role)
+}
+
+func userCursor(u *user.User) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: u.CreatedAt, ID: u.ID}
 }
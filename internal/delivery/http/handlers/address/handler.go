@@ -0,0 +1,122 @@
+package address
+
+import (
+	"net/http"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/addressapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles address book requests
+type Handler struct {
+	addressService *addressapp.Service
+}
+
+// NewHandler creates a new address book handler
+func NewHandler(addressService *addressapp.Service) *Handler {
+	return &Handler{
+		addressService: addressService,
+	}
+}
+
+func toAddressInput(req AddressRequest) addressapp.AddressInput {
+	return addressapp.AddressInput{
+		Name:            req.Name,
+		Line1:           req.Line1,
+		Line2:           req.Line2,
+		City:            req.City,
+		Region:          req.Region,
+		PostalCode:      req.PostalCode,
+		Country:         req.Country,
+		Phone:           req.Phone,
+		DefaultShipping: req.DefaultShipping,
+		DefaultBilling:  req.DefaultBilling,
+	}
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	addresses, err := h.addressService.List(userID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, addresses)
+	return nil
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	address, err := h.addressService.Get(userID, mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, address)
+	return nil
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var req AddressRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	address, err := h.addressService.Create(userID, toAddressInput(req))
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, address)
+	return nil
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var req AddressRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	address, err := h.addressService.Update(userID, mux.Vars(r)["id"], toAddressInput(req))
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, address)
+	return nil
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	if err := h.addressService.Delete(userID, mux.Vars(r)["id"]); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
@@ -0,0 +1,14 @@
+package address
+
+type AddressRequest struct {
+	Name            string `json:"name" validate:"required,max=255"`
+	Line1           string `json:"line1" validate:"required,max=255"`
+	Line2           string `json:"line2" validate:"max=255"`
+	City            string `json:"city" validate:"required,max=100"`
+	Region          string `json:"region" validate:"max=100"`
+	PostalCode      string `json:"postal_code" validate:"max=20"`
+	Country         string `json:"country" validate:"required,min=2,max=2"`
+	Phone           string `json:"phone" validate:"max=32"`
+	DefaultShipping bool   `json:"default_shipping"`
+	DefaultBilling  bool   `json:"default_billing"`
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/authapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
 	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
 	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
 	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
@@ -34,6 +35,7 @@ func (h *Handler) SignUp(w http.ResponseWriter, r *http.Request) error {
 		Password:  req.Password,
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
+		CartToken: middleware.GetCartTokenFromContext(r.Context()),
 	}
 
 	user, err := h.authService.SignUp(dto)
@@ -63,8 +65,9 @@ func (h *Handler) SignIn(w http.ResponseWriter, r *http.Request) error {
 
 	// Convert HTTP request to application DTO
 	dto := authapp.SignInDTO{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		CartToken: middleware.GetCartTokenFromContext(r.Context()),
 	}
 
 	user, err := h.authService.SignIn(dto)
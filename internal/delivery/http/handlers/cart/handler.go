@@ -1,25 +1,201 @@
 package cart
 
-import "net/http"
+import (
+	"net/http"
+	"time"
 
-type Handler struct{}
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/cartapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/cart"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
 
-func NewHandler() *Handler {
-	return &Handler{}
+type Handler struct {
+	cartService *cartapp.Service
+}
+
+func NewHandler(cartService *cartapp.Service) *Handler {
+	return &Handler{
+		cartService: cartService,
+	}
+}
+
+type AddProductRequest struct {
+	ProductID string `json:"product_id" validate:"required"`
+	VariantID string `json:"variant_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+type ApplyDiscountRequest struct {
+	Code string `json:"code" validate:"required,max=64"`
+}
+
+type RestoreRequest struct {
+	Token string `json:"token" validate:"required,max=512"`
 }
 
 func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	cart, err := h.cartService.Get(owner(r), middleware.GetCurrencyFromContext(r.Context()))
+	if err != nil {
+		return err
+	}
+
+	respondWithCart(w, cart)
 	return nil
 }
 
 func (h *Handler) AddProduct(w http.ResponseWriter, r *http.Request) error {
+	var req AddProductRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	cart, err := h.cartService.AddItem(owner(r), middleware.GetCurrencyFromContext(r.Context()), cartapp.AddItemInput{
+		ProductID: req.ProductID,
+		VariantID: req.VariantID,
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		return err
+	}
+
+	respondWithCart(w, cart)
 	return nil
 }
 
 func (h *Handler) RemoveProduct(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["productId"]
+	variantID := r.URL.Query().Get("variant_id")
+
+	cart, err := h.cartService.RemoveItem(owner(r), productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	respondWithCart(w, cart)
 	return nil
 }
 
 func (h *Handler) Clear(w http.ResponseWriter, r *http.Request) error {
+	if err := h.cartService.Clear(owner(r)); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+// ApplyDiscount applies a discount code and returns the cart with its
+// line-by-line discounts
+func (h *Handler) ApplyDiscount(w http.ResponseWriter, r *http.Request) error {
+	var req ApplyDiscountRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	cart, err := h.cartService.ApplyDiscount(owner(r), middleware.GetCurrencyFromContext(r.Context()), req.Code)
+	if err != nil {
+		return err
+	}
+
+	respondWithCart(w, cart)
+	return nil
+}
+
+func (h *Handler) RemoveDiscount(w http.ResponseWriter, r *http.Request) error {
+	cart, err := h.cartService.RemoveDiscount(owner(r), middleware.GetCurrencyFromContext(r.Context()))
+	if err != nil {
+		return err
+	}
+
+	respondWithCart(w, cart)
 	return nil
 }
+
+// Restore restores the cart of the token in a reminder's restore link
+func (h *Handler) Restore(w http.ResponseWriter, r *http.Request) error {
+	var req RestoreRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	cart, err := h.cartService.Restore(owner(r), req.Token)
+	if err != nil {
+		return err
+	}
+
+	respondWithCart(w, cart)
+	return nil
+}
+
+// owner identifies the cart of the request: the signed-in user's cart, else
+// the guest cart of the cart token header
+func owner(r *http.Request) cartapp.Owner {
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	return cartapp.Owner{
+		UserID:    userID,
+		CartToken: middleware.GetCartTokenFromContext(r.Context()),
+	}
+}
+
+// respondWithCart writes the cart, echoing the token of a guest cart in the
+// cart token header so clients can keep it for later requests
+func respondWithCart(w http.ResponseWriter, c *cart.Cart) {
+	if c.Token != "" {
+		w.Header().Set(middleware.CartTokenHeader, c.Token)
+	}
+	httputil.RespondWithJSON(w, http.StatusOK, c)
+}
+
+// AbandonedReport sums the value of abandoned and recovered carts per period.
+// from and to are inclusive dates (YYYY-MM-DD, UTC) and default to the last 30
+// days; interval is day (the default), week or month.
+func (h *Handler) AbandonedReport(w http.ResponseWriter, r *http.Request) error {
+	filters, err := parseReportFilters(r)
+	if err != nil {
+		return err
+	}
+
+	report, err := h.cartService.AbandonedReport(filters)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, report)
+	return nil
+}
+
+func parseReportFilters(r *http.Request) (cart.ReportFilters, error) {
+	query := r.URL.Query()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	filters := cart.ReportFilters{
+		From:     today.AddDate(0, 0, -29),
+		To:       today.AddDate(0, 0, 1),
+		Interval: cart.IntervalDay,
+	}
+
+	if raw := query.Get("from"); raw != "" {
+		from, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return cart.ReportFilters{}, apperrors.ErrInvalidReportPeriod
+		}
+		filters.From = from
+	}
+	if raw := query.Get("to"); raw != "" {
+		to, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return cart.ReportFilters{}, apperrors.ErrInvalidReportPeriod
+		}
+		filters.To = to.AddDate(0, 0, 1)
+	}
+	if raw := query.Get("interval"); raw != "" {
+		filters.Interval = cart.Interval(raw)
+	}
+
+	return filters, nil
+}
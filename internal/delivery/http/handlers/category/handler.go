@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/categoryapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
 	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
 	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
 	"github.com/gorilla/mux"
@@ -20,11 +21,31 @@ func NewHandler(categoryService *categoryapp.Service) *Handler {
 }
 
 type CreateCategoryRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name            string `json:"name" validate:"required,max=100"`
+	Slug            string `json:"slug" validate:"max=120"`
+	ParentID        string `json:"parent_id"`
+	MetaTitle       string `json:"meta_title" validate:"max=255"`
+	MetaDescription string `json:"meta_description" validate:"max=500"`
+	CanonicalURL    string `json:"canonical_url" validate:"max=500"`
 }
 
 type UpdateCategoryRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name            string `json:"name" validate:"required,max=100"`
+	Slug            string `json:"slug" validate:"max=120"`
+	ParentID        string `json:"parent_id"`
+	MetaTitle       string `json:"meta_title" validate:"max=255"`
+	MetaDescription string `json:"meta_description" validate:"max=500"`
+	CanonicalURL    string `json:"canonical_url" validate:"max=500"`
+}
+
+type CreateAttributeRequest struct {
+	Key      string   `json:"key" validate:"required,max=100"`
+	Label    string   `json:"label" validate:"required,max=100"`
+	Type     string   `json:"type" validate:"required"`
+	Unit     string   `json:"unit" validate:"max=20"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+	Position int      `json:"position"`
 }
 
 func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
@@ -37,6 +58,30 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// Tree returns the public category tree with product counts
+func (h *Handler) Tree(w http.ResponseWriter, r *http.Request) error {
+	tree, err := h.categoryService.Tree()
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, tree)
+	return nil
+}
+
+func (h *Handler) GetBySlug(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	slug := params["slug"]
+
+	category, err := h.categoryService.GetBySlug(slug)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, category)
+	return nil
+}
+
 func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
 	params := mux.Vars(r)
 	id := params["id"]
@@ -56,7 +101,16 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	category, err := h.categoryService.Create(req.Name)
+	category, err := h.categoryService.Create(categoryapp.CategoryInput{
+		Name:     req.Name,
+		Slug:     req.Slug,
+		ParentID: req.ParentID,
+		SEO: seo.Metadata{
+			MetaTitle:       req.MetaTitle,
+			MetaDescription: req.MetaDescription,
+			CanonicalURL:    req.CanonicalURL,
+		},
+	})
 	if err != nil {
 		return err
 	}
@@ -74,7 +128,16 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	category, err := h.categoryService.Update(id, req.Name)
+	category, err := h.categoryService.Update(id, categoryapp.CategoryInput{
+		Name:     req.Name,
+		Slug:     req.Slug,
+		ParentID: req.ParentID,
+		SEO: seo.Metadata{
+			MetaTitle:       req.MetaTitle,
+			MetaDescription: req.MetaDescription,
+			CanonicalURL:    req.CanonicalURL,
+		},
+	})
 	if err != nil {
 		return err
 	}
@@ -87,7 +150,63 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
 	params := mux.Vars(r)
 	id := params["id"]
 
-	if err := h.categoryService.Delete(id); err != nil {
+	reassignTo := r.URL.Query().Get("reassign_to")
+
+	result, err := h.categoryService.Delete(id, reassignTo)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
+	return nil
+}
+
+// ListAttributes returns the attribute schema for products of the category
+func (h *Handler) ListAttributes(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	id := params["id"]
+
+	attributes, err := h.categoryService.Attributes(id)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, attributes)
+	return nil
+}
+
+func (h *Handler) CreateAttribute(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	id := params["id"]
+
+	var req CreateAttributeRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	attribute, err := h.categoryService.CreateAttribute(id, categoryapp.CreateAttributeInput{
+		Key:      req.Key,
+		Label:    req.Label,
+		Type:     req.Type,
+		Unit:     req.Unit,
+		Options:  req.Options,
+		Required: req.Required,
+		Position: req.Position,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, attribute)
+	return nil
+}
+
+func (h *Handler) DeleteAttribute(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	id := params["id"]
+	attributeID := params["attributeId"]
+
+	if err := h.categoryService.DeleteAttribute(id, attributeID); err != nil {
 		return err
 	}
 
@@ -1,13 +1,73 @@
 package checkout
 
-import "net/http"
+import (
+	"net/http"
 
-type Handler struct{}
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/cartapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/checkoutapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+)
 
-func NewHandler() *Handler {
-	return &Handler{}
+type Handler struct {
+	checkoutService *checkoutapp.Service
+}
+
+func NewHandler(checkoutService *checkoutapp.Service) *Handler {
+	return &Handler{
+		checkoutService: checkoutService,
+	}
 }
 
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	var req CreateRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	result, err := h.checkoutService.Create(owner(r), checkoutapp.CreateInput{
+		ShippingAddress:   (*checkoutapp.AddressInput)(req.ShippingAddress),
+		ShippingAddressID: req.ShippingAddressID,
+		BillingAddress:    (*checkoutapp.AddressInput)(req.BillingAddress),
+		BillingAddressID:  req.BillingAddressID,
+		ShippingMethodID:  req.ShippingMethodID,
+		Email:             req.Email,
+		GiftCardCodes:     req.GiftCardCodes,
+		UseStoreCredit:    req.UseStoreCredit,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, result)
 	return nil
 }
+
+func (h *Handler) ShippingRates(w http.ResponseWriter, r *http.Request) error {
+	var req ShippingRatesRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	quotes, err := h.checkoutService.ShippingRates(owner(r), checkoutapp.ShippingRatesInput{
+		ShippingAddress:   (*checkoutapp.AddressInput)(req.ShippingAddress),
+		ShippingAddressID: req.ShippingAddressID,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, quotes)
+	return nil
+}
+
+// owner identifies the cart being checked out: the signed-in user's cart, else
+// the guest cart of the cart token header
+func owner(r *http.Request) cartapp.Owner {
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	return cartapp.Owner{
+		UserID:    userID,
+		CartToken: middleware.GetCartTokenFromContext(r.Context()),
+	}
+}
@@ -0,0 +1,28 @@
+package checkout
+
+type AddressRequest struct {
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	Phone      string `json:"phone"`
+}
+
+type ShippingRatesRequest struct {
+	ShippingAddress   *AddressRequest `json:"shipping_address"`
+	ShippingAddressID string          `json:"shipping_address_id"`
+}
+
+type CreateRequest struct {
+	ShippingAddress   *AddressRequest `json:"shipping_address"`
+	ShippingAddressID string          `json:"shipping_address_id"`
+	BillingAddress    *AddressRequest `json:"billing_address"`
+	BillingAddressID  string          `json:"billing_address_id"`
+	ShippingMethodID  string          `json:"shipping_method_id" validate:"required"`
+	Email             string          `json:"email" validate:"omitempty,email,max=255"` // required for guests
+	GiftCardCodes     []string        `json:"gift_card_codes" validate:"max=10,dive,max=32"`
+	UseStoreCredit    bool            `json:"use_store_credit"`
+}
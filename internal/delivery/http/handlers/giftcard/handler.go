@@ -0,0 +1,94 @@
+package giftcard
+
+import (
+	"net/http"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/giftcardapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles gift card and store credit HTTP requests
+type Handler struct {
+	giftCardService *giftcardapp.Service
+}
+
+// NewHandler creates a new gift card handler
+func NewHandler(giftCardService *giftcardapp.Service) *Handler {
+	return &Handler{
+		giftCardService: giftCardService,
+	}
+}
+
+func (h *Handler) Balance(w http.ResponseWriter, r *http.Request) error {
+	balance, err := h.giftCardService.Balance(mux.Vars(r)["code"])
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, balance)
+	return nil
+}
+
+func (h *Handler) MyStoreCredit(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	credit, err := h.giftCardService.StoreCredit(userID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, credit)
+	return nil
+}
+
+func (h *Handler) Issue(w http.ResponseWriter, r *http.Request) error {
+	actorID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var req IssueRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	card, err := h.giftCardService.Issue(giftcardapp.IssueInput{
+		Amount:   req.Amount,
+		Currency: req.Currency,
+		Email:    req.Email,
+	}, actorID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, card)
+	return nil
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	card, err := h.giftCardService.Get(mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, card)
+	return nil
+}
+
+// IssueForOrder issues the gift cards of a paid order that are still
+// missing, e.g. after a failure when the payment came in
+func (h *Handler) IssueForOrder(w http.ResponseWriter, r *http.Request) error {
+	cards, err := h.giftCardService.IssueForOrder(mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, cards)
+	return nil
+}
@@ -0,0 +1,7 @@
+package giftcard
+
+type IssueRequest struct {
+	Amount   string `json:"amount" validate:"required"`
+	Currency string `json:"currency" validate:"required,len=3"`
+	Email    string `json:"email" validate:"omitempty,email,max=255"` // where the code is sent
+}
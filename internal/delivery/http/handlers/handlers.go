@@ -1,30 +1,66 @@
 package handlers
 
 import (
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/addressapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/authapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/cartapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/categoryapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/checkoutapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/giftcardapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/inventoryapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/invoiceapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/orderapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/pricingapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/productapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/promotionapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/recommendationapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/reviewapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/rmaapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/shippingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/taxapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/userapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/address"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/auth"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/cart"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/category"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/checkout"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/giftcard"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/inventory"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/invoice"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/order"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/pricing"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/product"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/promotion"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/recommendation"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/review"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/rma"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/shipping"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/tax"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/user"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers/webhook"
 )
 
 // Handlers contains all HTTP handlers organized by feature
 type Handlers struct {
-	Auth     *auth.Handler
-	User     *user.Handler
-	Category *category.Handler
-	Product  *product.Handler
-	Order    *order.Handler
-	Cart     *cart.Handler
-	Checkout *checkout.Handler
-	Webhook  *webhook.Handler
+	Auth           *auth.Handler
+	User           *user.Handler
+	Category       *category.Handler
+	Product        *product.Handler
+	Order          *order.Handler
+	Cart           *cart.Handler
+	Checkout       *checkout.Handler
+	Webhook        *webhook.Handler
+	Inventory      *inventory.Handler
+	Pricing        *pricing.Handler
+	Review         *review.Handler
+	Recommendation *recommendation.Handler
+	Promotion      *promotion.Handler
+	Tax            *tax.Handler
+	Shipping       *shipping.Handler
+	Address        *address.Handler
+	Return         *rma.Handler
+	Invoice        *invoice.Handler
+	GiftCard       *giftcard.Handler
 }
 
 // NewHandlers creates all handlers with their dependencies
@@ -33,15 +69,40 @@ func NewHandlers(
 	userService *userapp.Service,
 	categoryService *categoryapp.Service,
 	productService *productapp.Service,
+	cartService *cartapp.Service,
+	orderService *orderapp.Service,
+	inventoryService *inventoryapp.Service,
+	checkoutService *checkoutapp.Service,
+	pricingService *pricingapp.Service,
+	reviewService *reviewapp.Service,
+	recommendationService *recommendationapp.Service,
+	promotionService *promotionapp.Service,
+	taxService *taxapp.Service,
+	shippingService *shippingapp.Service,
+	addressService *addressapp.Service,
+	rmaService *rmaapp.Service,
+	invoiceService *invoiceapp.Service,
+	giftCardService *giftcardapp.Service,
 ) *Handlers {
 	return &Handlers{
-		Auth:     auth.NewHandler(authService),
-		User:     user.NewHandler(userService),
-		Category: category.NewHandler(categoryService),
-		Product:  product.NewHandler(productService),
-		Order:    order.NewHandler(),
-		Cart:     cart.NewHandler(),
-		Checkout: checkout.NewHandler(),
-		Webhook:  webhook.NewHandler(),
+		Auth:           auth.NewHandler(authService),
+		User:           user.NewHandler(userService),
+		Category:       category.NewHandler(categoryService),
+		Product:        product.NewHandler(productService),
+		Order:          order.NewHandler(orderService),
+		Cart:           cart.NewHandler(cartService),
+		Checkout:       checkout.NewHandler(checkoutService),
+		Webhook:        webhook.NewHandler(checkoutService),
+		Inventory:      inventory.NewHandler(inventoryService),
+		Pricing:        pricing.NewHandler(pricingService),
+		Review:         review.NewHandler(reviewService),
+		Recommendation: recommendation.NewHandler(recommendationService),
+		Promotion:      promotion.NewHandler(promotionService),
+		Tax:            tax.NewHandler(taxService),
+		Shipping:       shipping.NewHandler(shippingService),
+		Address:        address.NewHandler(addressService),
+		Return:         rma.NewHandler(rmaService),
+		Invoice:        invoice.NewHandler(invoiceService),
+		GiftCard:       giftcard.NewHandler(giftCardService),
 	}
 }
@@ -0,0 +1,132 @@
+package inventory
+
+import (
+	"net/http"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/inventoryapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	domaininventory "github.com/RubenRodrigo/go-tiny-store/internal/domain/inventory"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+)
+
+// Handler handles stock ledger HTTP requests
+type Handler struct {
+	inventoryService *inventoryapp.Service
+}
+
+// NewHandler creates a new inventory handler
+func NewHandler(inventoryService *inventoryapp.Service) *Handler {
+	return &Handler{
+		inventoryService: inventoryService,
+	}
+}
+
+type RecordMovementRequest struct {
+	ProductID string `json:"product_id" validate:"required"`
+	VariantID string `json:"variant_id"`
+	Type      string `json:"type" validate:"required"`
+	Quantity  int    `json:"quantity"`
+	Reason    string `json:"reason" validate:"max=255"`
+	Reference string `json:"reference" validate:"max=64"`
+}
+
+type ReconcileRequest struct {
+	ProductID string `json:"product_id" validate:"required"`
+	VariantID string `json:"variant_id"`
+}
+
+type SetThresholdRequest struct {
+	ProductID string `json:"product_id" validate:"required"`
+	VariantID string `json:"variant_id"`
+	Threshold *int   `json:"threshold"`
+}
+
+func (h *Handler) RecordMovement(w http.ResponseWriter, r *http.Request) error {
+	actorID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var req RecordMovementRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	movement, err := h.inventoryService.RecordAdjustment(actorID, inventoryapp.AdjustmentInput{
+		ProductID: req.ProductID,
+		VariantID: req.VariantID,
+		Type:      domaininventory.MovementType(req.Type),
+		Quantity:  req.Quantity,
+		Reason:    req.Reason,
+		Reference: req.Reference,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, movement)
+	return nil
+}
+
+func (h *Handler) ListMovements(w http.ResponseWriter, r *http.Request) error {
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
+
+	query := r.URL.Query()
+	filters := domaininventory.Filters{
+		ProductID: query.Get("product_id"),
+		VariantID: query.Get("variant_id"),
+		Type:      domaininventory.MovementType(query.Get("type")),
+	}
+
+	result, err := h.inventoryService.ListMovements(paginationParams, filters)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
+	return nil
+}
+
+func (h *Handler) Reconcile(w http.ResponseWriter, r *http.Request) error {
+	var req ReconcileRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	result, err := h.inventoryService.Reconcile(req.ProductID, req.VariantID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
+	return nil
+}
+
+func (h *Handler) SetThreshold(w http.ResponseWriter, r *http.Request) error {
+	var req SetThresholdRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	if err := h.inventoryService.SetThreshold(req.ProductID, req.VariantID, req.Threshold); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+func (h *Handler) ListLowStock(w http.ResponseWriter, r *http.Request) error {
+	items, err := h.inventoryService.ListLowStock()
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, items)
+	return nil
+}
@@ -0,0 +1,107 @@
+package invoice
+
+import (
+	"net/http"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/invoiceapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	domaininvoice "github.com/RubenRodrigo/go-tiny-store/internal/domain/invoice"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles invoice and credit note HTTP requests
+type Handler struct {
+	invoiceService *invoiceapp.Service
+}
+
+// NewHandler creates a new invoice handler
+func NewHandler(invoiceService *invoiceapp.Service) *Handler {
+	return &Handler{
+		invoiceService: invoiceService,
+	}
+}
+
+func (h *Handler) ListMyDocuments(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	docs, err := h.invoiceService.ListDocumentsForUser(mux.Vars(r)["id"], userID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, docs)
+	return nil
+}
+
+func (h *Handler) MyInvoice(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	doc, data, err := h.invoiceService.InvoicePDFForUser(mux.Vars(r)["id"], userID)
+	if err != nil {
+		return err
+	}
+
+	respondWithPDF(w, doc, data)
+	return nil
+}
+
+func (h *Handler) MyCreditNote(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	params := mux.Vars(r)
+	doc, data, err := h.invoiceService.CreditNotePDFForUser(params["id"], params["creditNoteId"], userID)
+	if err != nil {
+		return err
+	}
+
+	respondWithPDF(w, doc, data)
+	return nil
+}
+
+func (h *Handler) ListDocuments(w http.ResponseWriter, r *http.Request) error {
+	docs, err := h.invoiceService.ListDocuments(mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, docs)
+	return nil
+}
+
+func (h *Handler) Invoice(w http.ResponseWriter, r *http.Request) error {
+	doc, data, err := h.invoiceService.InvoicePDF(mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	respondWithPDF(w, doc, data)
+	return nil
+}
+
+func (h *Handler) CreditNote(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	doc, data, err := h.invoiceService.CreditNotePDF(params["id"], params["creditNoteId"])
+	if err != nil {
+		return err
+	}
+
+	respondWithPDF(w, doc, data)
+	return nil
+}
+
+func respondWithPDF(w http.ResponseWriter, doc *domaininvoice.Document, data []byte) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+doc.Number+`.pdf"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -1,21 +1,89 @@
 package order
 
-import "net/http"
+import (
+	"net/http"
 
-type Handler struct{}
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/orderapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	domainorder "github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"github.com/gorilla/mux"
+)
 
-func NewHandler() *Handler {
-	return &Handler{}
+type Handler struct {
+	orderService *orderapp.Service
+}
+
+func NewHandler(orderService *orderapp.Service) *Handler {
+	return &Handler{
+		orderService: orderService,
+	}
 }
 
 func (h *Handler) ListMyOrders(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.orderService.ListForUser(userID, paginationParams)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
 	return nil
 }
 
 func (h *Handler) ListAllOrders(w http.ResponseWriter, r *http.Request) error {
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
+	status := domainorder.Status(r.URL.Query().Get("status"))
+
+	result, err := h.orderService.List(paginationParams, status)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
 	return nil
 }
 
 func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	params := mux.Vars(r)
+	id := params["id"]
+
+	order, err := h.orderService.GetForUser(id, userID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, order)
+	return nil
+}
+
+func (h *Handler) ManagerGet(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	id := params["id"]
+
+	order, err := h.orderService.Get(id)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, order)
 	return nil
 }
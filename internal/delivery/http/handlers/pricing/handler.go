@@ -0,0 +1,190 @@
+package pricing
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/pricingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/pricing"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles price list, exchange rate and currency preference requests
+type Handler struct {
+	pricingService *pricingapp.Service
+}
+
+// NewHandler creates a new pricing handler
+func NewHandler(pricingService *pricingapp.Service) *Handler {
+	return &Handler{
+		pricingService: pricingService,
+	}
+}
+
+// exchangeRateResponse renders the rate as a decimal string
+type exchangeRateResponse struct {
+	Base      string    `json:"base"`
+	Quote     string    `json:"quote"`
+	Rate      string    `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func toExchangeRateResponse(rate *pricing.ExchangeRate) exchangeRateResponse {
+	return exchangeRateResponse{
+		Base:      rate.Base,
+		Quote:     rate.Quote,
+		Rate:      rate.Rate.FloatString(10),
+		UpdatedAt: rate.UpdatedAt,
+	}
+}
+
+func (h *Handler) CreatePriceList(w http.ResponseWriter, r *http.Request) error {
+	var req CreatePriceListRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	priceList, err := h.pricingService.CreatePriceList(pricingapp.CreatePriceListInput{
+		Name:          req.Name,
+		Currency:      req.Currency,
+		CustomerGroup: req.CustomerGroup,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, priceList)
+	return nil
+}
+
+func (h *Handler) ListPriceLists(w http.ResponseWriter, r *http.Request) error {
+	priceLists, err := h.pricingService.ListPriceLists()
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, priceLists)
+	return nil
+}
+
+func (h *Handler) GetPriceList(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	id := params["id"]
+
+	priceList, err := h.pricingService.GetPriceList(id)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, priceList)
+	return nil
+}
+
+func (h *Handler) SetPrice(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	priceListID := params["id"]
+
+	var req SetPriceRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	price, err := h.pricingService.SetPrice(priceListID, pricingapp.SetPriceInput{
+		ProductID: req.ProductID,
+		VariantID: req.VariantID,
+		Amount:    req.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, price)
+	return nil
+}
+
+func (h *Handler) DeletePrice(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	priceListID := params["id"]
+	productID := params["productId"]
+	variantID := r.URL.Query().Get("variant_id")
+
+	if err := h.pricingService.DeletePrice(priceListID, productID, variantID); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+func (h *Handler) ListRates(w http.ResponseWriter, r *http.Request) error {
+	rates, err := h.pricingService.ListRates()
+	if err != nil {
+		return err
+	}
+
+	response := make([]exchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		response[i] = toExchangeRateResponse(rate)
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, response)
+	return nil
+}
+
+func (h *Handler) SetRate(w http.ResponseWriter, r *http.Request) error {
+	var req SetRateRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	rate, err := h.pricingService.SetRate(pricingapp.SetRateInput{
+		Base:  req.Base,
+		Quote: req.Quote,
+		Rate:  req.Rate,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toExchangeRateResponse(rate))
+	return nil
+}
+
+func (h *Handler) SetMyCurrency(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var req SetCurrencyRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	if err := h.pricingService.SetPreferredCurrency(userID, req.Currency); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+func (h *Handler) SetCustomerGroup(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	userID := params["id"]
+
+	var req SetCustomerGroupRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	if err := h.pricingService.SetCustomerGroup(userID, req.CustomerGroup); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
@@ -0,0 +1,27 @@
+package pricing
+
+type CreatePriceListRequest struct {
+	Name          string `json:"name" validate:"required,max=100"`
+	Currency      string `json:"currency" validate:"required,min=3,max=3"`
+	CustomerGroup string `json:"customer_group" validate:"max=64"`
+}
+
+type SetPriceRequest struct {
+	ProductID string `json:"product_id" validate:"required"`
+	VariantID string `json:"variant_id"`
+	Amount    string `json:"amount" validate:"required"`
+}
+
+type SetRateRequest struct {
+	Base  string `json:"base" validate:"required,min=3,max=3"`
+	Quote string `json:"quote" validate:"required,min=3,max=3"`
+	Rate  string `json:"rate" validate:"required"`
+}
+
+type SetCurrencyRequest struct {
+	Currency string `json:"currency" validate:"required,min=3,max=3"`
+}
+
+type SetCustomerGroupRequest struct {
+	CustomerGroup string `json:"customer_group" validate:"max=64"`
+}
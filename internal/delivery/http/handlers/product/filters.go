@@ -2,7 +2,10 @@ package product
 
 import (
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/productapp"
 )
@@ -16,19 +19,35 @@ func ParseFilters(r *http.Request) productapp.ProductFilters {
 		filters.CategoryID = categoryID
 	}
 
-	// Price range filters
-	if minPriceStr := r.URL.Query().Get("min_price"); minPriceStr != "" {
-		if minPrice, err := strconv.ParseFloat(minPriceStr, 64); err == nil {
-			filters.MinPrice = &minPrice
-		}
+	// Price range filters (decimal strings, validated by the service)
+	if minPrice := r.URL.Query().Get("min_price"); minPrice != "" {
+		filters.MinPrice = &minPrice
+	}
+
+	if maxPrice := r.URL.Query().Get("max_price"); maxPrice != "" {
+		filters.MaxPrice = &maxPrice
 	}
 
-	if maxPriceStr := r.URL.Query().Get("max_price"); maxPriceStr != "" {
-		if maxPrice, err := strconv.ParseFloat(maxPriceStr, 64); err == nil {
-			filters.MaxPrice = &maxPrice
+	// Minimum average rating filter
+	if minRatingStr := r.URL.Query().Get("min_rating"); minRatingStr != "" {
+		if minRating, err := strconv.ParseFloat(minRatingStr, 64); err == nil {
+			filters.MinRating = &minRating
 		}
 	}
 
+	// Brand filter
+	if brand := r.URL.Query().Get("brand"); brand != "" {
+		filters.Brand = brand
+	}
+
+	// Specification filters: attr.<key>=value, attr.<key>.min and attr.<key>.max
+	filters.Attributes = parseAttributeFilters(r.URL.Query())
+
+	// Sort order
+	if sort := r.URL.Query().Get("sort"); sort != "" {
+		filters.Sort = sort
+	}
+
 	// Disabled filter
 	if disabledStr := r.URL.Query().Get("disabled"); disabledStr != "" {
 		if disabled, err := strconv.ParseBool(disabledStr); err == nil {
@@ -38,3 +57,61 @@ func ParseFilters(r *http.Request) productapp.ProductFilters {
 
 	return filters
 }
+
+const attributeFilterPrefix = "attr."
+
+// parseAttributeFilters collects attr.* query parameters into one filter per
+// attribute key; unparsable range bounds are ignored
+func parseAttributeFilters(query url.Values) []productapp.AttributeFilter {
+	byKey := make(map[string]*productapp.AttributeFilter)
+	var keys []string
+
+	for param, values := range query {
+		if !strings.HasPrefix(param, attributeFilterPrefix) || len(values) == 0 || values[0] == "" {
+			continue
+		}
+
+		key := strings.TrimPrefix(param, attributeFilterPrefix)
+		bound := ""
+		if k, b, ok := strings.Cut(key, "."); ok {
+			key, bound = k, b
+		}
+		if key == "" {
+			continue
+		}
+
+		f, ok := byKey[key]
+		if !ok {
+			f = &productapp.AttributeFilter{Key: key}
+			byKey[key] = f
+			keys = append(keys, key)
+		}
+
+		value := values[0]
+		switch bound {
+		case "":
+			f.Value = &value
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			if bound == "min" {
+				f.Min = &n
+			} else {
+				f.Max = &n
+			}
+		}
+	}
+
+	// Map iteration order is random; keep the generated SQL stable
+	sort.Strings(keys)
+	filters := make([]productapp.AttributeFilter, 0, len(keys))
+	for _, key := range keys {
+		f := byKey[key]
+		if f.Value != nil || f.Min != nil || f.Max != nil {
+			filters = append(filters, *f)
+		}
+	}
+	return filters
+}
@@ -2,10 +2,15 @@ package product
 
 import (
 	"net/http"
+	"net/url"
 
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/productapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
 	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
 	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
@@ -22,15 +27,52 @@ func NewHandler(productService *productapp.Service) *Handler {
 }
 
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
-	// TODO: Implement product creation
+	var req CreateProductRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	product, err := h.productService.Create(productapp.CreateProductInput{
+		SKU:        req.SKU,
+		Name:       req.Name,
+		Price:      req.Price,
+		Sale:       saleInput(req.SalePrice, req.SaleStartsAt, req.SaleEndsAt),
+		Stock:      req.Stock,
+		CategoryID: req.CategoryID,
+		Slug:       req.Slug,
+		SEO: seo.Metadata{
+			MetaTitle:       req.MetaTitle,
+			MetaDescription: req.MetaDescription,
+			CanonicalURL:    req.CanonicalURL,
+		},
+		Details: productapp.ProductDetails{
+			Description:    req.Description,
+			Brand:          req.Brand,
+			TaxClass:       req.TaxClass,
+			WeightGrams:    req.WeightGrams,
+			LengthMM:       req.LengthMM,
+			WidthMM:        req.WidthMM,
+			HeightMM:       req.HeightMM,
+			GiftCard:       req.GiftCard,
+			Specifications: req.Specifications,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, product)
 	return nil
 }
 
 func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
-	paginationParams := pagination.ParseParams(r)
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
 	filters := ParseFilters(r)
 
-	result, err := h.productService.List(paginationParams, filters)
+	result, err := h.productService.List(paginationParams, filters, middleware.GetCurrencyFromContext(r.Context()))
 	if err != nil {
 		return err
 	}
@@ -39,15 +81,38 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// Get returns a product by ID or slug. Historical slugs redirect permanently
+// to the product's current slug.
 func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
 	params := mux.Vars(r)
-	id := params["id"]
+	ref := params["id"]
+	currency := middleware.GetCurrencyFromContext(r.Context())
+
+	if _, err := uuid.Parse(ref); err == nil {
+		product, err := h.productService.Get(ref, currency)
+		if err != nil {
+			return err
+		}
 
-	product, err := h.productService.Get(id)
+		httputil.RespondWithJSON(w, http.StatusOK, product)
+		return nil
+	}
+
+	product, current, err := h.productService.GetBySlug(ref, currency)
 	if err != nil {
 		return err
 	}
 
+	if !current {
+		// A relative target keeps the /products/ prefix of the request path
+		target := url.PathEscape(product.Slug)
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+		return nil
+	}
+
 	httputil.RespondWithJSON(w, http.StatusOK, product)
 	return nil
 }
@@ -56,11 +121,14 @@ func (h *Handler) GetByCategory(w http.ResponseWriter, r *http.Request) error {
 	params := mux.Vars(r)
 	categoryID := params["categoryId"]
 
-	paginationParams := pagination.ParseParams(r)
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
 	filters := ParseFilters(r)
 	filters.CategoryID = categoryID
 
-	result, err := h.productService.List(paginationParams, filters)
+	result, err := h.productService.List(paginationParams, filters, middleware.GetCurrencyFromContext(r.Context()))
 	if err != nil {
 		return err
 	}
@@ -70,7 +138,43 @@ func (h *Handler) GetByCategory(w http.ResponseWriter, r *http.Request) error {
 }
 
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
-	// TODO: Implement product update
+	params := mux.Vars(r)
+	id := params["id"]
+
+	var req UpdateProductRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	product, err := h.productService.Update(id, productapp.UpdateProductInput{
+		SKU:      req.SKU,
+		Name:     req.Name,
+		Price:    req.Price,
+		Sale:     saleInput(req.SalePrice, req.SaleStartsAt, req.SaleEndsAt),
+		Disabled: req.Disabled,
+		Slug:     req.Slug,
+		SEO: seo.Metadata{
+			MetaTitle:       req.MetaTitle,
+			MetaDescription: req.MetaDescription,
+			CanonicalURL:    req.CanonicalURL,
+		},
+		Details: productapp.ProductDetails{
+			Description:    req.Description,
+			Brand:          req.Brand,
+			TaxClass:       req.TaxClass,
+			WeightGrams:    req.WeightGrams,
+			LengthMM:       req.LengthMM,
+			WidthMM:        req.WidthMM,
+			HeightMM:       req.HeightMM,
+			GiftCard:       req.GiftCard,
+			Specifications: req.Specifications,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, product)
 	return nil
 }
 
@@ -85,7 +189,56 @@ func (h *Handler) Disable(w http.ResponseWriter, r *http.Request) error {
 }
 
 func (h *Handler) Like(w http.ResponseWriter, r *http.Request) error {
-	// TODO: Implement product like
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	params := mux.Vars(r)
+	id := params["id"]
+
+	if err := h.productService.Like(userID, id); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+func (h *Handler) Unlike(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	params := mux.Vars(r)
+	id := params["id"]
+
+	if err := h.productService.Unlike(userID, id); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+func (h *Handler) ListMyLikes(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.productService.ListLikes(userID, paginationParams, middleware.GetCurrencyFromContext(r.Context()))
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
 	return nil
 }
 
@@ -93,3 +246,105 @@ func (h *Handler) UploadImage(w http.ResponseWriter, r *http.Request) error {
 	// TODO: Implement image upload
 	return nil
 }
+
+func (h *Handler) AddOption(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+
+	var req CreateOptionTypeRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	optionType, err := h.productService.AddOptionType(productID, productapp.CreateOptionTypeInput{
+		Name:   req.Name,
+		Values: req.Values,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, optionType)
+	return nil
+}
+
+func (h *Handler) CreateVariant(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+
+	var req CreateVariantRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	variant, err := h.productService.CreateVariant(productID, productapp.CreateVariantInput{
+		SKU:            req.SKU,
+		Price:          req.Price,
+		Stock:          req.Stock,
+		OptionValueIDs: req.OptionValueIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, variant)
+	return nil
+}
+
+func (h *Handler) UpdateVariant(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+	variantID := params["variantId"]
+
+	var req UpdateVariantRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	variant, err := h.productService.UpdateVariant(productID, variantID, productapp.UpdateVariantInput{
+		SKU:      req.SKU,
+		Price:    req.Price,
+		Disabled: req.Disabled,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, variant)
+	return nil
+}
+
+func (h *Handler) DeleteVariant(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+	variantID := params["variantId"]
+
+	if err := h.productService.DeleteVariant(productID, variantID); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+func (h *Handler) UploadVariantImage(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+	variantID := params["variantId"]
+
+	var req AddImageRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	image, err := h.productService.AddVariantImage(productID, variantID, productapp.AddImageInput{
+		URL:     req.URL,
+		AltText: req.AltText,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, image)
+	return nil
+}
@@ -0,0 +1,129 @@
+package product
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/productapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/importjob"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/gorilla/mux"
+)
+
+var exportContentTypes = map[importjob.Format]string{
+	importjob.FormatCSV:    "text/csv; charset=utf-8",
+	importjob.FormatNDJSON: "application/x-ndjson",
+}
+
+// Import accepts a CSV or NDJSON file as the raw request body
+// (?format=csv|ndjson, default csv; ?dry_run=true only validates). It responds
+// 200 with the finished job, or 202 when the file was queued for the worker.
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	format := importjob.Format(r.URL.Query().Get("format"))
+	if format == "" {
+		format = importjob.FormatCSV
+	}
+
+	dryRun := false
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			return apperrors.ErrRequestInvalidBody
+		}
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.productService.ImportMaxBytes()))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return apperrors.ErrImportTooLarge
+		}
+		return apperrors.ErrRequestInvalidBody
+	}
+
+	job, err := h.productService.Import(productapp.ImportInput{
+		Format:  format,
+		DryRun:  dryRun,
+		Payload: payload,
+		UserID:  userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	status := http.StatusOK
+	if !job.Finished() {
+		status = http.StatusAccepted
+	}
+	httputil.RespondWithJSON(w, status, job)
+	return nil
+}
+
+// GetImportJob returns the progress and row errors of an import
+func (h *Handler) GetImportJob(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	jobID := params["jobId"]
+
+	job, err := h.productService.GetImportJob(jobID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, job)
+	return nil
+}
+
+// Export streams the filtered product list in the import format
+// (?format=csv|ndjson, default csv) using the same filters as List
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) error {
+	format := importjob.Format(r.URL.Query().Get("format"))
+	if format == "" {
+		format = importjob.FormatCSV
+	}
+
+	out := &exportWriter{w: w, format: format}
+	if err := h.productService.Export(ParseFilters(r), format, out); err != nil {
+		if !out.started {
+			return err
+		}
+		// The status line is already sent, so the truncated body is all we can do
+		log.Printf("ERROR: Product export stopped mid-stream. Error: %v", err)
+	}
+
+	if !out.started {
+		out.start()
+	}
+	return nil
+}
+
+// exportWriter sends the response headers on the first write, so errors found
+// before any output can still be reported as JSON
+type exportWriter struct {
+	w       http.ResponseWriter
+	format  importjob.Format
+	started bool
+}
+
+func (e *exportWriter) start() {
+	e.started = true
+	e.w.Header().Set("Content-Type", exportContentTypes[e.format])
+	e.w.Header().Set("Content-Disposition", `attachment; filename="products.`+string(e.format)+`"`)
+	e.w.WriteHeader(http.StatusOK)
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	if !e.started {
+		e.start()
+	}
+	return e.w.Write(p)
+}
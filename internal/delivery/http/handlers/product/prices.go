@@ -0,0 +1,85 @@
+package product
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/productapp"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
+
+// saleInput builds the sale of a product request; no sale price means no sale
+func saleInput(price *string, startsAt, endsAt *time.Time) *productapp.SaleInput {
+	if price == nil {
+		return nil
+	}
+	return &productapp.SaleInput{Price: *price, StartsAt: startsAt, EndsAt: endsAt}
+}
+
+func (h *Handler) SchedulePrice(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+
+	var req SchedulePriceRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	schedule, err := h.productService.SchedulePrice(productID, productapp.SchedulePriceInput{
+		Price:       req.Price,
+		EffectiveAt: req.EffectiveAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, schedule)
+	return nil
+}
+
+func (h *Handler) ListPriceSchedules(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+
+	schedules, err := h.productService.ListPriceSchedules(productID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, schedules)
+	return nil
+}
+
+func (h *Handler) CancelPriceSchedule(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+	scheduleID := params["scheduleId"]
+
+	if err := h.productService.CancelPriceSchedule(productID, scheduleID); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+func (h *Handler) PriceHistory(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.productService.PriceHistory(productID, paginationParams)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
+	return nil
+}
@@ -0,0 +1,78 @@
+package product
+
+import "time"
+
+type CreateOptionTypeRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Values []string `json:"values"`
+}
+
+type CreateVariantRequest struct {
+	SKU            string   `json:"sku" validate:"required,max=64"`
+	Price          *string  `json:"price"`
+	Stock          int      `json:"stock"`
+	OptionValueIDs []string `json:"option_value_ids"`
+}
+
+type UpdateVariantRequest struct {
+	SKU      string  `json:"sku" validate:"required,max=64"`
+	Price    *string `json:"price"`
+	Disabled bool    `json:"disabled"`
+}
+
+type AddImageRequest struct {
+	URL     string `json:"url" validate:"required,max=500"`
+	AltText string `json:"alt_text" validate:"max=255"`
+}
+
+type CreateProductRequest struct {
+	SKU             string            `json:"sku" validate:"max=64"`
+	Name            string            `json:"name" validate:"required,max=255"`
+	Price           string            `json:"price" validate:"required"`
+	SalePrice       *string           `json:"sale_price"`
+	SaleStartsAt    *time.Time        `json:"sale_starts_at"`
+	SaleEndsAt      *time.Time        `json:"sale_ends_at"`
+	Stock           int               `json:"stock"`
+	CategoryID      string            `json:"category_id" validate:"required"`
+	Slug            string            `json:"slug" validate:"max=280"`
+	MetaTitle       string            `json:"meta_title" validate:"max=255"`
+	MetaDescription string            `json:"meta_description" validate:"max=500"`
+	CanonicalURL    string            `json:"canonical_url" validate:"max=500"`
+	Description     string            `json:"description" validate:"max=20000"`
+	Brand           string            `json:"brand" validate:"max=100"`
+	TaxClass        string            `json:"tax_class" validate:"max=32"`
+	WeightGrams     int               `json:"weight_grams"`
+	LengthMM        int               `json:"length_mm"`
+	WidthMM         int               `json:"width_mm"`
+	HeightMM        int               `json:"height_mm"`
+	GiftCard        bool              `json:"gift_card"`
+	Specifications  map[string]string `json:"specifications"`
+}
+
+type UpdateProductRequest struct {
+	SKU             string            `json:"sku" validate:"max=64"`
+	Name            string            `json:"name" validate:"required,max=255"`
+	Price           string            `json:"price" validate:"required"`
+	SalePrice       *string           `json:"sale_price"`
+	SaleStartsAt    *time.Time        `json:"sale_starts_at"`
+	SaleEndsAt      *time.Time        `json:"sale_ends_at"`
+	Disabled        bool              `json:"disabled"`
+	Slug            string            `json:"slug" validate:"max=280"`
+	MetaTitle       string            `json:"meta_title" validate:"max=255"`
+	MetaDescription string            `json:"meta_description" validate:"max=500"`
+	CanonicalURL    string            `json:"canonical_url" validate:"max=500"`
+	Description     string            `json:"description" validate:"max=20000"`
+	Brand           string            `json:"brand" validate:"max=100"`
+	TaxClass        string            `json:"tax_class" validate:"max=32"`
+	WeightGrams     int               `json:"weight_grams"`
+	LengthMM        int               `json:"length_mm"`
+	WidthMM         int               `json:"width_mm"`
+	HeightMM        int               `json:"height_mm"`
+	GiftCard        bool              `json:"gift_card"`
+	Specifications  map[string]string `json:"specifications"`
+}
+
+type SchedulePriceRequest struct {
+	Price       string    `json:"price" validate:"required"`
+	EffectiveAt time.Time `json:"effective_at"`
+}
@@ -0,0 +1,44 @@
+package product
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+)
+
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// Sitemap serves the XML sitemap of enabled products
+func (h *Handler) Sitemap(w http.ResponseWriter, r *http.Request) error {
+	urls, err := h.productService.Sitemap()
+	if err != nil {
+		return err
+	}
+
+	set := sitemapURLSet{Xmlns: sitemapNamespace, URLs: make([]sitemapURL, len(urls))}
+	for i, u := range urls {
+		set.URLs[i] = sitemapURL{Loc: u.Loc, LastMod: u.LastMod.UTC().Format(time.RFC3339)}
+	}
+
+	body, err := xml.Marshal(set)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	w.Write(body)
+	return nil
+}
@@ -0,0 +1,116 @@
+package promotion
+
+import (
+	"net/http"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/promotionapp"
+	domainpromotion "github.com/RubenRodrigo/go-tiny-store/internal/domain/promotion"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles discount code and promotion management requests
+type Handler struct {
+	promotionService *promotionapp.Service
+}
+
+// NewHandler creates a new promotion handler
+func NewHandler(promotionService *promotionapp.Service) *Handler {
+	return &Handler{
+		promotionService: promotionService,
+	}
+}
+
+func toPromotionInput(req PromotionRequest) promotionapp.PromotionInput {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return promotionapp.PromotionInput{
+		Name:             req.Name,
+		Code:             req.Code,
+		Type:             domainpromotion.Type(req.Type),
+		Percent:          req.Percent,
+		Amount:           req.Amount,
+		BuyQuantity:      req.BuyQuantity,
+		GetQuantity:      req.GetQuantity,
+		MinOrderValue:    req.MinOrderValue,
+		UsageLimit:       req.UsageLimit,
+		PerCustomerLimit: req.PerCustomerLimit,
+		ProductIDs:       req.ProductIDs,
+		CategoryIDs:      req.CategoryIDs,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		Active:           active,
+	}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	var req PromotionRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	promotion, err := h.promotionService.CreatePromotion(toPromotionInput(req))
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, promotion)
+	return nil
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	promotions, err := h.promotionService.ListPromotions()
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, promotions)
+	return nil
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	id := params["id"]
+
+	promotion, err := h.promotionService.GetPromotion(id)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, promotion)
+	return nil
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	id := params["id"]
+
+	var req PromotionRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	promotion, err := h.promotionService.UpdatePromotion(id, toPromotionInput(req))
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, promotion)
+	return nil
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	id := params["id"]
+
+	if err := h.promotionService.DeletePromotion(id); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
@@ -0,0 +1,21 @@
+package promotion
+
+import "time"
+
+type PromotionRequest struct {
+	Name             string     `json:"name" validate:"required,max=255"`
+	Code             string     `json:"code" validate:"max=64"`
+	Type             string     `json:"type" validate:"required"`
+	Percent          int        `json:"percent"`
+	Amount           string     `json:"amount"`
+	BuyQuantity      int        `json:"buy_quantity"`
+	GetQuantity      int        `json:"get_quantity"`
+	MinOrderValue    string     `json:"min_order_value"`
+	UsageLimit       int        `json:"usage_limit"`
+	PerCustomerLimit int        `json:"per_customer_limit"`
+	ProductIDs       []string   `json:"product_ids"`
+	CategoryIDs      []string   `json:"category_ids"`
+	StartsAt         *time.Time `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at"`
+	Active           *bool      `json:"active"` // defaults to true
+}
@@ -0,0 +1,103 @@
+package recommendation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/recommendationapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	domainrecommendation "github.com/RubenRodrigo/go-tiny-store/internal/domain/recommendation"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles recommendation HTTP requests
+type Handler struct {
+	recommendationService *recommendationapp.Service
+}
+
+// NewHandler creates a new recommendation handler
+func NewHandler(recommendationService *recommendationapp.Service) *Handler {
+	return &Handler{
+		recommendationService: recommendationService,
+	}
+}
+
+type CreateLinkRequest struct {
+	TargetID string `json:"target_id" validate:"required"`
+	Type     string `json:"type" validate:"required"`
+	Position int    `json:"position"`
+}
+
+// List returns a product's recommendations (?limit=, default 10)
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return apperrors.ErrRequestInvalidBody
+		}
+		limit = n
+	}
+
+	recs, err := h.recommendationService.Recommendations(productID, middleware.GetCurrencyFromContext(r.Context()), limit)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, recs)
+	return nil
+}
+
+func (h *Handler) ListLinks(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+
+	links, err := h.recommendationService.ListLinks(productID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, links)
+	return nil
+}
+
+func (h *Handler) CreateLink(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+
+	var req CreateLinkRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	link, err := h.recommendationService.CreateLink(productID, recommendationapp.CreateLinkInput{
+		TargetID: req.TargetID,
+		Type:     domainrecommendation.LinkType(req.Type),
+		Position: req.Position,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, link)
+	return nil
+}
+
+func (h *Handler) DeleteLink(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+	linkID := params["linkId"]
+
+	if err := h.recommendationService.DeleteLink(productID, linkID); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
@@ -0,0 +1,141 @@
+package review
+
+import (
+	"net/http"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/reviewapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	domainreview "github.com/RubenRodrigo/go-tiny-store/internal/domain/review"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles review HTTP requests
+type Handler struct {
+	reviewService *reviewapp.Service
+}
+
+// NewHandler creates a new review handler
+func NewHandler(reviewService *reviewapp.Service) *Handler {
+	return &Handler{
+		reviewService: reviewService,
+	}
+}
+
+type CreateReviewRequest struct {
+	OrderID string `json:"order_id" validate:"required"`
+	Rating  int    `json:"rating"`
+	Title   string `json:"title" validate:"max=200"`
+	Body    string `json:"body" validate:"max=5000"`
+}
+
+func (h *Handler) ListForProduct(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+	productID := params["id"]
+
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.reviewService.ListForProduct(productID, paginationParams)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
+	return nil
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	params := mux.Vars(r)
+	productID := params["id"]
+
+	var req CreateReviewRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	review, err := h.reviewService.Create(userID, productID, reviewapp.CreateReviewInput{
+		OrderID: req.OrderID,
+		Rating:  req.Rating,
+		Title:   req.Title,
+		Body:    req.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, review)
+	return nil
+}
+
+func (h *Handler) MarkHelpful(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	params := mux.Vars(r)
+	id := params["id"]
+
+	if err := h.reviewService.MarkHelpful(id, userID); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+func (h *Handler) ListForModeration(w http.ResponseWriter, r *http.Request) error {
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
+	status := domainreview.Status(r.URL.Query().Get("status"))
+
+	result, err := h.reviewService.ListForModeration(paginationParams, status)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
+	return nil
+}
+
+func (h *Handler) Approve(w http.ResponseWriter, r *http.Request) error {
+	return h.moderate(w, r, h.reviewService.Approve)
+}
+
+func (h *Handler) Reject(w http.ResponseWriter, r *http.Request) error {
+	return h.moderate(w, r, h.reviewService.Reject)
+}
+
+func (h *Handler) Hide(w http.ResponseWriter, r *http.Request) error {
+	return h.moderate(w, r, h.reviewService.Hide)
+}
+
+func (h *Handler) moderate(w http.ResponseWriter, r *http.Request, action func(id, moderatorID string) (*domainreview.Review, error)) error {
+	moderatorID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	params := mux.Vars(r)
+	id := params["id"]
+
+	review, err := action(id, moderatorID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, review)
+	return nil
+}
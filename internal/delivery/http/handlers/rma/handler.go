@@ -0,0 +1,214 @@
+package rma
+
+import (
+	"net/http"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/rmaapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	domainrma "github.com/RubenRodrigo/go-tiny-store/internal/domain/rma"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles return and refund HTTP requests
+type Handler struct {
+	rmaService *rmaapp.Service
+}
+
+// NewHandler creates a new return handler
+func NewHandler(rmaService *rmaapp.Service) *Handler {
+	return &Handler{
+		rmaService: rmaService,
+	}
+}
+
+func (h *Handler) Request(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var req ReturnRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	lines := make([]rmaapp.ReturnLineInput, len(req.Lines))
+	for i, l := range req.Lines {
+		lines[i] = rmaapp.ReturnLineInput{
+			OrderLineID: l.OrderLineID,
+			Quantity:    l.Quantity,
+			Reason:      domainrma.Reason(l.Reason),
+		}
+	}
+
+	ret, err := h.rmaService.Request(userID, mux.Vars(r)["id"], rmaapp.RequestReturnInput{
+		Comment: req.Comment,
+		Lines:   lines,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, ret)
+	return nil
+}
+
+func (h *Handler) ListMine(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.rmaService.ListForUser(userID, paginationParams)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
+	return nil
+}
+
+func (h *Handler) GetMine(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	ret, err := h.rmaService.GetForUser(mux.Vars(r)["id"], userID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, ret)
+	return nil
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
+	status := domainrma.Status(r.URL.Query().Get("status"))
+
+	result, err := h.rmaService.List(paginationParams, status)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, result)
+	return nil
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	ret, err := h.rmaService.Get(mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, ret)
+	return nil
+}
+
+func (h *Handler) Approve(w http.ResponseWriter, r *http.Request) error {
+	return h.resolve(w, r, h.rmaService.Approve)
+}
+
+func (h *Handler) Reject(w http.ResponseWriter, r *http.Request) error {
+	return h.resolve(w, r, h.rmaService.Reject)
+}
+
+func (h *Handler) resolve(w http.ResponseWriter, r *http.Request, action func(id, resolution string) (*domainrma.Return, error)) error {
+	var req ResolveRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	ret, err := action(mux.Vars(r)["id"], req.Resolution)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, ret)
+	return nil
+}
+
+func (h *Handler) Receive(w http.ResponseWriter, r *http.Request) error {
+	actorID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	ret, err := h.rmaService.Receive(mux.Vars(r)["id"], actorID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, ret)
+	return nil
+}
+
+func (h *Handler) Refund(w http.ResponseWriter, r *http.Request) error {
+	actorID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var req RefundRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	ret, err := h.rmaService.Refund(mux.Vars(r)["id"], actorID, rmaapp.RefundInput{
+		Amount:      req.Amount,
+		Reason:      req.Reason,
+		StoreCredit: req.StoreCredit,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, ret)
+	return nil
+}
+
+func (h *Handler) ListRefunds(w http.ResponseWriter, r *http.Request) error {
+	refunds, err := h.rmaService.ListRefunds(mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, refunds)
+	return nil
+}
+
+func (h *Handler) RefundOrder(w http.ResponseWriter, r *http.Request) error {
+	actorID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	var req RefundRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	refund, err := h.rmaService.RefundOrder(mux.Vars(r)["id"], actorID, rmaapp.RefundInput{
+		Amount:      req.Amount,
+		Reason:      req.Reason,
+		StoreCredit: req.StoreCredit,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, refund)
+	return nil
+}
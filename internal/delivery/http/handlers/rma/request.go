@@ -0,0 +1,22 @@
+package rma
+
+type ReturnRequest struct {
+	Comment string              `json:"comment" validate:"max=2000"`
+	Lines   []ReturnLineRequest `json:"lines" validate:"required"`
+}
+
+type ReturnLineRequest struct {
+	OrderLineID string `json:"order_line_id"`
+	Quantity    int    `json:"quantity"`
+	Reason      string `json:"reason"`
+}
+
+type ResolveRequest struct {
+	Resolution string `json:"resolution" validate:"max=2000"`
+}
+
+type RefundRequest struct {
+	Amount      string `json:"amount"` // defaults to the value of the returned lines, or what is left to refund
+	Reason      string `json:"reason" validate:"max=255"`
+	StoreCredit bool   `json:"store_credit"` // issue the whole refund as store credit instead of paying the card back
+}
@@ -0,0 +1,200 @@
+package shipping
+
+import (
+	"net/http"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/shippingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
+	domainshipping "github.com/RubenRodrigo/go-tiny-store/internal/domain/shipping"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles shipping zone, method and shipment requests
+type Handler struct {
+	shippingService *shippingapp.Service
+}
+
+// NewHandler creates a new shipping handler
+func NewHandler(shippingService *shippingapp.Service) *Handler {
+	return &Handler{
+		shippingService: shippingService,
+	}
+}
+
+func (h *Handler) ListZones(w http.ResponseWriter, r *http.Request) error {
+	zones, err := h.shippingService.ListZones()
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, zones)
+	return nil
+}
+
+func (h *Handler) GetZone(w http.ResponseWriter, r *http.Request) error {
+	zone, err := h.shippingService.GetZone(mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, zone)
+	return nil
+}
+
+func (h *Handler) CreateZone(w http.ResponseWriter, r *http.Request) error {
+	var req ZoneRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	zone, err := h.shippingService.CreateZone(shippingapp.ZoneInput{
+		Name:      req.Name,
+		Countries: req.Countries,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, zone)
+	return nil
+}
+
+func (h *Handler) UpdateZone(w http.ResponseWriter, r *http.Request) error {
+	var req ZoneRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	zone, err := h.shippingService.UpdateZone(mux.Vars(r)["id"], shippingapp.ZoneInput{
+		Name:      req.Name,
+		Countries: req.Countries,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, zone)
+	return nil
+}
+
+func (h *Handler) DeleteZone(w http.ResponseWriter, r *http.Request) error {
+	if err := h.shippingService.DeleteZone(mux.Vars(r)["id"]); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+func toMethodInput(req MethodRequest) shippingapp.MethodInput {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return shippingapp.MethodInput{
+		Name:       req.Name,
+		Carrier:    req.Carrier,
+		Type:       domainshipping.MethodType(req.Type),
+		Price:      req.Price,
+		PricePerKg: req.PricePerKg,
+		FreeOver:   req.FreeOver,
+		Active:     active,
+	}
+}
+
+func (h *Handler) CreateMethod(w http.ResponseWriter, r *http.Request) error {
+	var req MethodRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	method, err := h.shippingService.CreateMethod(mux.Vars(r)["id"], toMethodInput(req))
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, method)
+	return nil
+}
+
+func (h *Handler) UpdateMethod(w http.ResponseWriter, r *http.Request) error {
+	var req MethodRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	method, err := h.shippingService.UpdateMethod(mux.Vars(r)["id"], toMethodInput(req))
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, method)
+	return nil
+}
+
+func (h *Handler) DeleteMethod(w http.ResponseWriter, r *http.Request) error {
+	if err := h.shippingService.DeleteMethod(mux.Vars(r)["id"]); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
+
+func (h *Handler) ListMyShipments(w http.ResponseWriter, r *http.Request) error {
+	userID, err := middleware.GetUserIDFromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	shipments, err := h.shippingService.ListShipmentsForUser(mux.Vars(r)["id"], userID)
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, shipments)
+	return nil
+}
+
+func (h *Handler) ListShipments(w http.ResponseWriter, r *http.Request) error {
+	shipments, err := h.shippingService.ListShipments(mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, shipments)
+	return nil
+}
+
+func (h *Handler) CreateShipment(w http.ResponseWriter, r *http.Request) error {
+	var req ShipmentRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	shipment, err := h.shippingService.CreateShipment(mux.Vars(r)["id"], shippingapp.ShipmentInput{
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, shipment)
+	return nil
+}
+
+func (h *Handler) MarkDelivered(w http.ResponseWriter, r *http.Request) error {
+	params := mux.Vars(r)
+
+	shipment, err := h.shippingService.MarkDelivered(params["id"], params["shipmentId"])
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, shipment)
+	return nil
+}
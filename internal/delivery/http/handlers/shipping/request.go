@@ -0,0 +1,21 @@
+package shipping
+
+type ZoneRequest struct {
+	Name      string   `json:"name" validate:"required,max=255"`
+	Countries []string `json:"countries"` // empty for the rest of the world
+}
+
+type MethodRequest struct {
+	Name       string `json:"name" validate:"required,max=255"`
+	Carrier    string `json:"carrier" validate:"max=100"`
+	Type       string `json:"type" validate:"required"`
+	Price      string `json:"price" validate:"required"`
+	PricePerKg string `json:"price_per_kg"`
+	FreeOver   string `json:"free_over"`
+	Active     *bool  `json:"active"` // defaults to true
+}
+
+type ShipmentRequest struct {
+	Carrier        string `json:"carrier" validate:"required,max=100"`
+	TrackingNumber string `json:"tracking_number" validate:"required,max=255"`
+}
@@ -0,0 +1,92 @@
+package tax
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/taxapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/tax"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/validation"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles tax rate requests
+type Handler struct {
+	taxService *taxapp.Service
+}
+
+// NewHandler creates a new tax handler
+func NewHandler(taxService *taxapp.Service) *Handler {
+	return &Handler{
+		taxService: taxService,
+	}
+}
+
+// rateResponse renders the rate as a percentage string
+type rateResponse struct {
+	ID        string    `json:"id"`
+	Country   string    `json:"country"`
+	Region    string    `json:"region"`
+	TaxClass  string    `json:"tax_class"`
+	Name      string    `json:"name"`
+	Rate      string    `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func toRateResponse(rate *tax.Rate) rateResponse {
+	return rateResponse{
+		ID:        rate.ID,
+		Country:   rate.Country,
+		Region:    rate.Region,
+		TaxClass:  rate.TaxClass,
+		Name:      rate.Name,
+		Rate:      tax.FormatPercent(rate.Rate),
+		UpdatedAt: rate.UpdatedAt,
+	}
+}
+
+func (h *Handler) ListRates(w http.ResponseWriter, r *http.Request) error {
+	rates, err := h.taxService.ListRates()
+	if err != nil {
+		return err
+	}
+
+	response := make([]rateResponse, len(rates))
+	for i, rate := range rates {
+		response[i] = toRateResponse(rate)
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, response)
+	return nil
+}
+
+func (h *Handler) SetRate(w http.ResponseWriter, r *http.Request) error {
+	var req SetRateRequest
+	if err := validation.DecodeAndValidate(r, &req); err != nil {
+		return err
+	}
+
+	rate, err := h.taxService.SetRate(taxapp.SetRateInput{
+		Country:  req.Country,
+		Region:   req.Region,
+		TaxClass: req.TaxClass,
+		Name:     req.Name,
+		Percent:  req.Rate,
+	})
+	if err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, toRateResponse(rate))
+	return nil
+}
+
+func (h *Handler) DeleteRate(w http.ResponseWriter, r *http.Request) error {
+	if err := h.taxService.DeleteRate(mux.Vars(r)["id"]); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusNoContent, nil)
+	return nil
+}
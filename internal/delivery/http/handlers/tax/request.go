@@ -0,0 +1,9 @@
+package tax
+
+type SetRateRequest struct {
+	Country  string `json:"country" validate:"required,min=2,max=2"`
+	Region   string `json:"region" validate:"max=100"`
+	TaxClass string `json:"tax_class" validate:"required,max=32"`
+	Name     string `json:"name" validate:"max=100"`
+	Rate     string `json:"rate" validate:"required"`
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/userapp"
 	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
 	"github.com/gorilla/mux"
 )
 
@@ -32,7 +33,12 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) error {
 }
 
 func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) error {
-	users, err := h.userService.ListUsers()
+	paginationParams, err := pagination.ParseParams(r)
+	if err != nil {
+		return err
+	}
+
+	users, err := h.userService.ListUsers(paginationParams)
 	if err != nil {
 		return err
 	}
@@ -1,13 +1,37 @@
 package webhook
 
-import "net/http"
+import (
+	"io"
+	"net/http"
 
-type Handler struct{}
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/checkoutapp"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+	httputil "github.com/RubenRodrigo/go-tiny-store/pkg/httputils"
+)
 
-func NewHandler() *Handler {
-	return &Handler{}
+// maxWebhookBody caps the payload read from the provider
+const maxWebhookBody = 1 << 16
+
+type Handler struct {
+	checkoutService *checkoutapp.Service
+}
+
+func NewHandler(checkoutService *checkoutapp.Service) *Handler {
+	return &Handler{
+		checkoutService: checkoutService,
+	}
 }
 
 func (h *Handler) WebhookStripe(w http.ResponseWriter, r *http.Request) error {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		return apperrors.ErrRequestInvalidBody
+	}
+
+	if err := h.checkoutService.HandlePaymentWebhook(payload, r.Header.Get("Stripe-Signature")); err != nil {
+		return err
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, map[string]bool{"received": true})
 	return nil
 }
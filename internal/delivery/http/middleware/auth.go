@@ -40,3 +40,20 @@ func AuthMiddleware(tokenService auth.TokenService) func(http.Handler) http.Hand
 		})
 	}
 }
+
+// OptionalAuthMiddleware authenticates requests that carry an Authorization
+// header like AuthMiddleware, and lets requests without one through anonymously
+func OptionalAuthMiddleware(tokenService auth.TokenService) func(http.Handler) http.Handler {
+	authenticate := AuthMiddleware(tokenService)
+	return func(next http.Handler) http.Handler {
+		authenticated := authenticate(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			authenticated.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// CartTokenHeader carries the token of a guest cart, both ways
+const CartTokenHeader = "X-Cart-Token"
+
+// CartTokenMiddleware stores the guest cart token, if any, in the request context
+func CartTokenMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := strings.TrimSpace(r.Header.Get(CartTokenHeader)); token != "" {
+			ctx := context.WithValue(r.Context(), "cartToken", token)
+			r = r.WithContext(ctx)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GetCartTokenFromContext returns the guest cart token, or "" when the client
+// did not send one
+func GetCartTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value("cartToken").(string)
+	return token
+}
@@ -2,33 +2,23 @@ package middleware
 
 import (
 	"context"
-	"strconv"
 
 	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
 )
 
-// GetUserIDFromContext extracts user ID from request context
-func GetUserIDFromContext(ctx context.Context) (uint, error) {
+// GetUserIDFromContext extracts user ID (a UUID) from request context
+func GetUserIDFromContext(ctx context.Context) (string, error) {
 	userID := ctx.Value("userID")
 	if userID == nil {
-		return 0, apperrors.ErrAuthUnauthorized
+		return "", apperrors.ErrAuthUnauthorized
 	}
 
-	// Handle different types that might be stored
-	switch v := userID.(type) {
-	case string:
-		id, err := strconv.ParseUint(v, 10, 32)
-		if err != nil {
-			return 0, apperrors.ErrAuthTokenInvalid
-		}
-		return uint(id), nil
-	case uint:
-		return v, nil
-	case float64: // JWT claims are often float64
-		return uint(v), nil
-	default:
-		return 0, apperrors.ErrAuthTokenInvalid
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		return "", apperrors.ErrAuthTokenInvalid
 	}
+
+	return userIDStr, nil
 }
 
 // GetEmailFromContext extracts email from request context
@@ -63,7 +53,7 @@ func GetUsernameFromContext(ctx context.Context) (string, error) {
 
 // AuthUser represents the authenticated user info
 type AuthUser struct {
-	ID       uint   `json:"id"`
+	ID       string `json:"id"`
 	Email    string `json:"email"`
 	Username string `json:"username"`
 }
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// CurrencyHeader lets clients ask for prices in a specific currency
+const CurrencyHeader = "X-Currency"
+
+// CurrencyMiddleware stores the requested currency, if any, in the request context
+func CurrencyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if currency := strings.TrimSpace(r.Header.Get(CurrencyHeader)); currency != "" {
+			ctx := context.WithValue(r.Context(), "currency", strings.ToUpper(currency))
+			r = r.WithContext(ctx)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GetCurrencyFromContext returns the requested currency, or "" when the
+// client did not ask for one
+func GetCurrencyFromContext(ctx context.Context) string {
+	currency, _ := ctx.Value("currency").(string)
+	return currency
+}
@@ -0,0 +1,229 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/idempotency"
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+// IdempotencyKeyHeader lets clients retry a mutating request without
+// repeating its effect
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyOptions configures IdempotencyMiddleware
+type IdempotencyOptions struct {
+	// TTL is how long a response is kept for retries
+	TTL time.Duration
+	// LockTTL bounds how long an in-flight request holds its key, so the key
+	// frees up again if the server stops before responding. The lock is
+	// extended while the request runs, and should be longer than the server
+	// takes to time out a request.
+	LockTTL time.Duration
+	// MaxBody is the largest request body read to fingerprint a request
+	MaxBody int64
+}
+
+// IdempotencyMiddleware makes POST, PUT, PATCH and DELETE requests that carry
+// an Idempotency-Key header safe to retry. The first request runs and its
+// response is kept for the TTL; retries with the same key and request get
+// that response again. A retry while the first request is still running fails
+// with 409, and reusing the key for a different request fails with 422. Keys
+// are scoped to the signed-in user, else to the guest cart token; a guest
+// without a cart yet, such as on the first item added, has nothing to scope
+// the key to, so the key is scoped to the request itself instead.
+// Bodies over MaxBody fail with 413. Server errors are not kept, so the
+// request can be retried.
+func IdempotencyMiddleware(store idempotency.Store, opts IdempotencyOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || !mutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > 255 {
+				HandleError(w, r, apperrors.ErrInvalidIdempotencyKey)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, opts.MaxBody))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					HandleError(w, r, apperrors.ErrRequestTooLarge)
+					return
+				}
+				HandleError(w, r, apperrors.ErrRequestInvalidBody)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fp := fingerprint(r, body)
+			now := time.Now()
+			record := &idempotency.Record{
+				Key:         idempotencyScope(r, key, fp),
+				Fingerprint: fp,
+				ExpiresAt:   now.Add(opts.LockTTL),
+				CreatedAt:   now,
+			}
+
+			held, claimed, err := store.Begin(record)
+			if err != nil {
+				HandleError(w, r, err)
+				return
+			}
+			if !claimed {
+				switch {
+				case held.Fingerprint != record.Fingerprint:
+					HandleError(w, r, apperrors.ErrIdempotencyKeyReused)
+				case held.InFlight():
+					HandleError(w, r, apperrors.ErrIdempotencyKeyInFlight)
+				default:
+					replay(w, held)
+				}
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w}
+			completed := false
+			defer func() {
+				// The request panicked or failed on the server; let a retry run it again
+				if !completed {
+					if err := store.Release(record.Key); err != nil {
+						log.Printf("Warning: failed to release idempotency key: %v", err)
+					}
+				}
+			}()
+
+			func() {
+				defer holdKey(store, record.Key, opts.LockTTL)()
+				next.ServeHTTP(rec, r)
+			}()
+
+			if rec.status() >= http.StatusInternalServerError {
+				return
+			}
+
+			record.Status = rec.status()
+			record.Header = rec.Header().Clone()
+			record.Body = rec.body.Bytes()
+			record.ExpiresAt = time.Now().Add(opts.TTL)
+			if err := store.Complete(record); err != nil {
+				log.Printf("Warning: failed to store idempotent response: %v", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// holdKey keeps extending the lock on the key until the returned function is
+// called, so a slow request does not lose its key to a retry
+func holdKey(store idempotency.Store, key string, lockTTL time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(lockTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := store.Extend(key, time.Now().Add(lockTTL)); err != nil {
+					log.Printf("Warning: failed to extend idempotency key: %v", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// idempotencyScope hashes the client key together with the caller, so
+// different callers cannot see each other's responses. A guest without a cart
+// token cannot be told apart from other guests, so the key is hashed with the
+// request fingerprint instead: a retry of the same request still finds its
+// response, and only a caller holding the same key sees it.
+func idempotencyScope(r *http.Request, key, fp string) string {
+	var scope string
+	if userID, err := GetUserIDFromContext(r.Context()); err == nil {
+		scope = "user:" + userID
+	} else if token := GetCartTokenFromContext(r.Context()); token != "" {
+		scope = "guest:" + token
+	} else {
+		scope = "anonymous:" + fp
+	}
+
+	sum := sha256.Sum256([]byte(scope + "\n" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// fingerprint hashes what makes a request the same request
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replay writes a stored response
+func replay(w http.ResponseWriter, record *idempotency.Record) {
+	for name, values := range record.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(record.Status)
+	w.Write(record.Body)
+}
+
+// responseRecorder passes a response through while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	code int
+	body bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(code int) {
+	if rec.code == 0 {
+		rec.code = code
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.code == 0 {
+		rec.code = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+func (rec *responseRecorder) status() int {
+	if rec.code == 0 {
+		return http.StatusOK
+	}
+	return rec.code
+}
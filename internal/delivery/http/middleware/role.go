@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+// RoleChecker reports whether a user has been granted a role
+type RoleChecker interface {
+	HasRole(userID, role string) (bool, error)
+}
+
+// RequireRole only lets through users that have the role. It must run after
+// AuthMiddleware, which puts the user in the request context.
+func RequireRole(checker RoleChecker, role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, err := GetUserIDFromContext(r.Context())
+			if err != nil {
+				HandleError(w, r, err)
+				return
+			}
+
+			ok, err := checker.HasRole(userID, role)
+			if err != nil {
+				HandleError(w, r, err)
+				return
+			}
+			if !ok {
+				HandleError(w, r, apperrors.ErrInsufficientPermissions)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
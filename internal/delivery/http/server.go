@@ -4,41 +4,82 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/addressapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/authapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/cartapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/categoryapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/checkoutapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/giftcardapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/inventoryapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/invoiceapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/orderapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/pricingapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/productapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/promotionapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/recommendationapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/reviewapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/rmaapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/shippingapp"
+	"github.com/RubenRodrigo/go-tiny-store/internal/application/taxapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/application/userapp"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/handlers"
 	"github.com/RubenRodrigo/go-tiny-store/internal/delivery/http/middleware"
 	"github.com/RubenRodrigo/go-tiny-store/internal/domain/auth"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/idempotency"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/user"
 	"github.com/RubenRodrigo/go-tiny-store/internal/infrastructure/config"
 	"github.com/gorilla/mux"
 )
 
 // Services contains all application services
 type Services struct {
-	User     *userapp.Service
-	Auth     *authapp.Service
-	Category *categoryapp.Service
-	Product  *productapp.Service
+	User           *userapp.Service
+	Auth           *authapp.Service
+	Category       *categoryapp.Service
+	Product        *productapp.Service
+	Cart           *cartapp.Service
+	Order          *orderapp.Service
+	Inventory      *inventoryapp.Service
+	Checkout       *checkoutapp.Service
+	Pricing        *pricingapp.Service
+	Review         *reviewapp.Service
+	Recommendation *recommendationapp.Service
+	Promotion      *promotionapp.Service
+	Tax            *taxapp.Service
+	Shipping       *shippingapp.Service
+	Address        *addressapp.Service
+	Return         *rmaapp.Service
+	Invoice        *invoiceapp.Service
+	GiftCard       *giftcardapp.Service
 }
 
 // Server represents the HTTP server
 type Server struct {
-	router       *mux.Router
-	services     Services
-	config       *config.ServerConfig
-	tokenService auth.TokenService
+	router           *mux.Router
+	services         Services
+	config           *config.ServerConfig
+	tokenService     auth.TokenService
+	idempotencyStore idempotency.Store
+	idempotency      middleware.IdempotencyOptions
 }
 
 // NewServer creates a new HTTP server
-func NewServer(services Services, cfg *config.ServerConfig, tokenService auth.TokenService) *Server {
+func NewServer(
+	services Services,
+	cfg *config.ServerConfig,
+	tokenService auth.TokenService,
+	idempotencyStore idempotency.Store,
+	idempotency middleware.IdempotencyOptions,
+) *Server {
 	server := &Server{
-		router:       mux.NewRouter(),
-		services:     services,
-		config:       cfg,
-		tokenService: tokenService,
+		router:           mux.NewRouter(),
+		services:         services,
+		config:           cfg,
+		tokenService:     tokenService,
+		idempotencyStore: idempotencyStore,
+		idempotency:      idempotency,
 	}
 
 	server.setupRoutes()
@@ -52,15 +93,21 @@ func (s *Server) setupRoutes() {
 	// Apply global middleware
 	s.router.Use(middleware.LoggingMiddleware)
 	s.router.Use(middleware.RecoveryMiddleware)
+	s.router.Use(middleware.CurrencyMiddleware)
+	s.router.Use(middleware.CartTokenMiddleware)
 
 	// Health check
 	s.router.HandleFunc("/health", s.healthCheck).Methods("GET")
 
+	// XML sitemap for search engines
+	s.router.HandleFunc("/sitemap.xml", s.handle(h.Product.Sitemap)).Methods("GET")
+
 	// API v1 routes
 	api := s.router.PathPrefix("/api/v1").Subrouter()
 
 	// Setup route groups
 	s.setupPublicRoutes(api, h)
+	s.setupShopperRoutes(api, h)
 	s.setupProtectedRoutes(api, h)
 	s.setupManagerRoutes(api, h)
 }
@@ -71,6 +118,20 @@ func (s *Server) initializeHandlers() *handlers.Handlers {
 		s.services.User,
 		s.services.Category,
 		s.services.Product,
+		s.services.Cart,
+		s.services.Order,
+		s.services.Inventory,
+		s.services.Checkout,
+		s.services.Pricing,
+		s.services.Review,
+		s.services.Recommendation,
+		s.services.Promotion,
+		s.services.Tax,
+		s.services.Shipping,
+		s.services.Address,
+		s.services.Return,
+		s.services.Invoice,
+		s.services.GiftCard,
 	)
 }
 
@@ -92,68 +153,219 @@ func (s *Server) setupPublicRoutes(api *mux.Router, h *handlers.Handlers) {
 	products.HandleFunc("", s.handle(h.Product.List)).Methods("GET")
 	products.HandleFunc("/{id}", s.handle(h.Product.Get)).Methods("GET")
 	products.HandleFunc("/category/{categoryId}", s.handle(h.Product.GetByCategory)).Methods("GET")
+	products.HandleFunc("/{id}/reviews", s.handle(h.Review.ListForProduct)).Methods("GET")
+	products.HandleFunc("/{id}/recommendations", s.handle(h.Recommendation.List)).Methods("GET")
+
+	// Public category browsing
+	categories := api.PathPrefix("/categories").Subrouter()
+	categories.HandleFunc("", s.handle(h.Category.Tree)).Methods("GET")
+	categories.HandleFunc("/{slug}", s.handle(h.Category.GetBySlug)).Methods("GET")
+
+	// Gift card balance lookup by whoever holds the code
+	api.HandleFunc("/gift-cards/{code}", s.handle(h.GiftCard.Balance)).Methods("GET")
+}
+
+// setupShopperRoutes registers the routes open to guests as well as signed-in
+// users; guests are identified by their cart token
+func (s *Server) setupShopperRoutes(api *mux.Router, h *handlers.Handlers) {
+	shopper := api.PathPrefix("").Subrouter()
+	shopper.Use(middleware.OptionalAuthMiddleware(s.tokenService))
+	shopper.Use(middleware.IdempotencyMiddleware(s.idempotencyStore, s.idempotency))
+
+	// Cart routes
+	cart := shopper.PathPrefix("/cart").Subrouter()
+	cart.HandleFunc("", s.handle(h.Cart.Get)).Methods("GET")
+	cart.HandleFunc("/items", s.handle(h.Cart.AddProduct)).Methods("POST")
+	cart.HandleFunc("/items/{productId}", s.handle(h.Cart.RemoveProduct)).Methods("DELETE")
+	cart.HandleFunc("", s.handle(h.Cart.Clear)).Methods("DELETE")
+	cart.HandleFunc("/discount", s.handle(h.Cart.ApplyDiscount)).Methods("POST")
+	cart.HandleFunc("/discount", s.handle(h.Cart.RemoveDiscount)).Methods("DELETE")
+	cart.HandleFunc("/restore", s.handle(h.Cart.Restore)).Methods("POST")
+
+	// Checkout routes
+	checkout := shopper.PathPrefix("/checkout").Subrouter()
+	checkout.HandleFunc("", s.handle(h.Checkout.Create)).Methods("POST")
+	checkout.HandleFunc("/shipping-rates", s.handle(h.Checkout.ShippingRates)).Methods("POST")
 }
 
 func (s *Server) setupProtectedRoutes(api *mux.Router, h *handlers.Handlers) {
 	// Create protected subrouter with auth middleware
 	protected := api.PathPrefix("").Subrouter()
 	protected.Use(middleware.AuthMiddleware(s.tokenService))
+	protected.Use(middleware.IdempotencyMiddleware(s.idempotencyStore, s.idempotency))
 
 	// User routes
 	users := protected.PathPrefix("/users").Subrouter()
 	users.HandleFunc("/me", s.handle(h.User.GetCurrentUser)).Methods("GET")
 	users.HandleFunc("/me", s.handle(h.User.UpdateProfile)).Methods("PUT")
+	users.HandleFunc("/me/currency", s.handle(h.Pricing.SetMyCurrency)).Methods("PUT")
+	users.HandleFunc("/me/likes", s.handle(h.Product.ListMyLikes)).Methods("GET")
+	users.HandleFunc("/me/addresses", s.handle(h.Address.List)).Methods("GET")
+	users.HandleFunc("/me/addresses", s.handle(h.Address.Create)).Methods("POST")
+	users.HandleFunc("/me/addresses/{id}", s.handle(h.Address.Get)).Methods("GET")
+	users.HandleFunc("/me/addresses/{id}", s.handle(h.Address.Update)).Methods("PUT")
+	users.HandleFunc("/me/addresses/{id}", s.handle(h.Address.Delete)).Methods("DELETE")
+	users.HandleFunc("/me/store-credit", s.handle(h.GiftCard.MyStoreCredit)).Methods("GET")
 
 	// Product interactions
 	products := protected.PathPrefix("/products").Subrouter()
 	products.HandleFunc("/{id}/like", s.handle(h.Product.Like)).Methods("POST")
+	products.HandleFunc("/{id}/like", s.handle(h.Product.Unlike)).Methods("DELETE")
+	products.HandleFunc("/{id}/reviews", s.handle(h.Review.Create)).Methods("POST")
 
-	// Cart routes
-	cart := protected.PathPrefix("/cart").Subrouter()
-	cart.HandleFunc("", s.handle(h.Cart.Get)).Methods("GET")
-	cart.HandleFunc("/items", s.handle(h.Cart.AddProduct)).Methods("POST")
-	cart.HandleFunc("/items/{productId}", s.handle(h.Cart.RemoveProduct)).Methods("DELETE")
-	cart.HandleFunc("", s.handle(h.Cart.Clear)).Methods("DELETE")
+	// Review interactions
+	reviews := protected.PathPrefix("/reviews").Subrouter()
+	reviews.HandleFunc("/{id}/helpful", s.handle(h.Review.MarkHelpful)).Methods("POST")
 
 	// Order routes
 	orders := protected.PathPrefix("/orders").Subrouter()
 	orders.HandleFunc("", s.handle(h.Order.ListMyOrders)).Methods("GET")
 	orders.HandleFunc("/{id}", s.handle(h.Order.Get)).Methods("GET")
+	orders.HandleFunc("/{id}/shipments", s.handle(h.Shipping.ListMyShipments)).Methods("GET")
+	orders.HandleFunc("/{id}/returns", s.handle(h.Return.Request)).Methods("POST")
+	orders.HandleFunc("/{id}/documents", s.handle(h.Invoice.ListMyDocuments)).Methods("GET")
+	orders.HandleFunc("/{id}/invoice", s.handle(h.Invoice.MyInvoice)).Methods("GET")
+	orders.HandleFunc("/{id}/credit-notes/{creditNoteId}", s.handle(h.Invoice.MyCreditNote)).Methods("GET")
 
-	// Checkout routes
-	checkout := protected.PathPrefix("/checkout").Subrouter()
-	checkout.HandleFunc("", s.handle(h.Checkout.Create)).Methods("POST")
+	// Return routes
+	returns := protected.PathPrefix("/returns").Subrouter()
+	returns.HandleFunc("", s.handle(h.Return.ListMine)).Methods("GET")
+	returns.HandleFunc("/{id}", s.handle(h.Return.GetMine)).Methods("GET")
 }
 
 func (s *Server) setupManagerRoutes(api *mux.Router, h *handlers.Handlers) {
-	// Create manager subrouter with auth middleware
+	// Create manager subrouter with auth middleware; only users granted the
+	// manager role get through
 	manager := api.PathPrefix("/manager").Subrouter()
 	manager.Use(middleware.AuthMiddleware(s.tokenService))
+	manager.Use(middleware.RequireRole(s.services.User, user.RoleManager))
+	manager.Use(middleware.IdempotencyMiddleware(s.idempotencyStore, s.idempotency))
 
 	// Product management
 	products := manager.PathPrefix("/products").Subrouter()
 	products.HandleFunc("", s.handle(h.Product.Create)).Methods("POST")
+	products.HandleFunc("/import", s.handle(h.Product.Import)).Methods("POST")
+	products.HandleFunc("/import/{jobId}", s.handle(h.Product.GetImportJob)).Methods("GET")
+	products.HandleFunc("/export", s.handle(h.Product.Export)).Methods("GET")
 	products.HandleFunc("/{id}", s.handle(h.Product.Update)).Methods("PUT")
 	products.HandleFunc("/{id}", s.handle(h.Product.Delete)).Methods("DELETE")
 	products.HandleFunc("/{id}/disable", s.handle(h.Product.Disable)).Methods("PATCH")
 	products.HandleFunc("/{id}/images", s.handle(h.Product.UploadImage)).Methods("POST")
+	products.HandleFunc("/{id}/price-history", s.handle(h.Product.PriceHistory)).Methods("GET")
+	products.HandleFunc("/{id}/price-schedules", s.handle(h.Product.ListPriceSchedules)).Methods("GET")
+	products.HandleFunc("/{id}/price-schedules", s.handle(h.Product.SchedulePrice)).Methods("POST")
+	products.HandleFunc("/{id}/price-schedules/{scheduleId}", s.handle(h.Product.CancelPriceSchedule)).Methods("DELETE")
+	products.HandleFunc("/{id}/links", s.handle(h.Recommendation.ListLinks)).Methods("GET")
+	products.HandleFunc("/{id}/links", s.handle(h.Recommendation.CreateLink)).Methods("POST")
+	products.HandleFunc("/{id}/links/{linkId}", s.handle(h.Recommendation.DeleteLink)).Methods("DELETE")
+	products.HandleFunc("/{id}/options", s.handle(h.Product.AddOption)).Methods("POST")
+	products.HandleFunc("/{id}/variants", s.handle(h.Product.CreateVariant)).Methods("POST")
+	products.HandleFunc("/{id}/variants/{variantId}", s.handle(h.Product.UpdateVariant)).Methods("PUT")
+	products.HandleFunc("/{id}/variants/{variantId}", s.handle(h.Product.DeleteVariant)).Methods("DELETE")
+	products.HandleFunc("/{id}/variants/{variantId}/images", s.handle(h.Product.UploadVariantImage)).Methods("POST")
 
 	// Category management
 	categories := manager.PathPrefix("/categories").Subrouter()
 	categories.HandleFunc("", s.handle(h.Category.Create)).Methods("POST")
 	categories.HandleFunc("/{id}", s.handle(h.Category.Update)).Methods("PUT")
 	categories.HandleFunc("/{id}", s.handle(h.Category.Get)).Methods("GET")
+	categories.HandleFunc("/{id}", s.handle(h.Category.Delete)).Methods("DELETE")
+	categories.HandleFunc("/{id}/attributes", s.handle(h.Category.ListAttributes)).Methods("GET")
+	categories.HandleFunc("/{id}/attributes", s.handle(h.Category.CreateAttribute)).Methods("POST")
+	categories.HandleFunc("/{id}/attributes/{attributeId}", s.handle(h.Category.DeleteAttribute)).Methods("DELETE")
 	categories.HandleFunc("", s.handle(h.Category.List)).Methods("GET")
 
+	// Inventory management
+	inventory := manager.PathPrefix("/inventory").Subrouter()
+	inventory.HandleFunc("/movements", s.handle(h.Inventory.ListMovements)).Methods("GET")
+	inventory.HandleFunc("/movements", s.handle(h.Inventory.RecordMovement)).Methods("POST")
+	inventory.HandleFunc("/reconcile", s.handle(h.Inventory.Reconcile)).Methods("POST")
+	inventory.HandleFunc("/thresholds", s.handle(h.Inventory.SetThreshold)).Methods("PUT")
+	inventory.HandleFunc("/low-stock", s.handle(h.Inventory.ListLowStock)).Methods("GET")
+
+	// Review moderation
+	reviews := manager.PathPrefix("/reviews").Subrouter()
+	reviews.HandleFunc("", s.handle(h.Review.ListForModeration)).Methods("GET")
+	reviews.HandleFunc("/{id}/approve", s.handle(h.Review.Approve)).Methods("POST")
+	reviews.HandleFunc("/{id}/reject", s.handle(h.Review.Reject)).Methods("POST")
+	reviews.HandleFunc("/{id}/hide", s.handle(h.Review.Hide)).Methods("POST")
+
+	// Pricing management
+	priceLists := manager.PathPrefix("/price-lists").Subrouter()
+	priceLists.HandleFunc("", s.handle(h.Pricing.ListPriceLists)).Methods("GET")
+	priceLists.HandleFunc("", s.handle(h.Pricing.CreatePriceList)).Methods("POST")
+	priceLists.HandleFunc("/{id}", s.handle(h.Pricing.GetPriceList)).Methods("GET")
+	priceLists.HandleFunc("/{id}/prices", s.handle(h.Pricing.SetPrice)).Methods("PUT")
+	priceLists.HandleFunc("/{id}/prices/{productId}", s.handle(h.Pricing.DeletePrice)).Methods("DELETE")
+
+	exchangeRates := manager.PathPrefix("/exchange-rates").Subrouter()
+	exchangeRates.HandleFunc("", s.handle(h.Pricing.ListRates)).Methods("GET")
+	exchangeRates.HandleFunc("", s.handle(h.Pricing.SetRate)).Methods("PUT")
+
+	// Promotion management
+	promotions := manager.PathPrefix("/promotions").Subrouter()
+	promotions.HandleFunc("", s.handle(h.Promotion.List)).Methods("GET")
+	promotions.HandleFunc("", s.handle(h.Promotion.Create)).Methods("POST")
+	promotions.HandleFunc("/{id}", s.handle(h.Promotion.Get)).Methods("GET")
+	promotions.HandleFunc("/{id}", s.handle(h.Promotion.Update)).Methods("PUT")
+	promotions.HandleFunc("/{id}", s.handle(h.Promotion.Delete)).Methods("DELETE")
+
+	// Tax management
+	taxRates := manager.PathPrefix("/tax-rates").Subrouter()
+	taxRates.HandleFunc("", s.handle(h.Tax.ListRates)).Methods("GET")
+	taxRates.HandleFunc("", s.handle(h.Tax.SetRate)).Methods("PUT")
+	taxRates.HandleFunc("/{id}", s.handle(h.Tax.DeleteRate)).Methods("DELETE")
+
+	// Shipping management
+	shippingZones := manager.PathPrefix("/shipping-zones").Subrouter()
+	shippingZones.HandleFunc("", s.handle(h.Shipping.ListZones)).Methods("GET")
+	shippingZones.HandleFunc("", s.handle(h.Shipping.CreateZone)).Methods("POST")
+	shippingZones.HandleFunc("/{id}", s.handle(h.Shipping.GetZone)).Methods("GET")
+	shippingZones.HandleFunc("/{id}", s.handle(h.Shipping.UpdateZone)).Methods("PUT")
+	shippingZones.HandleFunc("/{id}", s.handle(h.Shipping.DeleteZone)).Methods("DELETE")
+	shippingZones.HandleFunc("/{id}/methods", s.handle(h.Shipping.CreateMethod)).Methods("POST")
+
+	shippingMethods := manager.PathPrefix("/shipping-methods").Subrouter()
+	shippingMethods.HandleFunc("/{id}", s.handle(h.Shipping.UpdateMethod)).Methods("PUT")
+	shippingMethods.HandleFunc("/{id}", s.handle(h.Shipping.DeleteMethod)).Methods("DELETE")
+
 	// Order management
 	orders := manager.PathPrefix("/orders").Subrouter()
 	orders.HandleFunc("", s.handle(h.Order.ListAllOrders)).Methods("GET")
-	orders.HandleFunc("/{id}", s.handle(h.Order.Get)).Methods("GET")
+	orders.HandleFunc("/{id}", s.handle(h.Order.ManagerGet)).Methods("GET")
+	orders.HandleFunc("/{id}/shipments", s.handle(h.Shipping.ListShipments)).Methods("GET")
+	orders.HandleFunc("/{id}/shipments", s.handle(h.Shipping.CreateShipment)).Methods("POST")
+	orders.HandleFunc("/{id}/shipments/{shipmentId}/deliver", s.handle(h.Shipping.MarkDelivered)).Methods("POST")
+	orders.HandleFunc("/{id}/refunds", s.handle(h.Return.ListRefunds)).Methods("GET")
+	orders.HandleFunc("/{id}/refunds", s.handle(h.Return.RefundOrder)).Methods("POST")
+	orders.HandleFunc("/{id}/documents", s.handle(h.Invoice.ListDocuments)).Methods("GET")
+	orders.HandleFunc("/{id}/invoice", s.handle(h.Invoice.Invoice)).Methods("GET")
+	orders.HandleFunc("/{id}/credit-notes/{creditNoteId}", s.handle(h.Invoice.CreditNote)).Methods("GET")
+	orders.HandleFunc("/{id}/gift-cards", s.handle(h.GiftCard.IssueForOrder)).Methods("POST")
+
+	// Gift card management
+	giftCards := manager.PathPrefix("/gift-cards").Subrouter()
+	giftCards.HandleFunc("", s.handle(h.GiftCard.Issue)).Methods("POST")
+	giftCards.HandleFunc("/{id}", s.handle(h.GiftCard.Get)).Methods("GET")
+
+	// Return management
+	returns := manager.PathPrefix("/returns").Subrouter()
+	returns.HandleFunc("", s.handle(h.Return.List)).Methods("GET")
+	returns.HandleFunc("/{id}", s.handle(h.Return.Get)).Methods("GET")
+	returns.HandleFunc("/{id}/approve", s.handle(h.Return.Approve)).Methods("POST")
+	returns.HandleFunc("/{id}/reject", s.handle(h.Return.Reject)).Methods("POST")
+	returns.HandleFunc("/{id}/receive", s.handle(h.Return.Receive)).Methods("POST")
+	returns.HandleFunc("/{id}/refund", s.handle(h.Return.Refund)).Methods("POST")
+
+	// Reports
+	reports := manager.PathPrefix("/reports").Subrouter()
+	reports.HandleFunc("/abandoned-carts", s.handle(h.Cart.AbandonedReport)).Methods("GET")
 
 	// User management
 	users := manager.PathPrefix("/users").Subrouter()
 	users.HandleFunc("", s.handle(h.User.ListUsers)).Methods("GET")
 	users.HandleFunc("/{id}", s.handle(h.User.GetUser)).Methods("GET")
+	users.HandleFunc("/{id}/customer-group", s.handle(h.Pricing.SetCustomerGroup)).Methods("PUT")
 }
 
 // Wrapper to handle errors consistently
@@ -175,5 +387,10 @@ func (s *Server) healthCheck(w http.ResponseWriter, r *http.Request) {
 func (s *Server) Start() error {
 	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
 	log.Printf("🚀 REST server starting on %s", addr)
-	return http.ListenAndServe(addr, s.router)
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      s.router,
+		WriteTimeout: time.Duration(s.config.WriteTimeoutSeconds) * time.Second,
+	}
+	return server.ListenAndServe()
 }
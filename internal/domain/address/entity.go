@@ -0,0 +1,59 @@
+package address
+
+import (
+	"strings"
+	"time"
+)
+
+// Address is an entry in a customer's address book (pure domain entity).
+// Orders keep a copy of the address they were placed with, so editing or
+// deleting an entry never changes past orders.
+type Address struct {
+	ID              string
+	UserID          string
+	Name            string
+	Line1           string
+	Line2           string
+	City            string
+	Region          string // state or province
+	PostalCode      string
+	Country         string // ISO 3166-1 alpha-2
+	Phone           string
+	DefaultShipping bool
+	DefaultBilling  bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Normalize trims the fields and upper-cases the country and postal codes
+func (a *Address) Normalize() {
+	a.Name = strings.TrimSpace(a.Name)
+	a.Line1 = strings.TrimSpace(a.Line1)
+	a.Line2 = strings.TrimSpace(a.Line2)
+	a.City = strings.TrimSpace(a.City)
+	a.Region = strings.TrimSpace(a.Region)
+	a.PostalCode = strings.ToUpper(strings.TrimSpace(a.PostalCode))
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+	a.Phone = strings.TrimSpace(a.Phone)
+}
+
+// Validate checks the required fields and the postal code format of the country
+func (a *Address) Validate() error {
+	if a.Name == "" || a.Line1 == "" || a.City == "" || !ValidCountry(a.Country) {
+		return ErrInvalidAddress
+	}
+	return ValidatePostalCode(a.Country, a.PostalCode)
+}
+
+// ValidCountry reports whether the code has the form of an ISO 3166-1 alpha-2 code
+func ValidCountry(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+	for _, r := range country {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
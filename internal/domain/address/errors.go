@@ -0,0 +1,14 @@
+package address
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidAddress indicates that an address is missing required fields
+	ErrInvalidAddress = apperrors.ErrInvalidAddress
+
+	// ErrInvalidPostalCode indicates that a postal code does not match the country format
+	ErrInvalidPostalCode = apperrors.ErrInvalidPostalCode
+
+	// ErrAddressNotFound indicates that the address does not exist
+	ErrAddressNotFound = apperrors.ErrNotFound
+)
@@ -0,0 +1,15 @@
+package address
+
+// Repository defines the interface for address book persistence operations
+type Repository interface {
+	// ListAddresses returns the user's addresses, defaults first
+	ListAddresses(userID string) ([]*Address, error)
+	GetAddress(id string) (*Address, error)
+	// CreateAddress stores the address; a default flag it sets is cleared
+	// from the user's other addresses
+	CreateAddress(address *Address) error
+	// UpdateAddress replaces the address; a default flag it sets is cleared
+	// from the user's other addresses
+	UpdateAddress(address *Address) error
+	DeleteAddress(id string) error
+}
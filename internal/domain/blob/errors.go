@@ -0,0 +1,8 @@
+package blob
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrNotFound indicates that nothing is stored under the key
+	ErrNotFound = apperrors.ErrNotFound
+)
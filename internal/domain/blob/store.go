@@ -0,0 +1,11 @@
+package blob
+
+// Store defines the interface for storing generated files such as documents
+type Store interface {
+	// Put writes the data under the key, replacing what was there
+	Put(key, contentType string, data []byte) error
+
+	// Get reads the data stored under the key, failing with ErrNotFound when
+	// there is none
+	Get(key string) ([]byte, error)
+}
@@ -0,0 +1,96 @@
+package cart
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/promotion"
+)
+
+// Cart represents a customer's shopping cart (pure domain entity). Guest
+// carts have no UserID and are identified by a signed cart token instead.
+type Cart struct {
+	ID           string
+	UserID       string
+	Token        string // signed token of a guest cart, not stored
+	Currency     string // locked when the cart is created
+	DiscountCode string // coupon applied by the customer
+	Items        []Item
+	Subtotal     money.Money         // priced at current catalog prices, not stored
+	Discount     money.Money         // not stored
+	Total        money.Money         // Subtotal less Discount, not stored
+	Promotions   []promotion.Applied // promotions behind Discount, not stored
+	FreeShipping bool                // not stored
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Item is a cart line for a product, optionally narrowed to a variant
+type Item struct {
+	ID        string
+	CartID    string
+	ProductID string
+	VariantID string
+	Quantity  int
+	UnitPrice money.Money // current catalog price, not stored
+	Discount  money.Money // not stored
+	Total     money.Money // line amount less Discount, not stored
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// FindItem returns the line for the product/variant pair, or nil
+func (c *Cart) FindItem(productID, variantID string) *Item {
+	for i := range c.Items {
+		if c.Items[i].ProductID == productID && c.Items[i].VariantID == variantID {
+			return &c.Items[i]
+		}
+	}
+	return nil
+}
+
+// CalculateSubtotal sums the priced items into Subtotal
+func (c *Cart) CalculateSubtotal(currency string) error {
+	lineTotals := make([]money.Money, len(c.Items))
+	for i := range c.Items {
+		lineTotals[i] = c.Items[i].UnitPrice.Mul(c.Items[i].Quantity)
+	}
+
+	subtotal, err := money.Sum(currency, lineTotals...)
+	if err != nil {
+		return err
+	}
+
+	c.Subtotal = subtotal
+	return nil
+}
+
+// ApplyDiscounts sets the discount of each item and the cart totals from a
+// promotion breakdown of the items, in item order; nil applies no discount
+func (c *Cart) ApplyDiscounts(b *promotion.Breakdown) error {
+	c.Discount = money.Zero(c.Subtotal.Currency)
+	c.Promotions = nil
+	c.FreeShipping = false
+	if b != nil {
+		c.Discount = b.Discount
+		c.Promotions = b.Applied
+		c.FreeShipping = b.FreeShipping
+	}
+
+	for i := range c.Items {
+		item := &c.Items[i]
+		item.Discount = money.Zero(c.Subtotal.Currency)
+		if b != nil {
+			item.Discount = b.Discounts[i]
+		}
+
+		var err error
+		if item.Total, err = item.UnitPrice.Mul(item.Quantity).Sub(item.Discount); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	c.Total, err = c.Subtotal.Sub(c.Discount)
+	return err
+}
@@ -0,0 +1,91 @@
+package cart
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// Reminder records an email sent to a customer about their abandoned cart
+type Reminder struct {
+	ID       string
+	CartID   string
+	UserID   string
+	Email    string
+	Sequence int         // 1 for the first reminder since the cart last changed
+	Value    money.Money // cart total when the reminder was sent
+	OrderID  string      // order the cart was checked out into afterwards
+	SentAt   time.Time
+}
+
+// Abandoned is a customer cart left idle that is due a reminder
+type Abandoned struct {
+	CartID    string
+	UserID    string
+	Email     string
+	Reminders int // reminders sent since the cart last changed
+}
+
+// ReminderNotice is the content of an abandoned cart reminder
+type ReminderNotice struct {
+	To         string
+	Sequence   int
+	Items      int // number of units in the cart
+	Total      money.Money
+	RestoreURL string
+}
+
+// ReminderNotifier defines the interface for delivering abandoned cart reminders
+type ReminderNotifier interface {
+	NotifyAbandoned(notice ReminderNotice) error
+}
+
+// Interval is the length of the periods of an abandoned cart report
+type Interval string
+
+const (
+	IntervalDay   Interval = "day"
+	IntervalWeek  Interval = "week"
+	IntervalMonth Interval = "month"
+)
+
+// IsValid reports whether the interval is known
+func (i Interval) IsValid() bool {
+	switch i {
+	case IntervalDay, IntervalWeek, IntervalMonth:
+		return true
+	}
+	return false
+}
+
+// ReportFilters selects the periods of an abandoned cart report
+type ReportFilters struct {
+	From     time.Time // inclusive
+	To       time.Time // exclusive
+	Interval Interval
+}
+
+// ReportRow sums the carts abandoned in one period and currency. A cart counts
+// as abandoned when its first reminder is sent, and as recovered when it is
+// later checked out into an order that gets paid.
+type ReportRow struct {
+	Period         time.Time
+	Currency       string
+	Carts          int
+	Value          money.Money
+	RecoveredCarts int
+	RecoveredValue money.Money // totals of the paid orders
+}
+
+// ReminderRepository defines the interface for abandoned cart persistence
+type ReminderRepository interface {
+	// ListAbandoned returns customer carts with items that have not changed
+	// since idleSince, have had fewer than maxReminders reminders since they
+	// last changed, and none since idleSince
+	ListAbandoned(idleSince time.Time, maxReminders, limit int) ([]*Abandoned, error)
+	CreateReminder(reminder *Reminder) error
+	// MarkRecovered links the latest reminder of the cart not yet followed by
+	// an order to the order the cart was checked out into
+	MarkRecovered(cartID, orderID string) error
+	Report(filters ReportFilters) ([]ReportRow, error)
+}
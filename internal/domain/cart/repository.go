@@ -0,0 +1,18 @@
+package cart
+
+// Repository defines the interface for cart persistence operations
+type Repository interface {
+	GetCart(id string) (*Cart, error)
+	GetCartByUserID(userID string) (*Cart, error)
+	CreateCart(cart *Cart) error
+	SaveItem(item *Item) error
+	RemoveItem(cartID, productID, variantID string) error
+	ClearCart(cartID string) error
+	// SetDiscountCode stores the coupon applied to the cart; empty removes it
+	SetDiscountCode(cartID, code string) error
+	// MergeItems saves items into the cart in one transaction, along with the
+	// discount code when the cart has none. When fromID is set, that cart is
+	// deleted in the same transaction, and nothing is saved if it is already
+	// gone (ErrNotFound), so a cart is only ever merged once.
+	MergeItems(cartID string, items []Item, discountCode, fromID string) error
+}
@@ -0,0 +1,17 @@
+package cart
+
+import "time"
+
+// TokenService issues and verifies the signed tokens that identify guest
+// carts, so a guest cannot reach another guest's cart by guessing its ID
+type TokenService interface {
+	Issue(cartID string) string
+	// Parse returns the cart ID of a token, or apperrors.ErrInvalidCartToken
+	Parse(token string) (string, error)
+	// IssueRestore issues a token that restores the cart from a reminder
+	// link until expiresAt
+	IssueRestore(cartID string, expiresAt time.Time) string
+	// ParseRestore returns the cart ID of a restore token, or
+	// apperrors.ErrInvalidRestoreLink once it has expired
+	ParseRestore(token string) (string, error)
+}
@@ -0,0 +1,133 @@
+package category
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
+)
+
+// AttributeType is the value type of a category attribute
+type AttributeType string
+
+const (
+	AttributeText    AttributeType = "text"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+	AttributeEnum    AttributeType = "enum"
+)
+
+// MaxAttributeValueLength bounds text specification values
+const MaxAttributeValueLength = 255
+
+// Attribute defines a typed product specification for the products of a
+// category and its descendants
+type Attribute struct {
+	ID         string
+	CategoryID string
+	Key        string // slug, used in filters as attr.<key>
+	Label      string
+	Type       AttributeType
+	Unit       string   // display unit for numbers, e.g. "cm"
+	Options    []string // allowed values of enum attributes
+	Required   bool
+	Position   int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Validate checks the attribute definition
+func (a *Attribute) Validate() error {
+	if !seo.ValidSlug(a.Key) {
+		return ErrInvalidAttribute
+	}
+
+	switch a.Type {
+	case AttributeText, AttributeNumber, AttributeBoolean:
+		if len(a.Options) > 0 {
+			return ErrInvalidAttribute
+		}
+	case AttributeEnum:
+		if len(a.Options) == 0 {
+			return ErrInvalidAttribute
+		}
+		seen := make(map[string]bool, len(a.Options))
+		for _, option := range a.Options {
+			if option == "" || seen[option] {
+				return ErrInvalidAttribute
+			}
+			seen[option] = true
+		}
+	default:
+		return ErrInvalidAttribute
+	}
+
+	return nil
+}
+
+// Normalize checks a specification value against the attribute type and
+// returns its canonical text form, plus the parsed number for number
+// attributes
+func (a *Attribute) Normalize(value string) (string, *float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil, ErrInvalidAttributeValue
+	}
+
+	switch a.Type {
+	case AttributeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", nil, ErrInvalidAttributeValue
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), &n, nil
+	case AttributeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", nil, ErrInvalidAttributeValue
+		}
+		return strconv.FormatBool(b), nil, nil
+	case AttributeEnum:
+		for _, option := range a.Options {
+			if strings.EqualFold(option, value) {
+				return option, nil, nil
+			}
+		}
+		return "", nil, ErrInvalidAttributeValue
+	default:
+		if utf8.RuneCountInString(value) > MaxAttributeValueLength {
+			return "", nil, ErrInvalidAttributeValue
+		}
+		return value, nil, nil
+	}
+}
+
+// Schema resolves the attributes that apply to a category from the
+// attributes of its root-to-leaf path. A key redefined lower in the tree
+// replaces the ancestor's definition.
+func Schema(path []*Category, attributes []*Attribute) []*Attribute {
+	level := make(map[string]int, len(path))
+	for i, c := range path {
+		level[c.ID] = i
+	}
+
+	byKey := make(map[string]*Attribute)
+	var keys []string
+	for _, a := range attributes {
+		existing, ok := byKey[a.Key]
+		if !ok {
+			keys = append(keys, a.Key)
+		}
+		if !ok || level[a.CategoryID] > level[existing.CategoryID] {
+			byKey[a.Key] = a
+		}
+	}
+
+	schema := make([]*Attribute, len(keys))
+	for i, key := range keys {
+		schema[i] = byKey[key]
+	}
+	return schema
+}
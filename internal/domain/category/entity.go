@@ -1,11 +1,83 @@
 package category
 
-import "time"
+import (
+	"time"
 
-// Category represents a product category (pure domain entity)
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
+)
+
+// MaxDepth is the deepest level a category may sit at; root categories are level 1
+const MaxDepth = 5
+
+// Category represents a product category (pure domain entity). Categories form
+// a tree through ParentID; an empty ParentID marks a root category.
 type Category struct {
-	ID        string
-	Name      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           string
+	ParentID     string
+	Name         string
+	Slug         string
+	SEO          seo.Metadata
+	ProductCount int64 // enabled products in this category and its descendants, set on tree reads
+	Children     []*Category
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Breadcrumb is one step on the path from a root category down to a category
+type Breadcrumb struct {
+	ID   string
+	Name string
+	Slug string
+}
+
+// DeleteResult reports what a category deletion moved out of the category
+type DeleteResult struct {
+	CategoryID         string
+	ReassignedTo       string
+	ProductsReassigned int64 // products moved to ReassignedTo
+	ChildrenReparented int64 // child categories moved up to the deleted category's parent
+	SpecsDropped       int64 // product specifications of attributes that no longer apply
+}
+
+// BuildTree links a flat list of categories into root trees, rolling product
+// counts up into each ancestor. Categories whose parent is missing from the
+// list are treated as roots.
+func BuildTree(categories []*Category) []*Category {
+	byID := make(map[string]*Category, len(categories))
+	for _, c := range categories {
+		c.Children = nil
+		byID[c.ID] = c
+	}
+
+	var roots []*Category
+	for _, c := range categories {
+		if parent, ok := byID[c.ParentID]; ok && c.ParentID != "" {
+			parent.Children = append(parent.Children, c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	for _, root := range roots {
+		rollUpCounts(root)
+	}
+	return roots
+}
+
+func rollUpCounts(c *Category) int64 {
+	for _, child := range c.Children {
+		c.ProductCount += rollUpCounts(child)
+	}
+	return c.ProductCount
+}
+
+// Height returns the number of levels in the subtree rooted at c, counting c
+func Height(c *Category) int {
+	height := 0
+	for _, child := range c.Children {
+		if h := Height(child); h > height {
+			height = h
+		}
+	}
+	return height + 1
 }
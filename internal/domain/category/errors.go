@@ -0,0 +1,32 @@
+package category
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrCycle indicates a parent change that would make a category its own ancestor
+	ErrCycle = apperrors.ErrCategoryCycle
+
+	// ErrTooDeep indicates a tree change that would exceed MaxDepth
+	ErrTooDeep = apperrors.ErrCategoryTooDeep
+
+	// ErrNotEmpty indicates a deletion of a category that still has products and no reassignment target
+	ErrNotEmpty = apperrors.ErrCategoryNotEmpty
+
+	// ErrInvalidReassignTarget indicates a reassignment target that is missing or is the deleted category
+	ErrInvalidReassignTarget = apperrors.ErrInvalidReassignTarget
+
+	// ErrInvalidAttribute indicates an attribute definition with a bad key, type or option list
+	ErrInvalidAttribute = apperrors.ErrInvalidAttribute
+
+	// ErrInvalidAttributeValue indicates a specification value that does not match its attribute type
+	ErrInvalidAttributeValue = apperrors.ErrInvalidAttributeValue
+
+	// ErrAttributeRequired indicates a product missing a value for a required attribute
+	ErrAttributeRequired = apperrors.ErrAttributeRequired
+
+	// ErrUnknownAttribute indicates a specification key not defined for the product's category
+	ErrUnknownAttribute = apperrors.ErrUnknownAttribute
+
+	// ErrNotFound indicates that the category does not exist
+	ErrNotFound = apperrors.ErrNotFound
+)
@@ -3,8 +3,26 @@ package category
 // Repository defines the interface for category persistence operations
 type Repository interface {
 	ListCategories() ([]*Category, error)
+	// ListCategoriesWithCounts returns all categories with the number of enabled
+	// products assigned directly to each
+	ListCategoriesWithCounts() ([]*Category, error)
 	GetCategoryByID(id string) (*Category, error)
+	GetCategoryBySlug(slug string) (*Category, error)
+	// Ancestors returns the path from the root down to and including the category
+	Ancestors(id string) ([]*Category, error)
 	CreateCategory(category *Category) error
 	UpdateCategory(id string, category *Category) error
-	DeleteCategory(id string) error
+	// DeleteCategory soft-deletes a category in one transaction. Its products
+	// move to reassignTo, and deletion is refused with ErrNotEmpty when it has
+	// products and reassignTo is empty. Child categories move up to its parent.
+	// Product specifications are dropped where their attribute no longer
+	// applies: those of the category's own attributes, and on the moved
+	// products those outside the attributes of reassignTo and its ancestors.
+	DeleteCategory(id, reassignTo string) (*DeleteResult, error)
+	// ListAttributes returns the attributes defined directly on the given
+	// categories, ordered by position
+	ListAttributes(categoryIDs []string) ([]*Attribute, error)
+	CreateAttribute(attribute *Attribute) error
+	// DeleteAttribute removes the attribute and every product value for it
+	DeleteAttribute(categoryID, attributeID string) error
 }
@@ -0,0 +1,105 @@
+package giftcard
+
+import (
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// GiftCard is a prepaid balance redeemed with its code (pure domain entity)
+type GiftCard struct {
+	ID          string
+	Code        string // e.g. ABCD-EFGH-JKLM-NPQR, see NormalizeCode
+	Initial     money.Money
+	Balance     money.Money
+	OrderID     string // order the card was bought with; empty when issued by a manager
+	OrderLineID string
+	Email       string     // where the code was sent
+	VoidedAt    *time.Time // set when the purchase of the card was refunded
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// StoreCredit is a customer's balance in one currency, issued on refunds and
+// spent at checkout
+type StoreCredit struct {
+	ID        string
+	UserID    string
+	Balance   money.Money
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// EntryType classifies ledger entries
+type EntryType string
+
+const (
+	EntryIssue   EntryType = "issue"   // gift card bought or issued
+	EntryRedeem  EntryType = "redeem"  // spent on an order
+	EntryRelease EntryType = "release" // given back when an unpaid order is cancelled
+	EntryRefund  EntryType = "refund"  // store credit issued for a refund
+	EntryVoid    EntryType = "void"    // gift card taken back when its purchase is refunded
+	EntryRestore EntryType = "restore" // void undone when the refund failed
+)
+
+// Entry is one change to a gift card or store credit balance. Balances only
+// change together with an entry, so the ledger of an account always sums to
+// its balance.
+type Entry struct {
+	ID            string
+	GiftCardID    string // set for gift card entries
+	StoreCreditID string // set for store credit entries
+	Type          EntryType
+	Amount        money.Money // negative when spent
+	Balance       money.Money // balance after the entry
+	OrderID       string
+	RefundID      string
+	ActorID       string
+	CreatedAt     time.Time
+}
+
+// EntryFilters selects the ledger of a gift card or of a customer's store credit
+type EntryFilters struct {
+	GiftCardID string
+	UserID     string
+}
+
+// codeGroup is the length of the dash-separated groups of a code
+const codeGroup = 4
+
+// NormalizeCode returns a code as stored: upper case, in dash-separated
+// groups of four, whatever spacing and dashes the customer typed
+func NormalizeCode(code string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range strings.ToUpper(code) {
+		if r == '-' || r == ' ' {
+			continue
+		}
+		if n > 0 && n%codeGroup == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteRune(r)
+		n++
+	}
+	return b.String()
+}
+
+// IssuedNotice is the content of the email that delivers a gift card
+type IssuedNotice struct {
+	To      string
+	Code    string
+	Balance money.Money
+}
+
+// Notifier defines the interface for delivering gift card codes
+type Notifier interface {
+	NotifyIssued(notice IssuedNotice) error
+}
+
+// CodeGenerator defines the interface for generating gift card codes
+type CodeGenerator interface {
+	// Generate returns a new random code in the form of NormalizeCode
+	Generate() (string, error)
+}
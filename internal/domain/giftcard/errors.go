@@ -0,0 +1,30 @@
+package giftcard
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidCode indicates that no gift card has the code
+	ErrInvalidCode = apperrors.ErrInvalidGiftCard
+
+	// ErrEmpty indicates that a gift card has no balance left to redeem
+	ErrEmpty = apperrors.ErrGiftCardEmpty
+
+	// ErrCurrencyMismatch indicates that a gift card cannot pay for an order in another currency
+	ErrCurrencyMismatch = apperrors.ErrGiftCardCurrencyMismatch
+
+	// ErrPurchaseNotAllowed indicates that an order buying gift cards cannot be paid with a balance
+	ErrPurchaseNotAllowed = apperrors.ErrGiftCardPurchaseNotAllowed
+
+	// ErrOrderNotPaid indicates that the gift cards of an unpaid order cannot be issued yet
+	ErrOrderNotPaid = apperrors.ErrGiftCardOrderNotPaid
+
+	// ErrNotRefundable indicates a refund of gift cards that have been spent,
+	// or of gift cards outside a return of their lines
+	ErrNotRefundable = apperrors.ErrGiftCardNotRefundable
+
+	// ErrStoreCreditUnavailable indicates a store credit refund for a guest order
+	ErrStoreCreditUnavailable = apperrors.ErrStoreCreditUnavailable
+
+	// ErrNotFound indicates that the gift card does not exist
+	ErrNotFound = apperrors.ErrNotFound
+)
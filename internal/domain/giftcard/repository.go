@@ -0,0 +1,48 @@
+package giftcard
+
+import "github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+
+// Repository defines the interface for gift card, store credit and ledger
+// persistence. Every balance change is written together with its ledger
+// entry in one transaction, with the balance row locked.
+type Repository interface {
+	// CreateGiftCard stores the card with its initial balance and issue
+	// entry. It fails with ErrDuplicateEntry when the code is taken.
+	CreateGiftCard(card *GiftCard, actorID string) error
+
+	GetGiftCard(id string) (*GiftCard, error)
+	GetGiftCardByCode(code string) (*GiftCard, error)
+
+	// ListOrderGiftCards returns the gift cards bought with the order
+	ListOrderGiftCards(orderID string) ([]*GiftCard, error)
+
+	// ListStoreCredit returns the customer's store credit, one per currency
+	ListStoreCredit(userID string) ([]*StoreCredit, error)
+
+	// ListEntries returns a ledger, newest first
+	ListEntries(filters EntryFilters) ([]*Entry, error)
+
+	// Redeem spends up to amount on the order: from the gift cards in the
+	// order given, then from the user's store credit in the amount currency
+	// when userID is set. It adds what was spent to the order's redeemed
+	// amount and returns it. Balances are locked for the transaction, so
+	// concurrent redemptions never spend more than a balance holds.
+	Redeem(orderID string, giftCardIDs []string, userID string, amount money.Money) (money.Money, error)
+
+	// Release gives back everything redeemed on the order and not yet
+	// released; releasing again is a no-op
+	Release(orderID string) error
+
+	// Void takes the whole balance off gift cards bought with the order whose
+	// purchase is being refunded. It fails with ErrNotRefundable, voiding
+	// none, when any card has been spent or voided already.
+	Void(orderID string, giftCardIDs []string, refundID, actorID string) error
+
+	// Restore gives back what the refund voided, once the refund failed;
+	// restoring again is a no-op
+	Restore(refundID string) error
+
+	// Credit adds a refund to the user's store credit in the refund
+	// currency; crediting the same refund again is a no-op
+	Credit(userID string, amount money.Money, refundID, actorID string) error
+}
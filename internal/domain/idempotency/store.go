@@ -0,0 +1,41 @@
+package idempotency
+
+import "time"
+
+// Record is a request made with an idempotency key and, once it has
+// completed, the response to replay for retries of it
+type Record struct {
+	Key         string              // client key, scoped to the caller
+	Fingerprint string              // hash of the method, path and body
+	Status      int                 // response status; zero while in flight
+	Header      map[string][]string // response headers
+	Body        []byte              // response body
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+// InFlight reports whether the request has not completed yet
+func (r *Record) InFlight() bool {
+	return r.Status == 0
+}
+
+// Store defines the interface for persisting idempotency records
+type Store interface {
+	// Begin claims the key for an in-flight request. When the key is held by
+	// a record that has not expired, it returns that record and false instead.
+	Begin(record *Record) (*Record, bool, error)
+
+	// Extend moves the expiry of the in-flight request holding the key, so
+	// the key stays held while the request runs
+	Extend(key string, expiresAt time.Time) error
+
+	// Complete stores the response of the request holding the key
+	Complete(record *Record) error
+
+	// Release gives up the claim on a key whose request did not complete, so
+	// a retry can run it again
+	Release(key string) error
+
+	// DeleteExpired removes the records that expired before the time
+	DeleteExpired(before time.Time) error
+}
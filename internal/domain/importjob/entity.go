@@ -0,0 +1,71 @@
+package importjob
+
+import "time"
+
+// Status represents the lifecycle of a product import job
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Format is the encoding of an import file or export stream
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// ValidFormat reports whether the format is supported
+func ValidFormat(f Format) bool {
+	return f == FormatCSV || f == FormatNDJSON
+}
+
+// MaxRowErrors caps the row errors kept on a job; Failed still counts all of them
+const MaxRowErrors = 1000
+
+// RowError reports why a row was not imported. Row is the 1-based data row,
+// not counting the CSV header.
+type RowError struct {
+	Row     int
+	SKU     string
+	Message string
+}
+
+// Job tracks a bulk product import (pure domain entity). A dry run validates
+// every row and reports what would be created or updated without writing.
+type Job struct {
+	ID            string
+	Format        Format
+	DryRun        bool
+	Status        Status
+	TotalRows     int
+	ProcessedRows int
+	Created       int
+	Updated       int
+	Failed        int
+	Errors        []RowError
+	Message       string // reason a job failed as a whole
+	CreatedBy     string
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// AddError records a failed row
+func (j *Job) AddError(e RowError) {
+	j.Failed++
+	if len(j.Errors) < MaxRowErrors {
+		j.Errors = append(j.Errors, e)
+	}
+}
+
+// Finished reports whether the job has reached a terminal status
+func (j *Job) Finished() bool {
+	return j.Status == StatusCompleted || j.Status == StatusFailed
+}
@@ -0,0 +1,14 @@
+package importjob
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidFormat indicates an import or export format other than csv or ndjson
+	ErrInvalidFormat = apperrors.ErrInvalidImportFormat
+
+	// ErrInvalidFile indicates an upload that cannot be read as the given format
+	ErrInvalidFile = apperrors.ErrInvalidImportFile
+
+	// ErrNotFound indicates that the job does not exist
+	ErrNotFound = apperrors.ErrNotFound
+)
@@ -0,0 +1,17 @@
+package importjob
+
+import "time"
+
+// Repository defines the interface for import job persistence operations.
+// The uploaded file is stored with the job until it finishes.
+type Repository interface {
+	CreateJob(job *Job, payload []byte) error
+	GetJob(id string) (*Job, error)
+	// ClaimNext marks the oldest pending job, or a running job whose progress
+	// was last saved before staleBefore, as running and returns it with its
+	// file. It returns ErrNotFound when there is nothing to do.
+	ClaimNext(staleBefore time.Time) (*Job, []byte, error)
+	// SaveProgress stores counters, errors and status; the file is dropped once
+	// the job has finished
+	SaveProgress(job *Job) error
+}
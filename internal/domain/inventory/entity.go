@@ -0,0 +1,98 @@
+package inventory
+
+import "time"
+
+// MovementType classifies why stock changed
+type MovementType string
+
+const (
+	MovementReceipt     MovementType = "receipt"
+	MovementSale        MovementType = "sale"
+	MovementReturn      MovementType = "return"
+	MovementAdjustment  MovementType = "adjustment"
+	MovementReservation MovementType = "reservation"
+	MovementRelease     MovementType = "release"
+)
+
+// HoldTypes are the movement types that change the stock held for pending
+// checkouts rather than the stock on hand
+var HoldTypes = []MovementType{MovementReservation, MovementRelease}
+
+// Movement is an append-only stock ledger entry (pure domain entity).
+// Quantity is a signed delta of the stock on hand, or of the reserved stock
+// for HoldTypes; StockAfter is the on-hand level once applied.
+type Movement struct {
+	ID         string
+	ProductID  string
+	VariantID  string
+	Type       MovementType
+	Quantity   int
+	StockAfter int
+	Reason     string
+	ActorID    string
+	Reference  string
+	CreatedAt  time.Time
+}
+
+// LowStockItem is a product or variant at or below its low-stock threshold
+type LowStockItem struct {
+	ProductID string
+	VariantID string
+	Name      string
+	SKU       string
+	Stock     int
+	Threshold int
+}
+
+// LowStockAlert is emitted when a movement brings stock down to its threshold
+type LowStockAlert struct {
+	ProductID string
+	VariantID string
+	Stock     int
+	Threshold int
+}
+
+// ReconcileResult reports the stock level before and after reconciling with the ledger
+type ReconcileResult struct {
+	ProductID string
+	VariantID string
+	Before    int
+	After     int
+}
+
+// IsValid reports whether the movement type is known
+func (t MovementType) IsValid() bool {
+	switch t {
+	case MovementReceipt, MovementSale, MovementReturn, MovementAdjustment, MovementReservation, MovementRelease:
+		return true
+	}
+	return false
+}
+
+// CrossedThreshold reports whether a stock change went from above the threshold to at or below it
+func CrossedThreshold(before, after, threshold int) bool {
+	return before > threshold && after <= threshold
+}
+
+// ReservationStatus represents the lifecycle state of a stock reservation
+type ReservationStatus string
+
+const (
+	ReservationActive    ReservationStatus = "active"
+	ReservationConverted ReservationStatus = "converted"
+	ReservationReleased  ReservationStatus = "released"
+)
+
+// Reservation holds stock for a pending order until it is paid or expires.
+// Reserved stock stays on hand but is no longer available to sell.
+type Reservation struct {
+	ID        string
+	ProductID string
+	VariantID string
+	OrderID   string
+	Quantity  int
+	Status    ReservationStatus
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,8 @@
+package inventory
+
+// Filters represents filtering criteria for movement queries (domain value object)
+type Filters struct {
+	ProductID string
+	VariantID string
+	Type      MovementType
+}
@@ -0,0 +1,41 @@
+package inventory
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+)
+
+// Repository defines the interface for stock ledger persistence operations
+type Repository interface {
+	// RecordMovement appends the movement and applies its delta to on-hand stock
+	// atomically, filling in StockAfter. Stock may never go negative, and a
+	// decrement may not take stock below what is reserved.
+	RecordMovement(movement *Movement) error
+	ListMovements(params pagination.Params, filters Filters) ([]*Movement, int64, error)
+	// Reconcile resets on-hand stock to the sum of the ledger
+	Reconcile(productID, variantID string) (*ReconcileResult, error)
+	SetLowStockThreshold(productID, variantID string, threshold *int) error
+	GetLowStockThreshold(productID, variantID string) (*int, error)
+	ListLowStock(defaultThreshold int) ([]*LowStockItem, error)
+}
+
+// ReservationRepository defines the interface for stock reservation operations
+type ReservationRepository interface {
+	// Reserve holds stock for every reservation or none of them, recording a
+	// reservation movement for each
+	Reserve(reservations []*Reservation) error
+	// ConvertReservations turns an order's active reservations into sale
+	// movements, recording the release of the hold before each sale
+	ConvertReservations(orderID string) ([]*Movement, error)
+	// ReleaseReservations returns an order's active reservations to available
+	// stock, recording a release movement for each
+	ReleaseReservations(orderID string) error
+	// ListExpiredOrderIDs returns orders holding active reservations past their expiry
+	ListExpiredOrderIDs(now time.Time) ([]string, error)
+}
+
+// LowStockNotifier defines the interface for delivering low-stock alerts
+type LowStockNotifier interface {
+	NotifyLowStock(alert LowStockAlert) error
+}
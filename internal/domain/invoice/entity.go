@@ -0,0 +1,76 @@
+package invoice
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/order"
+)
+
+// Type distinguishes invoices from the credit notes that correct them
+type Type string
+
+const (
+	TypeInvoice    Type = "invoice"
+	TypeCreditNote Type = "credit_note"
+)
+
+// Prefix returns the number prefix of the document type
+func (t Type) Prefix() string {
+	if t == TypeCreditNote {
+		return "CN"
+	}
+	return "INV"
+}
+
+// Document is a numbered invoice or credit note (pure domain entity). An
+// order has one invoice; each succeeded refund has one credit note.
+type Document struct {
+	ID        string
+	Type      Type
+	Number    string // e.g. INV-2026-000042
+	OrderID   string
+	RefundID  string // credit notes only
+	Amount    money.Money
+	BlobKey   string // where the rendered PDF is stored; empty until it is
+	IssuedAt  time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Series returns the numbering series of a document. Each type is numbered
+// separately and numbering restarts every calendar year.
+func Series(t Type, issuedAt time.Time) string {
+	return fmt.Sprintf("%s-%d", t.Prefix(), issuedAt.Year())
+}
+
+// FormatNumber returns the document number for a position in a series
+func FormatNumber(series string, sequence int64) string {
+	return fmt.Sprintf("%s-%06d", series, sequence)
+}
+
+// Party is the seller printed on documents
+type Party struct {
+	Name    string
+	Address []string
+	TaxID   string
+}
+
+// Content is everything a document shows
+type Content struct {
+	Document *Document
+	Seller   Party
+	Order    *order.Order
+	Refund   *order.Refund // credit notes only
+	Invoice  *Document     // invoice a credit note corrects
+}
+
+// CreditedTax returns the share of the order's tax in a refunded amount
+func CreditedTax(o *order.Order, amount money.Money) money.Money {
+	if o.Total.Amount == 0 {
+		return money.Zero(amount.Currency)
+	}
+	return amount.MulRat(big.NewRat(o.Tax.Amount, o.Total.Amount))
+}
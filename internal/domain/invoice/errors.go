@@ -0,0 +1,14 @@
+package invoice
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrNotInvoiceable indicates that the order has not been paid, so it has no invoice
+	ErrNotInvoiceable = apperrors.ErrOrderNotInvoiceable
+
+	// ErrRenderFailed indicates that a document could not be rendered or stored
+	ErrRenderFailed = apperrors.ErrDocumentRenderFailed
+
+	// ErrNotFound indicates that the document does not exist or is not visible
+	ErrNotFound = apperrors.ErrNotFound
+)
@@ -0,0 +1,6 @@
+package invoice
+
+// Renderer defines the interface for turning document content into a PDF
+type Renderer interface {
+	Render(content Content) ([]byte, error)
+}
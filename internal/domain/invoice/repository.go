@@ -0,0 +1,16 @@
+package invoice
+
+// Repository defines the interface for document persistence operations
+type Repository interface {
+	// Issue numbers and stores the document. The next number of its series
+	// is taken in the same transaction as the document is written, so
+	// numbers are sequential and gap-free under concurrency. When the order
+	// already has an invoice, or the refund a credit note, the existing
+	// document is loaded into doc instead and Issue returns false.
+	Issue(doc *Document) (bool, error)
+
+	GetDocument(id string) (*Document, error)
+	GetInvoice(orderID string) (*Document, error)
+	ListDocuments(orderID string) ([]*Document, error)
+	SetBlobKey(id, key string) error
+}
@@ -0,0 +1,14 @@
+package money
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidAmount indicates that an amount is not a valid decimal for its currency
+	ErrInvalidAmount = apperrors.ErrInvalidAmount
+
+	// ErrInvalidCurrency indicates that a currency is not a three-letter ISO 4217 code
+	ErrInvalidCurrency = apperrors.ErrInvalidCurrency
+
+	// ErrCurrencyMismatch indicates arithmetic between amounts in different currencies
+	ErrCurrencyMismatch = apperrors.ErrCurrencyMismatch
+)
@@ -0,0 +1,206 @@
+package money
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in integer minor units (e.g. cents) of an ISO 4217 currency
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// exponents lists currencies whose minor unit is not 1/100
+var exponents = map[string]int{
+	"BHD": 3, "CLP": 0, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0,
+	"KWD": 3, "OMR": 3, "TND": 3, "UGX": 0, "VND": 0, "XAF": 0, "XOF": 0,
+}
+
+// New returns an amount of minor units in the currency
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// Zero returns a zero amount in the currency
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// Exponent returns the number of decimal places of the currency's minor unit
+func Exponent(currency string) int {
+	if e, ok := exponents[strings.ToUpper(currency)]; ok {
+		return e
+	}
+	return 2
+}
+
+// Parse reads a decimal amount such as "19.99". More decimal places than the
+// currency has are rejected rather than rounded.
+func Parse(amount, currency string) (Money, error) {
+	if len(currency) != 3 {
+		return Money{}, ErrInvalidCurrency
+	}
+
+	s := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	exp := Exponent(currency)
+	if whole == "" || len(frac) > exp || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, ErrInvalidAmount
+	}
+
+	minor, err := strconv.ParseInt(whole+frac+strings.Repeat("0", exp-len(frac)), 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidAmount
+	}
+	if negative {
+		minor = -minor
+	}
+
+	return New(minor, currency), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the amount as a decimal without the currency (e.g. "19.99")
+func (m Money) String() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	exp := Exponent(m.Currency)
+	if exp == 0 {
+		return sign + strconv.FormatInt(amount, 10)
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	cut := len(digits) - exp
+
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+// Add returns m + o; both amounts must be in the same currency
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+// Sub returns m - o; both amounts must be in the same currency
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount - o.Amount, Currency: m.Currency}, nil
+}
+
+// Mul returns the amount multiplied by a quantity
+func (m Money) Mul(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Convert returns the amount in another currency at rate (units of the target
+// currency per unit of m's currency), rounded half away from zero to the
+// target's minor unit
+func (m Money) Convert(currency string, rate *big.Rat) Money {
+	currency = strings.ToUpper(currency)
+
+	scaled := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), rate)
+	scaled.Mul(scaled, new(big.Rat).SetInt(pow10(Exponent(currency))))
+	scaled.Quo(scaled, new(big.Rat).SetInt(pow10(Exponent(m.Currency))))
+
+	return Money{Amount: roundHalfAway(scaled), Currency: currency}
+}
+
+// MulRat returns the amount multiplied by a ratio (e.g. a tax rate), rounded
+// half away from zero to the minor unit
+func (m Money) MulRat(ratio *big.Rat) Money {
+	scaled := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), ratio)
+	return Money{Amount: roundHalfAway(scaled), Currency: m.Currency}
+}
+
+// roundHalfAway rounds a ratio to the nearest integer, halves away from zero
+func roundHalfAway(r *big.Rat) int64 {
+	num, den := r.Num(), r.Denom()
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(den) >= 0 {
+		if num.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo.Int64()
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative reports whether the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Sum adds the amounts, starting from zero in the given currency
+func Sum(currency string, amounts ...Money) (Money, error) {
+	total := Zero(currency)
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+type jsonMoney struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes the amount as a decimal string so clients never see
+// binary floating point values
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.String(), Currency: m.Currency})
+}
+
+// UnmarshalJSON decodes {"amount": "19.99", "currency": "USD"}
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var j jsonMoney
+	if err := json.Unmarshal(data, &j); err != nil {
+		return ErrInvalidAmount
+	}
+
+	parsed, err := Parse(j.Amount, j.Currency)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
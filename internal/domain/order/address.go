@@ -0,0 +1,40 @@
+package order
+
+import (
+	"strings"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/address"
+)
+
+// Address is a postal address, snapshotted on orders so later changes to the
+// customer's address book do not alter placed orders
+type Address struct {
+	Name       string
+	Line1      string
+	Line2      string
+	City       string
+	Region     string // state or province
+	PostalCode string
+	Country    string // ISO 3166-1 alpha-2
+	Phone      string
+}
+
+// Normalize trims the fields and upper-cases the country and postal codes
+func (a *Address) Normalize() {
+	a.Name = strings.TrimSpace(a.Name)
+	a.Line1 = strings.TrimSpace(a.Line1)
+	a.Line2 = strings.TrimSpace(a.Line2)
+	a.City = strings.TrimSpace(a.City)
+	a.Region = strings.TrimSpace(a.Region)
+	a.PostalCode = strings.ToUpper(strings.TrimSpace(a.PostalCode))
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+	a.Phone = strings.TrimSpace(a.Phone)
+}
+
+// Validate checks that the address can be shipped to
+func (a *Address) Validate() error {
+	if a.Name == "" || a.Line1 == "" || a.City == "" || !address.ValidCountry(a.Country) {
+		return ErrInvalidAddress
+	}
+	return address.ValidatePostalCode(a.Country, a.PostalCode)
+}
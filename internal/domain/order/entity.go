@@ -0,0 +1,186 @@
+package order
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// Status represents the lifecycle state of an order
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusPaid      Status = "paid"
+	StatusShipped   Status = "shipped"
+	StatusDelivered Status = "delivered"
+	StatusCancelled Status = "cancelled"
+
+	// StatusPartiallyRefunded and StatusRefunded replace the fulfilment status
+	// once part or all of the total has been given back
+	StatusPartiallyRefunded Status = "partially_refunded"
+	StatusRefunded          Status = "refunded"
+)
+
+// PaidStatuses are the statuses of orders that have been paid for and not
+// fully refunded
+var PaidStatuses = []Status{StatusPaid, StatusShipped, StatusDelivered, StatusPartiallyRefunded}
+
+// Paid reports whether an order in this status has been paid for
+func (s Status) Paid() bool {
+	for _, paid := range PaidStatuses {
+		if s == paid {
+			return true
+		}
+	}
+	return false
+}
+
+// Fulfilment tracks shipping separately from Status, which refunds replace,
+// so a partially refunded order still records its shipments and delivery
+type Fulfilment string
+
+const (
+	FulfilmentUnfulfilled Fulfilment = "unfulfilled"
+	FulfilmentShipped     Fulfilment = "shipped"
+	FulfilmentDelivered   Fulfilment = "delivered"
+)
+
+// Order represents a placed order (pure domain entity)
+type Order struct {
+	ID              string
+	UserID          string // empty for guest orders
+	Email           string // contact address of a guest order
+	Status          Status
+	Fulfilment      Fulfilment
+	Subtotal        money.Money // line amounts before discounts
+	Discount        money.Money
+	Tax             money.Money
+	TaxInclusive    bool // line prices already include Tax
+	Shipping        money.Money
+	Total           money.Money // amount charged
+	Redeemed        money.Money // part of Total paid with gift cards and store credit
+	Refunded        money.Money // amount given back by succeeded refunds
+	DiscountCode    string
+	ShippingAddress Address
+	BillingAddress  Address
+	ShippingMethod  string // name of the chosen shipping option
+	PaymentID       string
+	Lines           []Line
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Line is an order line; product details are snapshotted at purchase time
+type Line struct {
+	ID        string
+	OrderID   string
+	ProductID string
+	VariantID string
+	SKU       string
+	Name      string
+	UnitPrice money.Money
+	Discount  money.Money // promotions taken off the line amount
+	Tax       money.Money // tax on the discounted line amount
+	TaxRate   string      // percentage applied, e.g. "7.25"
+	Quantity  int
+	GiftCard  bool // each unit is a gift card, see CardValues
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CalculateTotal sums the line amounts, discounts and taxes into Subtotal,
+// Discount, Tax and Total, keeping the currency already set on Total. Shipping
+// is added to Total, and so is Tax unless prices include it.
+func (o *Order) CalculateTotal() error {
+	currency := o.Total.Currency
+	amounts := make([]money.Money, len(o.Lines))
+	discounts := make([]money.Money, len(o.Lines))
+	taxes := make([]money.Money, len(o.Lines))
+	for i := range o.Lines {
+		amounts[i] = o.Lines[i].UnitPrice.Mul(o.Lines[i].Quantity)
+		discounts[i] = o.Lines[i].Discount
+		taxes[i] = o.Lines[i].Tax
+	}
+
+	subtotal, err := money.Sum(currency, amounts...)
+	if err != nil {
+		return err
+	}
+	discount, err := money.Sum(currency, discounts...)
+	if err != nil {
+		return err
+	}
+	tax, err := money.Sum(currency, taxes...)
+	if err != nil {
+		return err
+	}
+
+	total, err := subtotal.Sub(discount)
+	if err != nil {
+		return err
+	}
+	if o.Shipping.Currency != "" {
+		if total, err = total.Add(o.Shipping); err != nil {
+			return err
+		}
+	}
+	if !o.TaxInclusive {
+		if total, err = total.Add(tax); err != nil {
+			return err
+		}
+	}
+
+	o.Subtotal = subtotal
+	o.Discount = discount
+	o.Tax = tax
+	o.Total = total
+	return nil
+}
+
+// CardAmount returns the part of the total paid through the payment provider
+func (o *Order) CardAmount() money.Money {
+	return money.Money{Amount: o.Total.Amount - o.Redeemed.Amount, Currency: o.Total.Currency}
+}
+
+// HasGiftCards reports whether the order buys gift cards
+func (o *Order) HasGiftCards() bool {
+	for i := range o.Lines {
+		if o.Lines[i].GiftCard {
+			return true
+		}
+	}
+	return false
+}
+
+// Refundable returns what is left of the total once succeeded refunds are taken off
+func (o *Order) Refundable() money.Money {
+	return money.Money{Amount: o.Total.Amount - o.Refunded.Amount, Currency: o.Total.Currency}
+}
+
+// LineTotal returns the line amount less its discount, before tax
+func (l *Line) LineTotal() money.Money {
+	return money.Money{Amount: l.UnitPrice.Mul(l.Quantity).Amount - l.Discount.Amount, Currency: l.UnitPrice.Currency}
+}
+
+// CardValues returns what each gift card of a gift card line is worth: its
+// share of the discounted line amount, so a promotion lowers the value issued
+// rather than giving it away. Minor units that do not split evenly go to the
+// first cards.
+func (l *Line) CardValues() []money.Money {
+	if l.Quantity <= 0 {
+		return nil
+	}
+
+	total := l.LineTotal().Amount
+	share, rest := total/int64(l.Quantity), total%int64(l.Quantity)
+
+	values := make([]money.Money, l.Quantity)
+	for i := range values {
+		values[i] = money.New(share, l.UnitPrice.Currency)
+		if int64(i) < rest {
+			values[i].Amount++
+		}
+	}
+	return values
+}
@@ -0,0 +1,17 @@
+package order
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidAddress indicates that an address is missing required fields
+	ErrInvalidAddress = apperrors.ErrInvalidAddress
+
+	// ErrNotShippable indicates that the order is not paid, so it cannot be shipped
+	ErrNotShippable = apperrors.ErrOrderNotShippable
+
+	// ErrNotRefundable indicates that the order has not been paid or is already fully refunded
+	ErrNotRefundable = apperrors.ErrOrderNotRefundable
+
+	// ErrRefundExceedsTotal indicates that a refund would give back more than the order total
+	ErrRefundExceedsTotal = apperrors.ErrRefundExceedsTotal
+)
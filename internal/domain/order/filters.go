@@ -0,0 +1,7 @@
+package order
+
+// Filters represents filtering criteria for order queries (domain value object)
+type Filters struct {
+	UserID string
+	Status Status
+}
@@ -0,0 +1,49 @@
+package order
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// RefundStatus represents the state of a refund with the payment provider
+type RefundStatus string
+
+const (
+	RefundPending   RefundStatus = "pending"
+	RefundSucceeded RefundStatus = "succeeded"
+	RefundFailed    RefundStatus = "failed"
+)
+
+// Refund is money given back on a paid order, in full or in part
+type Refund struct {
+	ID          string
+	OrderID     string
+	ReturnID    string // empty for refunds not tied to a return
+	Amount      money.Money
+	StoreCredit money.Money // part of Amount issued as store credit rather than through the provider
+	Reason      string
+	Status      RefundStatus
+	ProviderID  string // refund ID at the payment provider
+	ActorID     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// RefundRepository defines the interface for refund persistence operations
+type RefundRepository interface {
+	ListRefunds(orderID string) ([]*Refund, error)
+	GetRefund(id string) (*Refund, error)
+
+	// CreateRefund stores a pending refund. It fails with ErrRefundExceedsTotal
+	// when the refund, together with the order's pending and succeeded refunds,
+	// would give back more than the order total.
+	CreateRefund(refund *Refund) error
+
+	// CompleteRefund marks a pending refund succeeded, adds it to the order's
+	// refunded amount and moves the order to refunded or partially refunded
+	CompleteRefund(id, providerID string) error
+
+	// FailRefund marks a pending refund failed so its amount can be refunded again
+	FailRefund(id string) error
+}
@@ -0,0 +1,15 @@
+package order
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+
+// Repository defines the interface for order persistence operations
+type Repository interface {
+	ListOrders(params pagination.Params, filters Filters) ([]*Order, int64, error)
+	GetOrder(id string) (*Order, error)
+	CreateOrder(order *Order) error
+	UpdateOrderStatus(id string, status Status) error
+	SetPaymentID(id, paymentID string) error
+	// TransitionStatus moves the order to the new status only if it is currently in from
+	TransitionStatus(id string, from, to Status) (bool, error)
+	SetFulfilment(id string, fulfilment Fulfilment) error
+}
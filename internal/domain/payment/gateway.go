@@ -0,0 +1,49 @@
+package payment
+
+import "github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+
+// Intent represents a payment created with the provider for an order
+type Intent struct {
+	ID           string
+	ClientSecret string
+	Amount       money.Money
+}
+
+// RefundResult is a refund accepted by the provider
+type RefundResult struct {
+	ID string
+}
+
+// EventType classifies payment provider notifications
+type EventType string
+
+const (
+	EventSucceeded EventType = "succeeded"
+	EventFailed    EventType = "failed"
+	EventIgnored   EventType = "ignored"
+)
+
+// Event is a verified payment provider notification
+type Event struct {
+	ID        string
+	Type      EventType
+	PaymentID string
+	OrderID   string
+	Amount    money.Money // captured amount, set for succeeded payments
+}
+
+// Gateway defines the interface for the payment provider
+type Gateway interface {
+	// CreateIntent starts a payment for the order amount
+	CreateIntent(orderID string, amount money.Money) (*Intent, error)
+
+	// CancelIntent cancels a payment that has not been captured yet
+	CancelIntent(paymentID string) error
+
+	// ParseWebhook verifies the signature and decodes a provider notification
+	ParseWebhook(payload []byte, signature string) (*Event, error)
+
+	// Refund gives back part or all of a captured payment. Requests repeated
+	// with the same idempotency key are only refunded once.
+	Refund(paymentID string, amount money.Money, idempotencyKey string) (*RefundResult, error)
+}
@@ -0,0 +1,64 @@
+package pricing
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// PriceList holds manager-set prices in one currency, optionally limited to a
+// customer group. Products without an entry fall back to the converted base price.
+type PriceList struct {
+	ID            string
+	Name          string
+	Currency      string
+	CustomerGroup string // empty applies to every customer
+	Prices        []Price
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Price overrides the price of a product, or of one of its variants, in a price list
+type Price struct {
+	ID          string
+	PriceListID string
+	ProductID   string
+	VariantID   string
+	Amount      money.Money
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ExchangeRate converts amounts from Base to Quote: 1 Base = Rate Quote
+type ExchangeRate struct {
+	Base      string
+	Quote     string
+	Rate      *big.Rat
+	UpdatedAt time.Time
+}
+
+// Selection is the currency and customer group prices are resolved for
+type Selection struct {
+	Currency      string
+	CustomerGroup string
+}
+
+// FindPrice returns the list's entry for the variant, falling back to the
+// product-wide entry, or nil
+func (pl *PriceList) FindPrice(productID, variantID string) *Price {
+	var productWide *Price
+	for i := range pl.Prices {
+		p := &pl.Prices[i]
+		if p.ProductID != productID {
+			continue
+		}
+		if variantID != "" && p.VariantID == variantID {
+			return p
+		}
+		if p.VariantID == "" {
+			productWide = p
+		}
+	}
+	return productWide
+}
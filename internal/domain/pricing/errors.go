@@ -0,0 +1,17 @@
+package pricing
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrUnsupportedCurrency indicates that prices cannot be shown in the requested currency
+	ErrUnsupportedCurrency = apperrors.ErrUnsupportedCurrency
+
+	// ErrInvalidExchangeRate indicates that a rate is not a positive decimal
+	ErrInvalidExchangeRate = apperrors.ErrInvalidExchangeRate
+
+	// ErrRatesReadOnly indicates that rates come from a source managers cannot write to
+	ErrRatesReadOnly = apperrors.ErrExchangeRatesReadOnly
+
+	// ErrRateNotFound indicates that no exchange rate is configured for the currency pair
+	ErrRateNotFound = apperrors.ErrNotFound
+)
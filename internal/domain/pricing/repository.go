@@ -0,0 +1,28 @@
+package pricing
+
+// Repository defines the interface for price list persistence operations
+type Repository interface {
+	CreatePriceList(pl *PriceList) error
+	GetPriceList(id string) (*PriceList, error)
+	ListPriceLists() ([]*PriceList, error)
+	SetPrice(price *Price) error
+	DeletePrice(priceListID, productID, variantID string) error
+
+	// FindPriceLists returns the lists in the currency that apply to the
+	// customer group (group-specific lists first, then lists for everyone),
+	// each loaded with the entries of the products only
+	FindPriceLists(productIDs []string, currency, customerGroup string) ([]*PriceList, error)
+}
+
+// RateProvider supplies exchange rates for currency conversion
+type RateProvider interface {
+	// GetRate returns the rate from base to quote, or ErrRateNotFound
+	GetRate(base, quote string) (*ExchangeRate, error)
+}
+
+// RateRepository is a RateProvider whose rates are maintained by managers
+type RateRepository interface {
+	RateProvider
+	SetRate(rate *ExchangeRate) error
+	ListRates() ([]*ExchangeRate, error)
+}
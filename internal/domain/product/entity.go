@@ -1,26 +1,238 @@
 package product
 
-import "time"
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/category"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/seo"
+)
 
 // Product represents a product in the system (pure domain entity)
 type Product struct {
-	ID         string
-	Name       string
-	Price      float64
-	Disabled   bool
-	Stock      int
-	CategoryID string
-	Images     []ProductImage
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID                string
+	SKU               string // optional; the key bulk imports upsert by
+	Name              string
+	Slug              string
+	SEO               seo.Metadata
+	Description       string // sanitized HTML
+	Brand             string
+	TaxClass          string // selects the tax rates that apply, see DefaultTaxClass
+	WeightGrams       int    // 0 when unknown
+	GiftCard          bool   // sold as gift cards worth the price paid, issued once paid
+	Dimensions        Dimensions
+	Price             money.Money
+	Sale              *Sale
+	CompareAtPrice    *money.Money // regular price while a sale lowers Price; set on reads
+	Disabled          bool
+	Stock             int  // on hand
+	Reserved          int  // held by pending checkouts
+	Available         int  // Stock - Reserved
+	LowStockThreshold *int // overrides the store-wide threshold when set
+	LikeCount         int
+	RatingCount       int     // approved reviews
+	RatingAverage     float64 // mean star rating of approved reviews, 0 when unrated
+	CategoryID        string
+	Breadcrumbs       []category.Breadcrumb // root-to-leaf path of CategoryID, set on reads
+	Specifications    []Specification
+	Images            []ProductImage
+	Options           []OptionType
+	Variants          []Variant
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// DefaultTaxClass is the tax class of products that do not set one
+const DefaultTaxClass = "standard"
+
+// Dimensions is the packed size of a product in millimetres; zero when unknown
+type Dimensions struct {
+	LengthMM int
+	WidthMM  int
+	HeightMM int
+}
+
+// Specification is a product's value for one of its category attributes
+type Specification struct {
+	AttributeID string
+	Key         string
+	Label       string
+	Type        category.AttributeType
+	Unit        string
+	Value       string // canonical text form, see category.Attribute.Normalize
+	NumberValue *float64
+}
+
+// SitemapEntry is an enabled product as listed in the XML sitemap
+type SitemapEntry struct {
+	Slug         string
+	CanonicalURL string
+	UpdatedAt    time.Time
 }
 
 // ProductImage represents an image associated with a product
 type ProductImage struct {
 	ID        string
 	ProductID string
+	VariantID string
 	URL       string
 	AltText   string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// Like records that a user added a product to their wishlist
+type Like struct {
+	ID        string
+	UserID    string
+	ProductID string
+	Product   *Product
+	CreatedAt time.Time
+}
+
+// OptionType is a dimension a product varies on (e.g. Size, Color)
+type OptionType struct {
+	ID        string
+	ProductID string
+	Name      string
+	Position  int
+	Values    []OptionValue
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OptionValue is one choice of an option type (e.g. "M" for Size)
+type OptionValue struct {
+	ID           string
+	OptionTypeID string
+	Value        string
+	Position     int
+}
+
+// Variant is a purchasable combination of option values with its own SKU and stock
+type Variant struct {
+	ID                string
+	ProductID         string
+	SKU               string
+	Price             *money.Money // overrides Product.Price when set
+	CompareAtPrice    *money.Money // regular price while a sale lowers Price; set on reads
+	Stock             int
+	Reserved          int
+	Available         int
+	LowStockThreshold *int
+	Disabled          bool
+	OptionValues      []OptionValue
+	Images            []ProductImage
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// HasVariants reports whether the product is sold through variants
+func (p *Product) HasVariants() bool {
+	return len(p.Variants) > 0
+}
+
+// FindVariant returns the variant with the given ID, or nil
+func (p *Product) FindVariant(id string) *Variant {
+	for i := range p.Variants {
+		if p.Variants[i].ID == id {
+			return &p.Variants[i]
+		}
+	}
+	return nil
+}
+
+// UnitPrice returns the variant price override or the product price
+func (p *Product) UnitPrice(v *Variant) money.Money {
+	if v != nil && v.Price != nil {
+		return *v.Price
+	}
+	return p.Price
+}
+
+// ValidateVariantOptions checks that the option values pick exactly one value
+// per option type and that no other variant already uses the same combination
+func (p *Product) ValidateVariantOptions(optionValueIDs []string) error {
+	if len(optionValueIDs) != len(p.Options) {
+		return ErrInvalidVariantOptions
+	}
+
+	selected := make(map[string]bool, len(optionValueIDs))
+	for _, ot := range p.Options {
+		matches := 0
+		for _, v := range ot.Values {
+			for _, id := range optionValueIDs {
+				if v.ID == id {
+					matches++
+					selected[id] = true
+				}
+			}
+		}
+		if matches != 1 {
+			return ErrInvalidVariantOptions
+		}
+	}
+
+	for _, existing := range p.Variants {
+		same := len(existing.OptionValues) == len(selected)
+		for _, v := range existing.OptionValues {
+			if !selected[v.ID] {
+				same = false
+				break
+			}
+		}
+		if same {
+			return ErrDuplicateVariant
+		}
+	}
+
+	return nil
+}
+
+// CheckPurchasable validates a product/variant selection against available stock
+func (p *Product) CheckPurchasable(variantID string, quantity int) (*Variant, error) {
+	if p.Disabled {
+		return nil, ErrUnavailable
+	}
+
+	if !p.HasVariants() {
+		if variantID != "" {
+			return nil, ErrVariantNotFound
+		}
+		if quantity > p.Available {
+			return nil, ErrInsufficientStock
+		}
+		return nil, nil
+	}
+
+	if variantID == "" {
+		return nil, ErrVariantRequired
+	}
+
+	v := p.FindVariant(variantID)
+	if v == nil {
+		return nil, ErrVariantNotFound
+	}
+	if v.Disabled {
+		return nil, ErrUnavailable
+	}
+	if quantity > v.Available {
+		return nil, ErrInsufficientStock
+	}
+
+	return v, nil
+}
+
+// AvailableQuantity returns the stock available of the product, or of the
+// variant for products with variants; zero when the variant does not exist
+func (p *Product) AvailableQuantity(variantID string) int {
+	if !p.HasVariants() {
+		return p.Available
+	}
+
+	v := p.FindVariant(variantID)
+	if v == nil {
+		return 0
+	}
+	return v.Available
+}
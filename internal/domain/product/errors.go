@@ -0,0 +1,42 @@
+package product
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrVariantRequired indicates that a product with variants was referenced without one
+	ErrVariantRequired = apperrors.ErrVariantRequired
+
+	// ErrInvalidVariantOptions indicates that a variant does not pick exactly one value per option type
+	ErrInvalidVariantOptions = apperrors.ErrInvalidVariantOptions
+
+	// ErrDuplicateVariant indicates that another variant already uses the same option values
+	ErrDuplicateVariant = apperrors.ErrDuplicateVariant
+
+	// ErrVariantNotFound indicates that the variant does not exist or belongs to another product
+	ErrVariantNotFound = apperrors.ErrNotFound
+
+	// ErrUnavailable indicates that the product or variant is disabled
+	ErrUnavailable = apperrors.ErrProductUnavailable
+
+	// ErrUnsupportedSort indicates a sort order that cannot be combined with cursor pagination
+	ErrUnsupportedSort = apperrors.ErrUnsupportedSort
+
+	// ErrSlugTaken indicates a slug that another product uses now or used before
+	ErrSlugTaken = apperrors.ErrSlugTaken
+
+	// ErrInvalidMeasurement indicates a negative weight or dimension
+	ErrInvalidMeasurement = apperrors.ErrInvalidMeasurement
+
+	// ErrInvalidSale indicates a sale price that is not below the regular price
+	// or a sale that ends before it starts
+	ErrInvalidSale = apperrors.ErrInvalidSale
+
+	// ErrInvalidPriceSchedule indicates a scheduled price change that is not in the future
+	ErrInvalidPriceSchedule = apperrors.ErrInvalidPriceSchedule
+
+	// ErrNotFound indicates that the product does not exist
+	ErrNotFound = apperrors.ErrNotFound
+
+	// ErrInsufficientStock indicates that not enough stock is available to sell
+	ErrInsufficientStock = apperrors.ErrInsufficientStock
+)
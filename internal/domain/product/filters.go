@@ -1,9 +1,33 @@
 package product
 
+import "github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+
+// Sort selects the order of product listings
+type Sort string
+
+const (
+	SortNewest    Sort = "newest"
+	SortMostLiked Sort = "most_liked"
+	SortTopRated  Sort = "top_rated"
+)
+
 // Filters represents filtering criteria for product queries (domain value object)
 type Filters struct {
-	CategoryID string
-	MinPrice   *float64
-	MaxPrice   *float64
+	CategoryID string // matches the category and all of its descendants
+	MinPrice   *money.Money
+	MaxPrice   *money.Money
 	Disabled   *bool
+	MinRating  *float64
+	Brand      string // case-insensitive exact match
+	Attributes []AttributeFilter
+	Sort       Sort
+}
+
+// AttributeFilter matches products by a specification value. Value is an exact,
+// case-insensitive match; Min and Max bound number attributes.
+type AttributeFilter struct {
+	Key   string
+	Value *string
+	Min   *float64
+	Max   *float64
 }
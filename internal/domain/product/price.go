@@ -0,0 +1,104 @@
+package product
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// Sale temporarily lowers the product price. A nil StartsAt applies from now
+// on and a nil EndsAt until the sale is removed. The price is in the store
+// currency; it only applies to the product price and variants that inherit it.
+type Sale struct {
+	Price    money.Money
+	StartsAt *time.Time
+	EndsAt   *time.Time
+}
+
+// Active reports whether the sale applies at the given time
+func (s *Sale) Active(now time.Time) bool {
+	if s == nil {
+		return false
+	}
+	if s.StartsAt != nil && now.Before(*s.StartsAt) {
+		return false
+	}
+	if s.EndsAt != nil && !now.Before(*s.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// ValidateSale checks that the sale price is below the regular price and that
+// the sale ends after it starts
+func (p *Product) ValidateSale() error {
+	if p.Sale == nil {
+		return nil
+	}
+
+	s := p.Sale
+	if s.Price.IsNegative() || s.Price.Currency != p.Price.Currency || s.Price.Amount >= p.Price.Amount {
+		return ErrInvalidSale
+	}
+	if s.StartsAt != nil && s.EndsAt != nil && !s.EndsAt.After(*s.StartsAt) {
+		return ErrInvalidSale
+	}
+	return nil
+}
+
+// EffectiveUnitPrice returns the price charged at the given time: the variant
+// price override, otherwise the sale price while a sale applies, otherwise the
+// product price. A regular price lowered below the sale price wins.
+func (p *Product) EffectiveUnitPrice(v *Variant, now time.Time) money.Money {
+	if v != nil && v.Price != nil {
+		return *v.Price
+	}
+	if p.Sale.Active(now) && p.Sale.Price.Amount < p.Price.Amount {
+		return p.Sale.Price
+	}
+	return p.Price
+}
+
+// PriceChangeSource identifies what changed a product's price
+type PriceChangeSource string
+
+const (
+	PriceChangeManual   PriceChangeSource = "manual"
+	PriceChangeImport   PriceChangeSource = "import"
+	PriceChangeSchedule PriceChangeSource = "schedule"
+)
+
+// PriceChange is an entry in a product's price history, recorded whenever the
+// regular price or the sale changes
+type PriceChange struct {
+	ID            string
+	ProductID     string
+	Price         money.Money  // regular price after the change
+	PreviousPrice *money.Money // nil for the price a product was created with
+	Sale          *Sale        // sale after the change
+	Source        PriceChangeSource
+	ScheduleID    string // set when Source is PriceChangeSchedule
+	CreatedAt     time.Time
+}
+
+// ScheduleStatus represents the lifecycle of a scheduled price change
+type ScheduleStatus string
+
+const (
+	SchedulePending   ScheduleStatus = "pending"
+	ScheduleApplied   ScheduleStatus = "applied"
+	ScheduleCancelled ScheduleStatus = "cancelled"
+)
+
+// PriceSchedule sets a product's regular price at EffectiveAt. Pending
+// schedules are applied by the background scheduler.
+type PriceSchedule struct {
+	ID          string
+	ProductID   string
+	Price       money.Money
+	EffectiveAt time.Time
+	Status      ScheduleStatus
+	AppliedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -1,11 +1,65 @@
 package product
 
-import "github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+)
 
 // Repository defines the interface for product persistence operations
 type Repository interface {
 	ListProducts(params pagination.Params, filters Filters) ([]*Product, int64, error)
 	GetProduct(id string) (*Product, error)
+	GetProductBySlug(slug string) (*Product, error)
+	// FindProductIDBySlugHistory returns the product that previously used the slug
+	FindProductIDBySlugHistory(slug string) (string, error)
+	// SlugTaken reports whether the slug is current or historical for any product
+	// other than exceptProductID
+	SlugTaken(slug, exceptProductID string) (bool, error)
 	CreateProduct(product *Product) error
+	// UpdateProduct writes catalogue fields and replaces the specifications; a
+	// changed slug is kept in the history
 	UpdateProduct(product *Product) error
+	ListSitemapEntries(limit int) ([]*SitemapEntry, error)
+	ListProductsBySKU(skus []string) ([]*Product, error)
+	// ImportProducts creates and updates a batch of products in one transaction
+	ImportProducts(creates, updates []*Product) error
+	// StreamProducts calls fn with successive batches of the filtered products
+	// until they are exhausted or fn returns an error
+	StreamProducts(filters Filters, batchSize int, fn func([]*Product) error) error
+}
+
+// PriceRepository defines the interface for price history and scheduled price
+// change persistence operations. History entries are written by the product
+// repository whenever a price or sale changes.
+type PriceRepository interface {
+	ListPriceHistory(productID string, params pagination.Params) ([]*PriceChange, int64, error)
+	CreateSchedule(schedule *PriceSchedule) error
+	ListSchedules(productID string) ([]*PriceSchedule, error)
+	// CancelSchedule cancels a pending schedule; it returns ErrNotFound when
+	// there is no pending schedule with the ID
+	CancelSchedule(productID, scheduleID string) error
+	ListDueSchedules(now time.Time, limit int) ([]*PriceSchedule, error)
+	// ApplySchedule sets the product price from a pending schedule, records it
+	// in the history and marks the schedule applied; schedules of deleted
+	// products are cancelled. It returns ErrNotFound when the schedule is no
+	// longer pending.
+	ApplySchedule(scheduleID string, now time.Time) error
+}
+
+// LikeRepository defines the interface for product like persistence operations.
+// Like and Unlike are idempotent and keep Product.LikeCount in step.
+type LikeRepository interface {
+	Like(userID, productID string) error
+	Unlike(userID, productID string) error
+	ListLikes(userID string, params pagination.Params) ([]*Like, int64, error)
+}
+
+// VariantRepository defines the interface for option type and variant persistence operations
+type VariantRepository interface {
+	CreateOptionType(optionType *OptionType) error
+	CreateVariant(variant *Variant, optionValueIDs []string) error
+	UpdateVariant(variant *Variant) error
+	DeleteVariant(productID, variantID string) error
+	AddVariantImage(image *ProductImage) error
 }
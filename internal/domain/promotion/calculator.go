@@ -0,0 +1,182 @@
+package promotion
+
+import (
+	"sort"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// Line is a priced cart line as seen by promotion rules
+type Line struct {
+	ProductID   string
+	VariantID   string
+	CategoryIDs []string // the product's category and its ancestors
+	UnitPrice   money.Money
+	Quantity    int
+}
+
+// Applied is a promotion applied to a cart and the amount it took off
+type Applied struct {
+	PromotionID string
+	Name        string
+	Code        string
+	Type        Type
+	Amount      money.Money
+}
+
+// Breakdown is the result of applying promotions to the lines of a cart
+type Breakdown struct {
+	Discounts    []money.Money // discount of each line, in line order
+	Applied      []Applied
+	Discount     money.Money
+	FreeShipping bool
+}
+
+// Apply applies the automatic promotions, then the coupon (nil for none), to
+// the lines. Each promotion discounts what earlier ones left, and no line is
+// discounted below zero. Promotion amounts must already be in currency.
+// Automatic promotions that do not apply are skipped; a coupon that does not
+// apply fails with ErrNotApplicable.
+func Apply(currency string, lines []Line, automatic []*Promotion, coupon *Promotion) (*Breakdown, error) {
+	subtotal := int64(0)
+	remaining := make([]int64, len(lines))
+	for i, l := range lines {
+		remaining[i] = l.UnitPrice.Mul(l.Quantity).Amount
+		subtotal += remaining[i]
+	}
+
+	b := &Breakdown{Discounts: make([]money.Money, len(lines)), Discount: money.Zero(currency)}
+	for i := range b.Discounts {
+		b.Discounts[i] = money.Zero(currency)
+	}
+
+	apply := func(p *Promotion) bool {
+		if p.MinOrderValue != nil && subtotal < p.MinOrderValue.Amount {
+			return false
+		}
+
+		var eligible []int
+		for i, l := range lines {
+			if p.Targets(l) {
+				eligible = append(eligible, i)
+			}
+		}
+		if len(eligible) == 0 {
+			return false
+		}
+
+		discounts := discount(p, lines, remaining, eligible)
+		total := int64(0)
+		for i, d := range discounts {
+			remaining[i] -= d
+			total += d
+		}
+		if total == 0 && p.Type != TypeFreeShipping {
+			return false
+		}
+
+		for i, d := range discounts {
+			b.Discounts[i].Amount += d
+		}
+		b.Discount.Amount += total
+		if p.Type == TypeFreeShipping {
+			b.FreeShipping = true
+		}
+		b.Applied = append(b.Applied, Applied{
+			PromotionID: p.ID,
+			Name:        p.Name,
+			Code:        p.Code,
+			Type:        p.Type,
+			Amount:      money.New(total, currency),
+		})
+		return true
+	}
+
+	for _, p := range automatic {
+		apply(p)
+	}
+	if coupon != nil && !apply(coupon) {
+		return nil, ErrNotApplicable
+	}
+
+	return b, nil
+}
+
+// discount returns the amount the promotion takes off each line, capped at
+// what is left of the line
+func discount(p *Promotion, lines []Line, remaining []int64, eligible []int) map[int]int64 {
+	discounts := make(map[int]int64, len(eligible))
+
+	switch p.Type {
+	case TypePercentage:
+		for _, i := range eligible {
+			discounts[i] = percentOf(remaining[i], p.Percent)
+		}
+
+	case TypeFixedAmount:
+		base := int64(0)
+		for _, i := range eligible {
+			base += remaining[i]
+		}
+		amount := p.Amount.Amount
+		if amount > base {
+			amount = base
+		}
+		if amount <= 0 {
+			return discounts
+		}
+
+		// Split in proportion to the lines, then hand out the rounding
+		// remainder one minor unit at a time
+		allocated := int64(0)
+		for _, i := range eligible {
+			discounts[i] = amount * remaining[i] / base
+			allocated += discounts[i]
+		}
+		for _, i := range eligible {
+			if allocated == amount {
+				break
+			}
+			if discounts[i] < remaining[i] {
+				discounts[i]++
+				allocated++
+			}
+		}
+
+	case TypeBuyXGetY:
+		// Every group of X+Y units gets its Y cheapest units discounted
+		units := 0
+		for _, i := range eligible {
+			units += lines[i].Quantity
+		}
+		free := units / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+
+		cheapest := append([]int(nil), eligible...)
+		sort.SliceStable(cheapest, func(a, b int) bool {
+			return lines[cheapest[a]].UnitPrice.Amount < lines[cheapest[b]].UnitPrice.Amount
+		})
+		for _, i := range cheapest {
+			if free == 0 {
+				break
+			}
+			n := lines[i].Quantity
+			if n > free {
+				n = free
+			}
+			free -= n
+			discounts[i] = percentOf(lines[i].UnitPrice.Mul(n).Amount, p.Percent)
+		}
+	}
+
+	for i, d := range discounts {
+		if d > remaining[i] {
+			discounts[i] = remaining[i]
+		}
+	}
+	return discounts
+}
+
+// percentOf returns percent% of amount, rounded half up
+func percentOf(amount int64, percent int) int64 {
+	return (amount*int64(percent) + 50) / 100
+}
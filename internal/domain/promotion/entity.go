@@ -0,0 +1,134 @@
+package promotion
+
+import (
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// Type is the kind of benefit a promotion gives
+type Type string
+
+const (
+	TypePercentage   Type = "percentage"
+	TypeFixedAmount  Type = "fixed_amount"
+	TypeFreeShipping Type = "free_shipping"
+	TypeBuyXGetY     Type = "buy_x_get_y"
+)
+
+// Promotion is a discount rule (pure domain entity). A promotion with a Code is
+// a coupon the customer applies to the cart; one without a code applies
+// automatically to every eligible cart. Amounts are in the store currency.
+type Promotion struct {
+	ID               string
+	Name             string
+	Code             string // empty for automatic promotions
+	Type             Type
+	Percent          int          // percentage off; for buy X get Y, off the Y units (100 = free)
+	Amount           *money.Money // amount off the eligible items, for fixed amount promotions
+	BuyQuantity      int          // X of buy X get Y
+	GetQuantity      int          // Y of buy X get Y
+	MinOrderValue    *money.Money // cart subtotal required before discounts
+	UsageLimit       int          // total redemptions allowed; 0 is unlimited
+	PerCustomerLimit int          // redemptions allowed per customer; 0 is unlimited
+	UsageCount       int
+	ProductIDs       []string // targeted products; with CategoryIDs empty every product is targeted
+	CategoryIDs      []string // targeted categories, including their subcategories
+	StartsAt         *time.Time
+	EndsAt           *time.Time
+	Active           bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Redemption records a promotion used by an order
+type Redemption struct {
+	ID          string
+	PromotionID string
+	OrderID     string
+	UserID      string
+	Amount      money.Money
+	CreatedAt   time.Time
+}
+
+// NormalizeCode returns the canonical form of a discount code; codes are
+// matched case-insensitively
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Automatic reports whether the promotion applies without a code
+func (p *Promotion) Automatic() bool {
+	return p.Code == ""
+}
+
+// Running reports whether the promotion is active and inside its validity window
+func (p *Promotion) Running(now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return false
+	}
+	return p.EndsAt == nil || now.Before(*p.EndsAt)
+}
+
+// Exhausted reports whether the promotion has reached its usage limit
+func (p *Promotion) Exhausted() bool {
+	return p.UsageLimit > 0 && p.UsageCount >= p.UsageLimit
+}
+
+// Targets reports whether the promotion applies to the line
+func (p *Promotion) Targets(line Line) bool {
+	if len(p.ProductIDs) == 0 && len(p.CategoryIDs) == 0 {
+		return true
+	}
+	for _, id := range p.ProductIDs {
+		if id == line.ProductID {
+			return true
+		}
+	}
+	for _, id := range p.CategoryIDs {
+		for _, categoryID := range line.CategoryIDs {
+			if id == categoryID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate checks that the rules needed by the promotion type are set and
+// consistent
+func (p *Promotion) Validate() error {
+	if p.Name == "" || p.UsageLimit < 0 || p.PerCustomerLimit < 0 {
+		return ErrInvalidPromotion
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return ErrInvalidPromotion
+	}
+	if p.MinOrderValue != nil && p.MinOrderValue.IsNegative() {
+		return ErrInvalidPromotion
+	}
+
+	switch p.Type {
+	case TypePercentage:
+		if p.Percent < 1 || p.Percent > 100 {
+			return ErrInvalidPromotion
+		}
+	case TypeFixedAmount:
+		if p.Amount == nil || p.Amount.Amount <= 0 {
+			return ErrInvalidPromotion
+		}
+	case TypeFreeShipping:
+	case TypeBuyXGetY:
+		if p.BuyQuantity < 1 || p.GetQuantity < 1 || p.Percent < 1 || p.Percent > 100 {
+			return ErrInvalidPromotion
+		}
+	default:
+		return ErrInvalidPromotion
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package promotion
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidPromotion indicates that the rules of a promotion are incomplete or inconsistent
+	ErrInvalidPromotion = apperrors.ErrInvalidPromotion
+
+	// ErrInvalidCode indicates that a discount code does not exist or is not running
+	ErrInvalidCode = apperrors.ErrInvalidDiscountCode
+
+	// ErrNotApplicable indicates that a discount code does not apply to the cart
+	ErrNotApplicable = apperrors.ErrDiscountNotApplicable
+
+	// ErrUsageLimitReached indicates that a promotion has no redemptions left for the customer
+	ErrUsageLimitReached = apperrors.ErrDiscountUsageLimit
+
+	// ErrNotFound indicates that the promotion does not exist
+	ErrNotFound = apperrors.ErrNotFound
+)
@@ -0,0 +1,24 @@
+package promotion
+
+import "time"
+
+// Repository defines the interface for promotion persistence operations
+type Repository interface {
+	CreatePromotion(promotion *Promotion) error
+	UpdatePromotion(promotion *Promotion) error
+	GetPromotion(id string) (*Promotion, error)
+	// GetPromotionByCode looks up a coupon by its normalized code
+	GetPromotionByCode(code string) (*Promotion, error)
+	ListPromotions() ([]*Promotion, error)
+	// ListAutomatic returns the promotions without a code running at now
+	ListAutomatic(now time.Time) ([]*Promotion, error)
+	DeletePromotion(id string) error
+	// CountRedemptions returns how many of the user's orders redeemed the promotion
+	CountRedemptions(promotionID, userID string) (int, error)
+	// Redeem records the redemptions of an order in one transaction, locking
+	// each promotion so concurrent checkouts cannot exceed its usage or
+	// per-customer limit. Nothing is recorded when any limit is reached.
+	Redeem(redemptions []*Redemption) error
+	// ReleaseRedemptions removes the redemptions of an order, giving the uses back
+	ReleaseRedemptions(orderID string) error
+}
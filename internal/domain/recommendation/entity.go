@@ -0,0 +1,118 @@
+package recommendation
+
+import (
+	"sort"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/product"
+)
+
+// LinkType is the kind of a manager-curated link between two products
+type LinkType string
+
+const (
+	LinkRelated   LinkType = "related"
+	LinkUpsell    LinkType = "upsell"
+	LinkCrossSell LinkType = "cross_sell"
+)
+
+// ValidLinkType reports whether t is a curated link type
+func ValidLinkType(t LinkType) bool {
+	return t == LinkRelated || t == LinkUpsell || t == LinkCrossSell
+}
+
+// linkRank orders curated link types within a recommendation list
+var linkRank = map[LinkType]int{LinkCrossSell: 0, LinkUpsell: 1, LinkRelated: 2}
+
+// Source identifies where a recommendation came from
+type Source string
+
+const (
+	SourceCurated    Source = "curated"
+	SourceCoPurchase Source = "co_purchase"
+)
+
+// TypeBoughtTogether is the type of recommendations derived from co-purchases
+const TypeBoughtTogether LinkType = "bought_together"
+
+// MaxPerProduct caps the recommendations stored for a product
+const MaxPerProduct = 20
+
+// Link is a manager-curated recommendation from ProductID to TargetID (pure
+// domain entity). Lower positions are shown first within a type.
+type Link struct {
+	ID        string
+	ProductID string
+	TargetID  string
+	Type      LinkType
+	Position  int
+	CreatedAt time.Time
+}
+
+// CoPurchase counts the paid orders that contained both products
+type CoPurchase struct {
+	ProductID string
+	RelatedID string
+	Orders    int
+}
+
+// Recommendation is a precomputed entry of a product's recommendation list.
+// Score is the co-purchase order count; curated links have none.
+type Recommendation struct {
+	ProductID     string
+	RecommendedID string
+	Type          LinkType
+	Source        Source
+	Score         int
+	Position      int
+	Product       *product.Product // set on reads
+}
+
+// Merge builds the recommendation list of a product: curated links first
+// (cross-sells, then upsells, then related, each by position), followed by
+// co-purchased products not already linked, most frequently bought first.
+// Pairs for other products are ignored.
+func Merge(productID string, links []*Link, pairs []CoPurchase) []*Recommendation {
+	curated := make([]*Link, 0, len(links))
+	for _, l := range links {
+		if l.ProductID == productID {
+			curated = append(curated, l)
+		}
+	}
+	sort.SliceStable(curated, func(i, j int) bool {
+		if curated[i].Type != curated[j].Type {
+			return linkRank[curated[i].Type] < linkRank[curated[j].Type]
+		}
+		return curated[i].Position < curated[j].Position
+	})
+
+	bought := make([]CoPurchase, 0, len(pairs))
+	for _, p := range pairs {
+		if p.ProductID == productID {
+			bought = append(bought, p)
+		}
+	}
+	sort.SliceStable(bought, func(i, j int) bool {
+		return bought[i].Orders > bought[j].Orders
+	})
+
+	seen := map[string]bool{productID: true}
+	var recs []*Recommendation
+	add := func(r *Recommendation) {
+		if seen[r.RecommendedID] || len(recs) >= MaxPerProduct {
+			return
+		}
+		seen[r.RecommendedID] = true
+		r.Position = len(recs)
+		recs = append(recs, r)
+	}
+
+	for _, l := range curated {
+		add(&Recommendation{ProductID: productID, RecommendedID: l.TargetID, Type: l.Type, Source: SourceCurated})
+	}
+	for _, p := range bought {
+		add(&Recommendation{ProductID: productID, RecommendedID: p.RelatedID, Type: TypeBoughtTogether, Source: SourceCoPurchase, Score: p.Orders})
+	}
+
+	return recs
+}
@@ -0,0 +1,14 @@
+package recommendation
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidLink indicates a link with an unknown type or to the product itself
+	ErrInvalidLink = apperrors.ErrInvalidProductLink
+
+	// ErrLinkExists indicates that the products are already linked with the same type
+	ErrLinkExists = apperrors.ErrDuplicateEntry
+
+	// ErrNotFound indicates that the link does not exist
+	ErrNotFound = apperrors.ErrNotFound
+)
@@ -0,0 +1,29 @@
+package recommendation
+
+import "time"
+
+// Repository defines the interface for curated links, co-purchase statistics
+// and precomputed recommendation lists
+type Repository interface {
+	CreateLink(link *Link) error
+	DeleteLink(productID, linkID string) error
+	ListLinks(productID string) ([]*Link, error)
+	ListAllLinks() ([]*Link, error)
+
+	// ComputeCoPurchases counts product pairs bought together in paid orders
+	// placed since the given time, keeping pairs seen in at least minOrders
+	// orders and at most limit pairs per product
+	ComputeCoPurchases(since time.Time, minOrders, limit int) ([]CoPurchase, error)
+	// ReplaceCoPurchases stores a freshly computed set of pairs
+	ReplaceCoPurchases(pairs []CoPurchase) error
+	ListCoPurchases(productID string) ([]CoPurchase, error)
+
+	// ReplaceRecommendations swaps the stored lists of the given products
+	ReplaceRecommendations(lists map[string][]*Recommendation) error
+	// ReplaceAllRecommendations swaps every stored list
+	ReplaceAllRecommendations(lists map[string][]*Recommendation) error
+	// ListRecommendations returns the product's stored list with each
+	// recommended product loaded, skipping products that are disabled or out
+	// of stock
+	ListRecommendations(productID string, limit int) ([]*Recommendation, error)
+}
@@ -0,0 +1,55 @@
+package review
+
+import "time"
+
+// Status represents the moderation state of a review
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+	StatusHidden   Status = "hidden"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// Review is a verified-purchase product review (pure domain entity). Only
+// approved reviews are public and count towards the product rating.
+type Review struct {
+	ID           string
+	ProductID    string
+	UserID       string
+	OrderID      string
+	Rating       int
+	Title        string
+	Body         string
+	Status       Status
+	HelpfulCount int
+	ModeratedBy  string
+	ModeratedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ValidRating reports whether the rating is within the star range
+func ValidRating(rating int) bool {
+	return rating >= MinRating && rating <= MaxRating
+}
+
+// ModerationSources returns the statuses a review may be moved to the target
+// status from
+func ModerationSources(to Status) []Status {
+	switch to {
+	case StatusApproved:
+		return []Status{StatusPending, StatusHidden}
+	case StatusRejected:
+		return []Status{StatusPending}
+	case StatusHidden:
+		return []Status{StatusApproved}
+	}
+	return nil
+}
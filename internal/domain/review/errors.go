@@ -0,0 +1,20 @@
+package review
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidRating indicates a rating outside the 1-5 star range
+	ErrInvalidRating = apperrors.ErrInvalidRating
+
+	// ErrNotVerifiedPurchase indicates that the order does not show the user bought the product
+	ErrNotVerifiedPurchase = apperrors.ErrReviewNotVerified
+
+	// ErrAlreadyReviewed indicates that the user has already reviewed the product
+	ErrAlreadyReviewed = apperrors.ErrAlreadyReviewed
+
+	// ErrInvalidTransition indicates a moderation action not allowed from the review's status
+	ErrInvalidTransition = apperrors.ErrInvalidReviewTransition
+
+	// ErrNotFound indicates that the review does not exist or is not visible
+	ErrNotFound = apperrors.ErrNotFound
+)
@@ -0,0 +1,8 @@
+package review
+
+// Filters represents filtering criteria for review queries (domain value object)
+type Filters struct {
+	ProductID string
+	UserID    string
+	Status    Status
+}
@@ -0,0 +1,17 @@
+package review
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+
+// Repository defines the interface for review persistence operations
+type Repository interface {
+	ListReviews(params pagination.Params, filters Filters) ([]*Review, int64, error)
+	GetReview(id string) (*Review, error)
+	CreateReview(review *Review) error
+
+	// Moderate moves the review to the new status only if it is currently in
+	// one of from, and refreshes the product's rating aggregates
+	Moderate(id string, from []Status, to Status, moderatorID string) (bool, error)
+
+	// AddHelpfulVote records a user's helpful vote once; repeated votes are ignored
+	AddHelpfulVote(reviewID, userID string) error
+}
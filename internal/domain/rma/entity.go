@@ -0,0 +1,84 @@
+package rma
+
+import "time"
+
+// Status represents the state of a return merchandise authorization
+type Status string
+
+const (
+	StatusRequested Status = "requested"
+	StatusApproved  Status = "approved"
+	StatusRejected  Status = "rejected"
+	StatusReceived  Status = "received"
+	StatusRefunded  Status = "refunded"
+)
+
+// Reason explains why a line is being returned
+type Reason string
+
+const (
+	ReasonDamaged        Reason = "damaged"
+	ReasonDefective      Reason = "defective"
+	ReasonWrongItem      Reason = "wrong_item"
+	ReasonNotAsDescribed Reason = "not_as_described"
+	ReasonNoLongerNeeded Reason = "no_longer_needed"
+	ReasonOther          Reason = "other"
+)
+
+// IsValid reports whether the reason is known
+func (r Reason) IsValid() bool {
+	switch r {
+	case ReasonDamaged, ReasonDefective, ReasonWrongItem, ReasonNotAsDescribed, ReasonNoLongerNeeded, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// Return is a customer's request to send back lines of a paid order (pure
+// domain entity). Managers approve or reject it, record receipt of the goods
+// and refund it.
+type Return struct {
+	ID         string
+	OrderID    string
+	UserID     string
+	Status     Status
+	Comment    string // customer's explanation
+	Resolution string // manager's note on approval or rejection
+	RefundID   string
+	ReceivedAt *time.Time
+	Lines      []Line
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Line is a quantity of an order line being returned
+type Line struct {
+	ID          string
+	ReturnID    string
+	OrderLineID string
+	ProductID   string
+	VariantID   string
+	Quantity    int
+	Reason      Reason
+}
+
+// ReceivableStatuses are the statuses in which the goods of a return can be
+// received. Receipt is tracked by ReceivedAt apart from the status, since a
+// return refunded before the goods arrive still has them to receive.
+var ReceivableStatuses = []Status{StatusApproved, StatusRefunded}
+
+// TransitionSources returns the statuses a return may be moved to the target
+// status from. Refunds do not wait for the goods, so approved returns can be
+// refunded before they are received; receiving them then leaves the status
+// at refunded.
+func TransitionSources(to Status) []Status {
+	switch to {
+	case StatusApproved, StatusRejected:
+		return []Status{StatusRequested}
+	case StatusReceived:
+		return []Status{StatusApproved}
+	case StatusRefunded:
+		return []Status{StatusApproved, StatusReceived}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package rma
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidReturn indicates a return without lines, or with lines, quantities or reasons that are not valid
+	ErrInvalidReturn = apperrors.ErrInvalidReturn
+
+	// ErrQuantityExceeded indicates that more units would be returned than were bought
+	ErrQuantityExceeded = apperrors.ErrReturnQuantityExceeded
+
+	// ErrInvalidTransition indicates an action not allowed from the return's status
+	ErrInvalidTransition = apperrors.ErrInvalidReturnTransition
+
+	// ErrOrderNotReturnable indicates that the order has not been paid for
+	ErrOrderNotReturnable = apperrors.ErrOrderNotReturnable
+
+	// ErrNotFound indicates that the return does not exist or is not visible
+	ErrNotFound = apperrors.ErrNotFound
+)
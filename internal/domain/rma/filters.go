@@ -0,0 +1,8 @@
+package rma
+
+// Filters represents filtering criteria for return queries (domain value object)
+type Filters struct {
+	OrderID string
+	UserID  string
+	Status  Status
+}
@@ -0,0 +1,27 @@
+package rma
+
+import (
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+)
+
+// Repository defines the interface for return persistence operations
+type Repository interface {
+	ListReturns(params pagination.Params, filters Filters) ([]*Return, int64, error)
+	GetReturn(id string) (*Return, error)
+
+	// CreateReturn stores the return. It fails with ErrQuantityExceeded when a
+	// line, counting the order's other returns that were not rejected, would
+	// be returned more times than it was bought.
+	CreateReturn(ret *Return) error
+
+	// Transition writes the return's status, resolution and refund only if
+	// it is currently in one of from
+	Transition(ret *Return, from []Status) (bool, error)
+
+	// MarkReceived records the receipt of the goods once, only if the return
+	// is in one of ReceivableStatuses. An approved return moves to received;
+	// a refunded one stays refunded.
+	MarkReceived(id string, at time.Time) (bool, error)
+}
@@ -0,0 +1,11 @@
+package seo
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidSlug indicates a slug that is not lowercase letters, digits and single hyphens
+	ErrInvalidSlug = apperrors.ErrInvalidSlug
+
+	// ErrInvalidCanonicalURL indicates a canonical URL that is not an absolute http(s) URL
+	ErrInvalidCanonicalURL = apperrors.ErrInvalidCanonicalURL
+)
@@ -0,0 +1,51 @@
+package seo
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// Metadata holds the search engine fields shared by products and categories.
+// Empty fields fall back to the storefront defaults.
+type Metadata struct {
+	MetaTitle       string
+	MetaDescription string
+	CanonicalURL    string
+}
+
+// Validate checks that a canonical URL, when set, is an absolute http(s) URL
+func (m Metadata) Validate() error {
+	if m.CanonicalURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(m.CanonicalURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidCanonicalURL
+	}
+	return nil
+}
+
+// Slugify derives a URL slug from a name: lowercase ASCII letters and digits
+// separated by single hyphens
+func Slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			hyphen = false
+		case !hyphen && b.Len() > 0:
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// ValidSlug reports whether slug is already in the form Slugify produces
+func ValidSlug(slug string) bool {
+	return slug != "" && Slugify(slug) == slug
+}
@@ -0,0 +1,35 @@
+package shipping
+
+import "github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+
+// Destination is the part of the shipping address carriers price by
+type Destination struct {
+	Country    string
+	Region     string
+	PostalCode string
+}
+
+// RateRequest describes a parcel to quote
+type RateRequest struct {
+	Destination Destination
+	WeightGrams int
+	Subtotal    money.Money // item amounts after discounts
+}
+
+// Quote is a priced shipping option. IDs must be stable across requests so
+// the option chosen from a quote can be found again at checkout.
+type Quote struct {
+	ID      string
+	Name    string
+	Carrier string
+	Amount  money.Money
+}
+
+// CarrierRates defines the interface for live carrier rate quotes (port).
+// Adapters call an external carrier; the store's own zones and methods are
+// always quoted alongside them.
+type CarrierRates interface {
+	// Rates returns the options the carrier offers for the parcel, in any
+	// currency
+	Rates(req RateRequest) ([]Quote, error)
+}
@@ -0,0 +1,152 @@
+package shipping
+
+import (
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// MethodType is how a shipping method prices a parcel
+type MethodType string
+
+const (
+	TypeFlatRate    MethodType = "flat_rate"
+	TypeWeightBased MethodType = "weight_based"
+	TypeFreeOver    MethodType = "free_over_threshold"
+)
+
+// Zone groups the countries that share shipping methods (pure domain entity).
+// A zone without countries covers every country no other zone lists.
+type Zone struct {
+	ID        string
+	Name      string
+	Countries []string // ISO 3166-1 alpha-2
+	Methods   []Method
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Method is a shipping option offered in a zone. Prices are in the store
+// currency.
+type Method struct {
+	ID         string
+	ZoneID     string
+	Name       string
+	Carrier    string // shown to customers, e.g. "DHL"
+	Type       MethodType
+	Price      money.Money  // flat price, or the base price of weight-based methods
+	PricePerKg *money.Money // added per started kilogram, for weight-based methods
+	FreeOver   *money.Money // subtotal from which shipping is free, for free-over-threshold methods
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Covers reports whether the zone lists the country
+func (z *Zone) Covers(country string) bool {
+	for _, c := range z.Countries {
+		if c == country {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate normalizes the country codes and checks the zone
+func (z *Zone) Validate() error {
+	z.Name = strings.TrimSpace(z.Name)
+	if z.Name == "" {
+		return ErrInvalidZone
+	}
+
+	seen := make(map[string]bool, len(z.Countries))
+	countries := make([]string, 0, len(z.Countries))
+	for _, c := range z.Countries {
+		c = strings.ToUpper(strings.TrimSpace(c))
+		if len(c) != 2 || c[0] < 'A' || c[0] > 'Z' || c[1] < 'A' || c[1] > 'Z' {
+			return ErrInvalidZone
+		}
+		if !seen[c] {
+			seen[c] = true
+			countries = append(countries, c)
+		}
+	}
+	z.Countries = countries
+	return nil
+}
+
+// MatchZone returns the zone listing the country, falling back to the zone
+// without countries, or nil when the country is not shipped to
+func MatchZone(zones []*Zone, country string) *Zone {
+	var restOfWorld *Zone
+	for _, z := range zones {
+		if z.Covers(country) {
+			return z
+		}
+		if len(z.Countries) == 0 {
+			restOfWorld = z
+		}
+	}
+	return restOfWorld
+}
+
+// Validate checks that the method has the prices its type uses
+func (m *Method) Validate() error {
+	if strings.TrimSpace(m.Name) == "" || m.Price.IsNegative() {
+		return ErrInvalidMethod
+	}
+
+	switch m.Type {
+	case TypeFlatRate:
+		m.PricePerKg, m.FreeOver = nil, nil
+	case TypeWeightBased:
+		if m.PricePerKg == nil || m.PricePerKg.IsNegative() {
+			return ErrInvalidMethod
+		}
+		m.FreeOver = nil
+	case TypeFreeOver:
+		if m.FreeOver == nil || m.FreeOver.Amount <= 0 {
+			return ErrInvalidMethod
+		}
+		m.PricePerKg = nil
+	default:
+		return ErrInvalidMethod
+	}
+	return nil
+}
+
+// Cost prices a parcel. Amounts must already be in the subtotal currency.
+func (m *Method) Cost(weightGrams int, subtotal money.Money) money.Money {
+	switch m.Type {
+	case TypeWeightBased:
+		kilograms := (weightGrams + 999) / 1000
+		return money.New(m.Price.Amount+m.PricePerKg.Mul(kilograms).Amount, m.Price.Currency)
+	case TypeFreeOver:
+		if subtotal.Amount >= m.FreeOver.Amount {
+			return money.Zero(m.Price.Currency)
+		}
+	}
+	return m.Price
+}
+
+// ShipmentStatus is the delivery state of a shipment
+type ShipmentStatus string
+
+const (
+	ShipmentShipped   ShipmentStatus = "shipped"
+	ShipmentDelivered ShipmentStatus = "delivered"
+)
+
+// Shipment is a parcel sent for an order
+type Shipment struct {
+	ID             string
+	OrderID        string
+	Carrier        string
+	TrackingNumber string
+	Status         ShipmentStatus
+	ShippedAt      time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
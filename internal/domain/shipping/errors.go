@@ -0,0 +1,23 @@
+package shipping
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidZone indicates that a zone has no name or a malformed country code
+	ErrInvalidZone = apperrors.ErrInvalidShippingZone
+
+	// ErrZoneOverlap indicates that a country is already listed by another zone
+	ErrZoneOverlap = apperrors.ErrShippingZoneOverlap
+
+	// ErrInvalidMethod indicates that a method lacks the prices its type uses
+	ErrInvalidMethod = apperrors.ErrInvalidShippingMethod
+
+	// ErrMethodUnavailable indicates that the chosen method does not ship to the address
+	ErrMethodUnavailable = apperrors.ErrShippingMethodUnavailable
+
+	// ErrInvalidShipment indicates that a shipment has no carrier or tracking number
+	ErrInvalidShipment = apperrors.ErrInvalidShipment
+
+	// ErrNotFound indicates that the zone, method or shipment does not exist
+	ErrNotFound = apperrors.ErrNotFound
+)
@@ -0,0 +1,26 @@
+package shipping
+
+import "time"
+
+// Repository defines the interface for shipping zone and method persistence operations
+type Repository interface {
+	// ListZones returns every zone with its methods
+	ListZones() ([]*Zone, error)
+	GetZone(id string) (*Zone, error)
+	CreateZone(zone *Zone) error
+	UpdateZone(zone *Zone) error
+	DeleteZone(id string) error
+	GetMethod(id string) (*Method, error)
+	CreateMethod(method *Method) error
+	UpdateMethod(method *Method) error
+	DeleteMethod(id string) error
+}
+
+// ShipmentRepository defines the interface for shipment persistence operations
+type ShipmentRepository interface {
+	CreateShipment(shipment *Shipment) error
+	GetShipment(id string) (*Shipment, error)
+	ListShipments(orderID string) ([]*Shipment, error)
+	// MarkDelivered records the delivery only if the shipment is not yet delivered
+	MarkDelivered(id string, at time.Time) (bool, error)
+}
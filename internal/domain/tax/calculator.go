@@ -0,0 +1,8 @@
+package tax
+
+// Calculator defines the interface for tax calculation (port). Adapters may
+// apply local rules or call an external tax service.
+type Calculator interface {
+	// Calculate returns the tax of each line of the request
+	Calculate(req Request) (*Result, error)
+}
@@ -0,0 +1,95 @@
+package tax
+
+import (
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/internal/domain/money"
+)
+
+// Rate is the tax rate of a product tax class in a country, optionally
+// narrowed to a region (state, province) of that country (pure domain entity)
+type Rate struct {
+	ID        string
+	Country   string // ISO 3166-1 alpha-2
+	Region    string // empty applies to the whole country
+	TaxClass  string
+	Name      string   // shown on invoices, e.g. "VAT"
+	Rate      *big.Rat // fraction of the taxed amount, e.g. 0.0725
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Match returns the rate for the tax class in the region, falling back to
+// the country-wide rate, or nil when the class is not taxed there
+func Match(rates []*Rate, country, region, class string) *Rate {
+	var countryWide *Rate
+	for _, r := range rates {
+		if r.Country != country || r.TaxClass != class {
+			continue
+		}
+		if region != "" && strings.EqualFold(r.Region, region) {
+			return r
+		}
+		if r.Region == "" {
+			countryWide = r
+		}
+	}
+	return countryWide
+}
+
+// FormatPercent renders a rate fraction as a percentage without trailing
+// zeros, e.g. 0.0725 as "7.25"
+func FormatPercent(rate *big.Rat) string {
+	if rate == nil {
+		return "0"
+	}
+	percent := new(big.Rat).Mul(rate, big.NewRat(100, 1)).FloatString(4)
+	percent = strings.TrimRight(percent, "0")
+	return strings.TrimSuffix(percent, ".")
+}
+
+// Address is the part of the shipping address that decides which rates apply
+type Address struct {
+	Country    string
+	Region     string
+	PostalCode string
+}
+
+// Customer identifies who is buying, for exemptions
+type Customer struct {
+	UserID        string
+	CustomerGroup string
+}
+
+// Line is an order line to tax; Amount is the line amount after discounts
+type Line struct {
+	ProductID string
+	TaxClass  string
+	Amount    money.Money
+}
+
+// Request describes an order to calculate tax for. When PricesIncludeTax is
+// set, line amounts already include tax and the tax is the part of them it
+// accounts for; otherwise tax is added on top.
+type Request struct {
+	Currency         string
+	Lines            []Line
+	Address          Address
+	Customer         Customer
+	PricesIncludeTax bool
+}
+
+// LineTax is the tax of one line, in request line order
+type LineTax struct {
+	Name   string
+	Rate   *big.Rat // zero when the line is not taxed
+	Amount money.Money
+}
+
+// Result is the calculated tax of an order
+type Result struct {
+	Lines []LineTax
+	Total money.Money
+}
@@ -0,0 +1,11 @@
+package tax
+
+import "github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+
+var (
+	// ErrInvalidRate indicates that a tax rate is not a percentage between 0 and 100
+	ErrInvalidRate = apperrors.ErrInvalidTaxRate
+
+	// ErrRateNotFound indicates that the tax rate does not exist
+	ErrRateNotFound = apperrors.ErrNotFound
+)
@@ -0,0 +1,10 @@
+package tax
+
+// RateRepository defines the interface for tax rate persistence operations
+type RateRepository interface {
+	ListRates() ([]*Rate, error)
+	ListRatesForCountry(country string) ([]*Rate, error)
+	// SetRate creates or replaces the rate for its country, region and tax class
+	SetRate(rate *Rate) error
+	DeleteRate(id string) error
+}
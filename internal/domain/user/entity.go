@@ -10,10 +10,19 @@ type User struct {
 	Password  string
 	FirstName string
 	LastName  string
+
+	// PreferredCurrency is used for prices when a request does not ask for one
+	PreferredCurrency string
+	// CustomerGroup selects group price lists (e.g. "wholesale")
+	CustomerGroup string
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// RoleManager is the role required for the manager API
+const RoleManager = "manager"
+
 // Role represents a user role
 type Role struct {
 	ID          string
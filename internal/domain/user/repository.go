@@ -1,13 +1,20 @@
 package user
 
+import "github.com/RubenRodrigo/go-tiny-store/pkg/pagination"
+
 // Repository defines the interface for user persistence operations
 type Repository interface {
 	CreateUser(user *User) error
 	GetUserByID(id string) (*User, error)
 	GetUserByEmail(email string) (*User, error)
-	ListUsers() ([]*User, error)
+	ListUsers(params pagination.Params) ([]*User, int64, error)
 	UpdateUser(user *User) error
 	UpdateUserPassword(userID, newHashedPassword string) error
+	UpdatePreferredCurrency(userID, currency string) error
+	UpdateCustomerGroup(userID, customerGroup string) error
+
+	// HasRole reports whether the user has been granted the named role
+	HasRole(userID, role string) (bool, error)
 }
 
 // RefreshTokenRepository defines the interface for refresh token operations
@@ -1,22 +1,39 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Auth     AuthConfig
+	Server         ServerConfig
+	Database       DatabaseConfig
+	Auth           AuthConfig
+	Cart           CartConfig
+	Store          StoreConfig
+	Pagination     PaginationConfig
+	Inventory      InventoryConfig
+	Checkout       CheckoutConfig
+	Payment        PaymentConfig
+	Import         ImportConfig
+	Pricing        PricingConfig
+	Recommendation RecommendationConfig
+	Tax            TaxConfig
+	Invoice        InvoiceConfig
+	Storage        StorageConfig
+	Idempotency    IdempotencyConfig
 }
 
 type ServerConfig struct {
-	Port int
-	Host string
+	Port                int
+	Host                string
+	WriteTimeoutSeconds int // longest a handler may take to respond
 }
 
 type DatabaseConfig struct {
@@ -32,6 +49,78 @@ type AuthConfig struct {
 	JWT_SECRET string
 }
 
+type CartConfig struct {
+	TokenSecret             string // signs the tokens that identify guest carts
+	AbandonedAfterHours     int    // idle time before a reminder, and between reminders
+	MaxReminders            int    // reminders per idle period; zero disables them
+	ReminderIntervalMinutes int    // how often abandoned carts are looked for
+}
+
+type StoreConfig struct {
+	Currency string
+	URL      string // public storefront base URL, used for sitemap links
+}
+
+type PaginationConfig struct {
+	CursorSecret string
+}
+
+type InventoryConfig struct {
+	LowStockThreshold int
+	AlertEmail        string
+}
+
+type CheckoutConfig struct {
+	ReservationTTLMinutes int
+	SweepIntervalSeconds  int
+}
+
+type PaymentConfig struct {
+	StripeSecretKey     string
+	StripeWebhookSecret string
+}
+
+type ImportConfig struct {
+	BatchSize           int
+	InlineRows          int
+	MaxUploadMB         int
+	PollIntervalSeconds int
+	StaleMinutes        int
+}
+
+type PricingConfig struct {
+	ScheduleIntervalSeconds int
+	RatesFile               string // JSON file of exchange rates; rates are kept in the database when empty
+}
+
+type RecommendationConfig struct {
+	IntervalMinutes int
+	WindowDays      int
+	MinOrders       int
+}
+
+type TaxConfig struct {
+	PricesIncludeTax bool
+	ExemptGroups     []string // customer groups that pay no tax
+}
+
+type InvoiceConfig struct {
+	SellerName    string
+	SellerAddress []string // printed one entry per line
+	SellerTaxID   string
+}
+
+type StorageConfig struct {
+	Dir string // root directory of the local blob store
+}
+
+type IdempotencyConfig struct {
+	Store          string // "postgres" or "memory"
+	TTLHours       int    // how long responses are kept for retries
+	LockTTLSeconds int    // how long an in-flight request holds its key
+	MaxBodyMB      int    // largest request body read to fingerprint a request
+}
+
 func Load() *Config {
 	// Load environment variables from .env file
 	if err := godotenv.Load(); err != nil {
@@ -40,8 +129,9 @@ func Load() *Config {
 
 	return &Config{
 		Server: ServerConfig{
-			Port: getEnvAsInt("SERVER_PORT", 4000),
-			Host: getEnv("SERVER_HOST", "localhost"),
+			Port:                getEnvAsInt("SERVER_PORT", 4000),
+			Host:                getEnv("SERVER_HOST", "localhost"),
+			WriteTimeoutSeconds: getEnvAsInt("SERVER_WRITE_TIMEOUT_SECONDS", 60),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -54,9 +144,107 @@ func Load() *Config {
 		Auth: AuthConfig{
 			JWT_SECRET: getEnv("JWT_SECRET", "JWT_SECRET"),
 		},
+		Cart: CartConfig{
+			TokenSecret:             getEnv("CART_TOKEN_SECRET", ""),
+			AbandonedAfterHours:     getEnvAsInt("CART_ABANDONED_AFTER_HOURS", 24),
+			MaxReminders:            getEnvAsInt("CART_MAX_REMINDERS", 2),
+			ReminderIntervalMinutes: getEnvAsInt("CART_REMINDER_INTERVAL_MINUTES", 15),
+		},
+		Store: StoreConfig{
+			Currency: strings.ToUpper(getEnv("STORE_CURRENCY", "USD")),
+			URL:      getEnv("STORE_URL", "http://localhost:3000"),
+		},
+		Pagination: PaginationConfig{
+			CursorSecret: getEnv("CURSOR_SECRET", ""),
+		},
+		Inventory: InventoryConfig{
+			LowStockThreshold: getEnvAsInt("INVENTORY_LOW_STOCK_THRESHOLD", 5),
+			AlertEmail:        getEnv("INVENTORY_ALERT_EMAIL", "admin@admin.com"),
+		},
+		Checkout: CheckoutConfig{
+			ReservationTTLMinutes: getEnvAsInt("CHECKOUT_RESERVATION_TTL_MINUTES", 15),
+			SweepIntervalSeconds:  getEnvAsInt("CHECKOUT_SWEEP_INTERVAL_SECONDS", 60),
+		},
+		Payment: PaymentConfig{
+			StripeSecretKey:     getEnv("STRIPE_SECRET_KEY", ""),
+			StripeWebhookSecret: getEnv("STRIPE_WEBHOOK_SECRET", ""),
+		},
+		Import: ImportConfig{
+			BatchSize:           getEnvAsInt("IMPORT_BATCH_SIZE", 100),
+			InlineRows:          getEnvAsInt("IMPORT_INLINE_ROWS", 200),
+			MaxUploadMB:         getEnvAsInt("IMPORT_MAX_UPLOAD_MB", 20),
+			PollIntervalSeconds: getEnvAsInt("IMPORT_POLL_INTERVAL_SECONDS", 5),
+			StaleMinutes:        getEnvAsInt("IMPORT_STALE_MINUTES", 10),
+		},
+		Pricing: PricingConfig{
+			ScheduleIntervalSeconds: getEnvAsInt("PRICING_SCHEDULE_INTERVAL_SECONDS", 60),
+			RatesFile:               getEnv("PRICING_RATES_FILE", ""),
+		},
+		Recommendation: RecommendationConfig{
+			IntervalMinutes: getEnvAsInt("RECOMMENDATIONS_INTERVAL_MINUTES", 60),
+			WindowDays:      getEnvAsInt("RECOMMENDATIONS_WINDOW_DAYS", 180),
+			MinOrders:       getEnvAsInt("RECOMMENDATIONS_MIN_ORDERS", 2),
+		},
+		Tax: TaxConfig{
+			PricesIncludeTax: getEnvAsBool("TAX_PRICES_INCLUDE_TAX", false),
+			ExemptGroups:     getEnvAsList("TAX_EXEMPT_GROUPS"),
+		},
+		Invoice: InvoiceConfig{
+			SellerName:    getEnv("INVOICE_SELLER_NAME", "Tiny Store"),
+			SellerAddress: getEnvAsList("INVOICE_SELLER_ADDRESS"),
+			SellerTaxID:   getEnv("INVOICE_SELLER_TAX_ID", ""),
+		},
+		Storage: StorageConfig{
+			Dir: getEnv("STORAGE_DIR", "storage"),
+		},
+		Idempotency: IdempotencyConfig{
+			Store:          getEnv("IDEMPOTENCY_STORE", "postgres"),
+			TTLHours:       getEnvAsInt("IDEMPOTENCY_TTL_HOURS", 24),
+			LockTTLSeconds: getEnvAsInt("IDEMPOTENCY_LOCK_TTL_SECONDS", 120),
+			MaxBodyMB:      getEnvAsInt("IDEMPOTENCY_MAX_BODY_MB", 20),
+		},
 	}
 }
 
+// Validate reports settings that have no safe default and must be set, and
+// settings out of range
+func (c *Config) Validate() error {
+	required := []struct{ key, value string }{
+		{"CURSOR_SECRET", c.Pagination.CursorSecret},
+		{"CART_TOKEN_SECRET", c.Cart.TokenSecret},
+	}
+	for _, setting := range required {
+		if setting.value == "" {
+			return fmt.Errorf("%s must be set", setting.key)
+		}
+	}
+
+	// Background jobs run on tickers, which need a positive interval
+	intervals := []struct {
+		key   string
+		value int
+	}{
+		{"CHECKOUT_SWEEP_INTERVAL_SECONDS", c.Checkout.SweepIntervalSeconds},
+		{"IMPORT_POLL_INTERVAL_SECONDS", c.Import.PollIntervalSeconds},
+		{"PRICING_SCHEDULE_INTERVAL_SECONDS", c.Pricing.ScheduleIntervalSeconds},
+		{"RECOMMENDATIONS_INTERVAL_MINUTES", c.Recommendation.IntervalMinutes},
+		{"CART_REMINDER_INTERVAL_MINUTES", c.Cart.ReminderIntervalMinutes},
+	}
+	for _, setting := range intervals {
+		if setting.value <= 0 {
+			return fmt.Errorf("%s must be greater than zero", setting.key)
+		}
+	}
+
+	// A request still running when its idempotency key frees up could be
+	// run a second time by a retry
+	if c.Idempotency.LockTTLSeconds <= c.Server.WriteTimeoutSeconds {
+		return errors.New("IDEMPOTENCY_LOCK_TTL_SECONDS must be longer than SERVER_WRITE_TIMEOUT_SECONDS")
+	}
+
+	return nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -74,3 +262,25 @@ func getEnvAsInt(key string, defaultValue int) int {
 
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+
+	return defaultValue
+}
+
+// getEnvAsList splits a comma-separated variable, dropping empty entries
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a background task run on a fixed interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+// Start runs each job on its own ticker until ctx is cancelled
+func Start(ctx context.Context, jobs ...Job) {
+	for _, job := range jobs {
+		go run(ctx, job)
+	}
+}
+
+func run(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	log.Printf("Background job %s started (every %s)", job.Name, job.Interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.Run(); err != nil {
+				log.Printf("ERROR: Background job %s failed: %v", job.Name, err)
+			}
+		}
+	}
+}
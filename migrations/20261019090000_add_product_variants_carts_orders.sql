@@ -0,0 +1,128 @@
+-- Create "product_variants" table
+CREATE TABLE "product_variants" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "product_id" uuid NOT NULL,
+  "sku" character varying(64) NOT NULL,
+  "price" numeric NULL,
+  "stock" bigint NULL DEFAULT 0,
+  "disabled" boolean NULL DEFAULT false,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_products_variants" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_product_variants_deleted_at" to table: "product_variants"
+CREATE INDEX "idx_product_variants_deleted_at" ON "product_variants" ("deleted_at");
+-- Create index "idx_product_variants_product_id" to table: "product_variants"
+CREATE INDEX "idx_product_variants_product_id" ON "product_variants" ("product_id");
+-- Create index "idx_product_variants_sku" to table: "product_variants"
+CREATE UNIQUE INDEX "idx_product_variants_sku" ON "product_variants" ("sku");
+-- Create "product_option_types" table
+CREATE TABLE "product_option_types" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "product_id" uuid NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "position" bigint NULL DEFAULT 0,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_products_options" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_product_option_types_deleted_at" to table: "product_option_types"
+CREATE INDEX "idx_product_option_types_deleted_at" ON "product_option_types" ("deleted_at");
+-- Create index "idx_product_option_types_product_id" to table: "product_option_types"
+CREATE INDEX "idx_product_option_types_product_id" ON "product_option_types" ("product_id");
+-- Create "product_option_values" table
+CREATE TABLE "product_option_values" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "option_type_id" uuid NOT NULL,
+  "value" character varying(100) NOT NULL,
+  "position" bigint NULL DEFAULT 0,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_product_option_types_values" FOREIGN KEY ("option_type_id") REFERENCES "product_option_types" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_product_option_values_deleted_at" to table: "product_option_values"
+CREATE INDEX "idx_product_option_values_deleted_at" ON "product_option_values" ("deleted_at");
+-- Create index "idx_product_option_values_option_type_id" to table: "product_option_values"
+CREATE INDEX "idx_product_option_values_option_type_id" ON "product_option_values" ("option_type_id");
+-- Create "product_variant_option_values" table
+CREATE TABLE "product_variant_option_values" (
+  "variant_id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "option_value_id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  PRIMARY KEY ("variant_id", "option_value_id"),
+  CONSTRAINT "fk_product_variant_option_values_product_option_value_model" FOREIGN KEY ("option_value_id") REFERENCES "product_option_values" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_product_variant_option_values_product_variant_model" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create "carts" table
+CREATE TABLE "carts" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "user_id" uuid NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_carts_deleted_at" to table: "carts"
+CREATE INDEX "idx_carts_deleted_at" ON "carts" ("deleted_at");
+-- Create index "idx_carts_user_id" to table: "carts"
+CREATE UNIQUE INDEX "idx_carts_user_id" ON "carts" ("user_id");
+-- Create "cart_items" table
+CREATE TABLE "cart_items" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "cart_id" uuid NOT NULL,
+  "product_id" uuid NOT NULL,
+  "variant_id" uuid NULL,
+  "quantity" bigint NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_carts_items" FOREIGN KEY ("cart_id") REFERENCES "carts" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_cart_items_cart_id" to table: "cart_items"
+CREATE INDEX "idx_cart_items_cart_id" ON "cart_items" ("cart_id");
+-- Create "orders" table
+CREATE TABLE "orders" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "user_id" uuid NOT NULL,
+  "status" character varying(32) NOT NULL,
+  "total" numeric NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_orders_deleted_at" to table: "orders"
+CREATE INDEX "idx_orders_deleted_at" ON "orders" ("deleted_at");
+-- Create index "idx_orders_status" to table: "orders"
+CREATE INDEX "idx_orders_status" ON "orders" ("status");
+-- Create index "idx_orders_user_id" to table: "orders"
+CREATE INDEX "idx_orders_user_id" ON "orders" ("user_id");
+-- Create "order_lines" table
+CREATE TABLE "order_lines" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "order_id" uuid NOT NULL,
+  "product_id" uuid NOT NULL,
+  "variant_id" uuid NULL,
+  "sku" character varying(64) NULL,
+  "name" character varying(255) NOT NULL,
+  "unit_price" numeric NOT NULL,
+  "quantity" bigint NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_orders_lines" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_order_lines_deleted_at" to table: "order_lines"
+CREATE INDEX "idx_order_lines_deleted_at" ON "order_lines" ("deleted_at");
+-- Create index "idx_order_lines_order_id" to table: "order_lines"
+CREATE INDEX "idx_order_lines_order_id" ON "order_lines" ("order_id");
+-- Modify "product_images" table
+ALTER TABLE "product_images" ADD COLUMN "variant_id" uuid NULL, ADD CONSTRAINT "fk_product_variants_images" FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION;
+-- Create index "idx_product_images_variant_id" to table: "product_images"
+CREATE INDEX "idx_product_images_variant_id" ON "product_images" ("variant_id");
@@ -0,0 +1,31 @@
+-- Create "stock_movements" table
+CREATE TABLE "stock_movements" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "product_id" uuid NOT NULL,
+  "variant_id" uuid NULL,
+  "type" character varying(32) NOT NULL,
+  "quantity" bigint NOT NULL,
+  "stock_after" bigint NOT NULL,
+  "reason" character varying(255) NULL,
+  "actor_id" uuid NULL,
+  "reference" character varying(64) NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_stock_movements_created_at" to table: "stock_movements"
+CREATE INDEX "idx_stock_movements_created_at" ON "stock_movements" ("created_at");
+-- Create index "idx_stock_movements_product_id" to table: "stock_movements"
+CREATE INDEX "idx_stock_movements_product_id" ON "stock_movements" ("product_id");
+-- Create index "idx_stock_movements_reference" to table: "stock_movements"
+CREATE INDEX "idx_stock_movements_reference" ON "stock_movements" ("reference");
+-- Create index "idx_stock_movements_variant_id" to table: "stock_movements"
+CREATE INDEX "idx_stock_movements_variant_id" ON "stock_movements" ("variant_id");
+-- Modify "products" table
+ALTER TABLE "products" ADD COLUMN "low_stock_threshold" bigint NULL;
+-- Modify "product_variants" table
+ALTER TABLE "product_variants" ADD COLUMN "low_stock_threshold" bigint NULL;
+-- Seed opening balances so the ledger sums to current on-hand stock
+INSERT INTO "stock_movements" ("created_at", "product_id", "variant_id", "type", "quantity", "stock_after", "reason")
+SELECT now(), "id", NULL, 'adjustment', "stock", "stock", 'opening balance' FROM "products" WHERE "stock" <> 0 AND "deleted_at" IS NULL;
+INSERT INTO "stock_movements" ("created_at", "product_id", "variant_id", "type", "quantity", "stock_after", "reason")
+SELECT now(), "product_id", "id", 'adjustment', "stock", "stock", 'opening balance' FROM "product_variants" WHERE "stock" <> 0 AND "deleted_at" IS NULL;
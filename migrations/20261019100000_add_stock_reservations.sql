@@ -0,0 +1,29 @@
+-- Create "stock_reservations" table
+CREATE TABLE "stock_reservations" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "product_id" uuid NOT NULL,
+  "variant_id" uuid NULL,
+  "order_id" uuid NOT NULL,
+  "quantity" bigint NOT NULL,
+  "status" character varying(32) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_stock_reservations_expires_at" to table: "stock_reservations"
+CREATE INDEX "idx_stock_reservations_expires_at" ON "stock_reservations" ("expires_at");
+-- Create index "idx_stock_reservations_order_id" to table: "stock_reservations"
+CREATE INDEX "idx_stock_reservations_order_id" ON "stock_reservations" ("order_id");
+-- Create index "idx_stock_reservations_product_id" to table: "stock_reservations"
+CREATE INDEX "idx_stock_reservations_product_id" ON "stock_reservations" ("product_id");
+-- Create index "idx_stock_reservations_status" to table: "stock_reservations"
+CREATE INDEX "idx_stock_reservations_status" ON "stock_reservations" ("status");
+-- Modify "products" table
+ALTER TABLE "products" ADD COLUMN "reserved" bigint NULL DEFAULT 0;
+-- Modify "product_variants" table
+ALTER TABLE "product_variants" ADD COLUMN "reserved" bigint NULL DEFAULT 0;
+-- Modify "orders" table
+ALTER TABLE "orders" ADD COLUMN "payment_id" character varying(255) NULL;
+-- Create index "idx_orders_payment_id" to table: "orders"
+CREATE INDEX "idx_orders_payment_id" ON "orders" ("payment_id");
@@ -0,0 +1,29 @@
+-- Prices were stored as floating point major units of the store currency and
+-- are converted to integer minor units of it. The migration cannot read
+-- STORE_CURRENCY, so the currency is taken from the "app.store_currency"
+-- setting, e.g. options=-c%20app.store_currency%3DJPY on the database URL,
+-- and is USD when that is not set. The minor units follow money.Exponent.
+DO $$
+DECLARE
+  currency text := upper(coalesce(nullif(current_setting('app.store_currency', true), ''), 'USD'));
+  factor bigint := CASE
+    WHEN currency IN ('CLP', 'ISK', 'JPY', 'KRW', 'UGX', 'VND', 'XAF', 'XOF') THEN 1
+    WHEN currency IN ('BHD', 'JOD', 'KWD', 'OMR', 'TND') THEN 1000
+    ELSE 100
+  END;
+BEGIN
+  IF currency !~ '^[A-Z]{3}$' THEN
+    RAISE EXCEPTION 'app.store_currency must be a three-letter currency code, got %', currency;
+  END IF;
+
+  -- Modify "products" table
+  EXECUTE format('ALTER TABLE "products" ALTER COLUMN "price" TYPE bigint USING ROUND("price" * %s)::bigint, ADD COLUMN "currency" character varying(3) NOT NULL DEFAULT %L', factor, currency);
+  ALTER TABLE "products" ALTER COLUMN "currency" DROP DEFAULT;
+  -- Modify "product_variants" table
+  EXECUTE format('ALTER TABLE "product_variants" ALTER COLUMN "price" TYPE bigint USING ROUND("price" * %s)::bigint', factor);
+  -- Modify "orders" table
+  EXECUTE format('ALTER TABLE "orders" ALTER COLUMN "total" TYPE bigint USING ROUND("total" * %s)::bigint, ADD COLUMN "currency" character varying(3) NOT NULL DEFAULT %L', factor, currency);
+  ALTER TABLE "orders" ALTER COLUMN "currency" DROP DEFAULT;
+  -- Modify "order_lines" table
+  EXECUTE format('ALTER TABLE "order_lines" ALTER COLUMN "unit_price" TYPE bigint USING ROUND("unit_price" * %s)::bigint', factor);
+END $$;
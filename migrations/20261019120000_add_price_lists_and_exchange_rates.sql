@@ -0,0 +1,57 @@
+-- Create "price_lists" table
+CREATE TABLE "price_lists" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "name" character varying(100) NOT NULL,
+  "currency" character varying(3) NOT NULL,
+  "customer_group" character varying(64) NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_price_lists_currency" to table: "price_lists"
+CREATE INDEX "idx_price_lists_currency" ON "price_lists" ("currency");
+-- Create index "idx_price_lists_customer_group" to table: "price_lists"
+CREATE INDEX "idx_price_lists_customer_group" ON "price_lists" ("customer_group");
+-- Create index "idx_price_lists_deleted_at" to table: "price_lists"
+CREATE INDEX "idx_price_lists_deleted_at" ON "price_lists" ("deleted_at");
+-- Create "price_list_prices" table
+CREATE TABLE "price_list_prices" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "price_list_id" uuid NOT NULL,
+  "product_id" uuid NOT NULL,
+  "variant_id" uuid NULL,
+  "amount" bigint NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_price_lists_prices" FOREIGN KEY ("price_list_id") REFERENCES "price_lists" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_price_list_prices_deleted_at" to table: "price_list_prices"
+CREATE INDEX "idx_price_list_prices_deleted_at" ON "price_list_prices" ("deleted_at");
+-- Create index "idx_price_list_prices_product" to table: "price_list_prices"
+CREATE INDEX "idx_price_list_prices_product" ON "price_list_prices" ("price_list_id","product_id");
+-- Create "exchange_rates" table
+CREATE TABLE "exchange_rates" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "base_currency" character varying(3) NOT NULL,
+  "quote_currency" character varying(3) NOT NULL,
+  "rate" numeric(20,10) NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_exchange_rates_deleted_at" to table: "exchange_rates"
+CREATE INDEX "idx_exchange_rates_deleted_at" ON "exchange_rates" ("deleted_at");
+-- Create index "idx_exchange_rates_pair" to table: "exchange_rates"
+CREATE UNIQUE INDEX "idx_exchange_rates_pair" ON "exchange_rates" ("base_currency","quote_currency");
+-- Modify "users" table
+ALTER TABLE "users" ADD COLUMN "preferred_currency" character varying(3) NULL, ADD COLUMN "customer_group" character varying(64) NULL;
+-- Create index "idx_users_customer_group" to table: "users"
+CREATE INDEX "idx_users_customer_group" ON "users" ("customer_group");
+-- Modify "carts" table
+-- Existing carts were priced in the store currency
+ALTER TABLE "carts" ADD COLUMN "currency" character varying(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE "carts" ALTER COLUMN "currency" DROP DEFAULT;
@@ -0,0 +1,17 @@
+-- Create "product_likes" table
+CREATE TABLE "product_likes" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "user_id" uuid NOT NULL,
+  "product_id" uuid NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_product_likes_product" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_product_likes_product_id" to table: "product_likes"
+CREATE INDEX "idx_product_likes_product_id" ON "product_likes" ("product_id");
+-- Create index "idx_product_likes_user_product" to table: "product_likes"
+CREATE UNIQUE INDEX "idx_product_likes_user_product" ON "product_likes" ("user_id","product_id");
+-- Modify "products" table
+ALTER TABLE "products" ADD COLUMN "like_count" bigint NOT NULL DEFAULT 0;
+-- Create index "idx_products_like_count" to table: "products"
+CREATE INDEX "idx_products_like_count" ON "products" ("like_count");
@@ -0,0 +1,45 @@
+-- Create "reviews" table
+CREATE TABLE "reviews" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "product_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "order_id" uuid NOT NULL,
+  "rating" bigint NOT NULL,
+  "title" character varying(200) NULL,
+  "body" text NULL,
+  "status" character varying(32) NOT NULL,
+  "helpful_count" bigint NOT NULL DEFAULT 0,
+  "moderated_by" uuid NULL,
+  "moderated_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_reviews_order" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_reviews_product" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_reviews_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_reviews_deleted_at" to table: "reviews"
+CREATE INDEX "idx_reviews_deleted_at" ON "reviews" ("deleted_at");
+-- Create index "idx_reviews_product_id" to table: "reviews"
+CREATE INDEX "idx_reviews_product_id" ON "reviews" ("product_id");
+-- Create index "idx_reviews_status" to table: "reviews"
+CREATE INDEX "idx_reviews_status" ON "reviews" ("status");
+-- Create index "idx_reviews_user_product" to table: "reviews"
+CREATE UNIQUE INDEX "idx_reviews_user_product" ON "reviews" ("user_id","product_id");
+-- Create "review_votes" table
+CREATE TABLE "review_votes" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "review_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_review_votes_review" FOREIGN KEY ("review_id") REFERENCES "reviews" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_review_votes_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_review_votes_review_user" to table: "review_votes"
+CREATE UNIQUE INDEX "idx_review_votes_review_user" ON "review_votes" ("review_id","user_id");
+-- Modify "products" table
+ALTER TABLE "products" ADD COLUMN "rating_count" bigint NOT NULL DEFAULT 0, ADD COLUMN "rating_average" numeric(3,2) NOT NULL DEFAULT 0;
+-- Create index "idx_products_rating_average" to table: "products"
+CREATE INDEX "idx_products_rating_average" ON "products" ("rating_average");
@@ -0,0 +1,10 @@
+-- Modify "categories" table
+ALTER TABLE "categories" ADD COLUMN "parent_id" uuid NULL, ADD COLUMN "slug" character varying(120) NULL, ADD CONSTRAINT "fk_categories_children" FOREIGN KEY ("parent_id") REFERENCES "categories" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION;
+-- Backfill slugs from names, suffixing the id where names collide
+UPDATE "categories" SET "slug" = COALESCE(NULLIF(TRIM(BOTH '-' FROM LOWER(REGEXP_REPLACE("name", '[^a-zA-Z0-9]+', '-', 'g'))), ''), 'category');
+UPDATE "categories" SET "slug" = "slug" || '-' || LEFT("id"::text, 8) WHERE "id" NOT IN (SELECT DISTINCT ON ("slug") "id" FROM "categories" ORDER BY "slug", "created_at");
+ALTER TABLE "categories" ALTER COLUMN "slug" SET NOT NULL;
+-- Create index "idx_categories_slug" to table: "categories"
+CREATE UNIQUE INDEX "idx_categories_slug" ON "categories" ("slug");
+-- Create index "idx_categories_parent_id" to table: "categories"
+CREATE INDEX "idx_categories_parent_id" ON "categories" ("parent_id");
@@ -0,0 +1,23 @@
+-- Create "product_slug_history" table
+CREATE TABLE "product_slug_history" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "product_id" uuid NOT NULL,
+  "slug" character varying(280) NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_product_slug_history_product" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_product_slug_history_product_id" to table: "product_slug_history"
+CREATE INDEX "idx_product_slug_history_product_id" ON "product_slug_history" ("product_id");
+-- Create index "idx_product_slug_history_slug" to table: "product_slug_history"
+CREATE UNIQUE INDEX "idx_product_slug_history_slug" ON "product_slug_history" ("slug");
+-- Modify "products" table
+ALTER TABLE "products" ADD COLUMN "slug" character varying(280) NULL, ADD COLUMN "meta_title" character varying(255) NULL, ADD COLUMN "meta_description" character varying(500) NULL, ADD COLUMN "canonical_url" character varying(500) NULL;
+-- Backfill slugs from names, suffixing the id where names collide
+UPDATE "products" SET "slug" = COALESCE(NULLIF(TRIM(BOTH '-' FROM LOWER(REGEXP_REPLACE("name", '[^a-zA-Z0-9]+', '-', 'g'))), ''), 'product');
+UPDATE "products" SET "slug" = "slug" || '-' || LEFT("id"::text, 8) WHERE "id" NOT IN (SELECT DISTINCT ON ("slug") "id" FROM "products" ORDER BY "slug", "created_at");
+ALTER TABLE "products" ALTER COLUMN "slug" SET NOT NULL;
+-- Create index "idx_products_slug" to table: "products"
+CREATE UNIQUE INDEX "idx_products_slug" ON "products" ("slug");
+-- Modify "categories" table
+ALTER TABLE "categories" ADD COLUMN "meta_title" character varying(255) NULL, ADD COLUMN "meta_description" character varying(500) NULL, ADD COLUMN "canonical_url" character varying(500) NULL;
@@ -0,0 +1,40 @@
+-- Create "category_attributes" table
+CREATE TABLE "category_attributes" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "category_id" uuid NOT NULL,
+  "key" character varying(100) NOT NULL,
+  "label" character varying(100) NOT NULL,
+  "type" character varying(20) NOT NULL,
+  "unit" character varying(20) NULL,
+  "options" jsonb NOT NULL DEFAULT '[]',
+  "required" boolean NOT NULL DEFAULT false,
+  "position" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_category_attributes_category" FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_category_attributes_category_key" to table: "category_attributes"
+CREATE UNIQUE INDEX "idx_category_attributes_category_key" ON "category_attributes" ("category_id","key");
+-- Create index "idx_category_attributes_key" to table: "category_attributes"
+CREATE INDEX "idx_category_attributes_key" ON "category_attributes" ("key");
+-- Create "product_specifications" table
+CREATE TABLE "product_specifications" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "product_id" uuid NOT NULL,
+  "attribute_id" uuid NOT NULL,
+  "value" character varying(255) NOT NULL,
+  "number_value" numeric NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_product_specifications_attribute" FOREIGN KEY ("attribute_id") REFERENCES "category_attributes" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_products_specs" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_product_specifications_attribute_id" to table: "product_specifications"
+CREATE INDEX "idx_product_specifications_attribute_id" ON "product_specifications" ("attribute_id");
+-- Create index "idx_product_specifications_product_attribute" to table: "product_specifications"
+CREATE UNIQUE INDEX "idx_product_specifications_product_attribute" ON "product_specifications" ("product_id","attribute_id");
+-- Modify "products" table
+ALTER TABLE "products" ADD COLUMN "description" text NULL, ADD COLUMN "brand" character varying(100) NULL, ADD COLUMN "weight_grams" bigint NOT NULL DEFAULT 0, ADD COLUMN "length_mm" bigint NOT NULL DEFAULT 0, ADD COLUMN "width_mm" bigint NOT NULL DEFAULT 0, ADD COLUMN "height_mm" bigint NOT NULL DEFAULT 0;
+-- Create index "idx_products_brand" to table: "products"
+CREATE INDEX "idx_products_brand" ON "products" ("brand");
@@ -0,0 +1,30 @@
+-- Create "import_jobs" table
+CREATE TABLE "import_jobs" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "format" character varying(10) NOT NULL,
+  "dry_run" boolean NOT NULL DEFAULT false,
+  "status" character varying(20) NOT NULL,
+  "total_rows" bigint NOT NULL DEFAULT 0,
+  "processed_rows" bigint NOT NULL DEFAULT 0,
+  "created" bigint NOT NULL DEFAULT 0,
+  "updated" bigint NOT NULL DEFAULT 0,
+  "failed" bigint NOT NULL DEFAULT 0,
+  "errors" jsonb NOT NULL DEFAULT '[]',
+  "message" character varying(255) NULL,
+  "created_by" uuid NULL,
+  "payload" bytea NULL,
+  "started_at" timestamptz NULL,
+  "finished_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_import_jobs_deleted_at" to table: "import_jobs"
+CREATE INDEX "idx_import_jobs_deleted_at" ON "import_jobs" ("deleted_at");
+-- Create index "idx_import_jobs_status" to table: "import_jobs"
+CREATE INDEX "idx_import_jobs_status" ON "import_jobs" ("status");
+-- Modify "products" table
+ALTER TABLE "products" ADD COLUMN "sku" character varying(64) NULL;
+-- Create index "idx_products_sku" to table: "products"
+CREATE UNIQUE INDEX "idx_products_sku" ON "products" ("sku");
@@ -0,0 +1,45 @@
+-- Create "product_price_history" table
+CREATE TABLE "product_price_history" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "product_id" uuid NOT NULL,
+  "price" bigint NOT NULL,
+  "previous_price" bigint NULL,
+  "currency" character varying(3) NOT NULL,
+  "sale_price" bigint NULL,
+  "sale_starts_at" timestamptz NULL,
+  "sale_ends_at" timestamptz NULL,
+  "source" character varying(20) NOT NULL,
+  "schedule_id" uuid NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_product_price_history_product" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_product_price_history_created_at" to table: "product_price_history"
+CREATE INDEX "idx_product_price_history_created_at" ON "product_price_history" ("created_at");
+-- Create index "idx_product_price_history_product_id" to table: "product_price_history"
+CREATE INDEX "idx_product_price_history_product_id" ON "product_price_history" ("product_id");
+-- Create "product_price_schedules" table
+CREATE TABLE "product_price_schedules" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "product_id" uuid NOT NULL,
+  "price" bigint NOT NULL,
+  "currency" character varying(3) NOT NULL,
+  "effective_at" timestamptz NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "applied_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_product_price_schedules_product" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_product_price_schedules_deleted_at" to table: "product_price_schedules"
+CREATE INDEX "idx_product_price_schedules_deleted_at" ON "product_price_schedules" ("deleted_at");
+-- Create index "idx_product_price_schedules_effective_at" to table: "product_price_schedules"
+CREATE INDEX "idx_product_price_schedules_effective_at" ON "product_price_schedules" ("effective_at");
+-- Create index "idx_product_price_schedules_product_id" to table: "product_price_schedules"
+CREATE INDEX "idx_product_price_schedules_product_id" ON "product_price_schedules" ("product_id");
+-- Create index "idx_product_price_schedules_status" to table: "product_price_schedules"
+CREATE INDEX "idx_product_price_schedules_status" ON "product_price_schedules" ("status");
+-- Modify "products" table
+ALTER TABLE "products" ADD COLUMN "sale_price" bigint NULL, ADD COLUMN "sale_starts_at" timestamptz NULL, ADD COLUMN "sale_ends_at" timestamptz NULL;
@@ -0,0 +1,37 @@
+-- Create "product_links" table
+CREATE TABLE "product_links" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "product_id" uuid NOT NULL,
+  "target_id" uuid NOT NULL,
+  "type" character varying(20) NOT NULL,
+  "position" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_product_links_product" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_product_links_target" FOREIGN KEY ("target_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_product_links_unique" to table: "product_links"
+CREATE UNIQUE INDEX "idx_product_links_unique" ON "product_links" ("product_id","target_id","type");
+-- Create "product_co_purchases" table
+CREATE TABLE "product_co_purchases" (
+  "product_id" uuid NOT NULL,
+  "related_id" uuid NOT NULL,
+  "orders" bigint NOT NULL,
+  "computed_at" timestamptz NOT NULL,
+  PRIMARY KEY ("product_id", "related_id"),
+  CONSTRAINT "fk_product_co_purchases_product" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_product_co_purchases_related" FOREIGN KEY ("related_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create "product_recommendations" table
+CREATE TABLE "product_recommendations" (
+  "product_id" uuid NOT NULL,
+  "recommended_id" uuid NOT NULL,
+  "type" character varying(20) NOT NULL,
+  "source" character varying(20) NOT NULL,
+  "score" bigint NOT NULL DEFAULT 0,
+  "position" bigint NOT NULL,
+  "computed_at" timestamptz NOT NULL,
+  PRIMARY KEY ("product_id", "recommended_id"),
+  CONSTRAINT "fk_product_recommendations_product" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_product_recommendations_recommended" FOREIGN KEY ("recommended_id") REFERENCES "products" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
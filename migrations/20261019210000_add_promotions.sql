@@ -0,0 +1,54 @@
+-- Create "promotions" table
+CREATE TABLE "promotions" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "name" character varying(255) NOT NULL,
+  "code" character varying(64) NULL,
+  "type" character varying(20) NOT NULL,
+  "percent" bigint NOT NULL DEFAULT 0,
+  "amount" bigint NULL,
+  "buy_quantity" bigint NOT NULL DEFAULT 0,
+  "get_quantity" bigint NOT NULL DEFAULT 0,
+  "min_order_value" bigint NULL,
+  "currency" character varying(3) NOT NULL,
+  "usage_limit" bigint NOT NULL DEFAULT 0,
+  "per_customer_limit" bigint NOT NULL DEFAULT 0,
+  "usage_count" bigint NOT NULL DEFAULT 0,
+  "product_ids" jsonb NOT NULL DEFAULT '[]',
+  "category_ids" jsonb NOT NULL DEFAULT '[]',
+  "starts_at" timestamptz NULL,
+  "ends_at" timestamptz NULL,
+  "active" boolean NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_promotions_active" to table: "promotions"
+CREATE INDEX "idx_promotions_active" ON "promotions" ("active");
+-- Create index "idx_promotions_code" to table: "promotions"
+CREATE UNIQUE INDEX "idx_promotions_code" ON "promotions" ("code");
+-- Create index "idx_promotions_deleted_at" to table: "promotions"
+CREATE INDEX "idx_promotions_deleted_at" ON "promotions" ("deleted_at");
+-- Create "promotion_redemptions" table
+CREATE TABLE "promotion_redemptions" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "promotion_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "order_id" uuid NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" character varying(3) NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_promotion_redemptions_order" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_promotion_redemptions_promotion" FOREIGN KEY ("promotion_id") REFERENCES "promotions" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_promotion_redemptions_order_id" to table: "promotion_redemptions"
+CREATE INDEX "idx_promotion_redemptions_order_id" ON "promotion_redemptions" ("order_id");
+-- Create index "idx_promotion_redemptions_promotion_user" to table: "promotion_redemptions"
+CREATE INDEX "idx_promotion_redemptions_promotion_user" ON "promotion_redemptions" ("promotion_id","user_id");
+-- Modify "carts" table
+ALTER TABLE "carts" ADD COLUMN "discount_code" character varying(64) NULL;
+-- Modify "orders" table
+ALTER TABLE "orders" ADD COLUMN "discount" bigint NOT NULL DEFAULT 0, ADD COLUMN "discount_code" character varying(64) NULL;
+-- Modify "order_lines" table
+ALTER TABLE "order_lines" ADD COLUMN "discount" bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,25 @@
+-- Create "tax_rates" table
+CREATE TABLE "tax_rates" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "country" character varying(2) NOT NULL,
+  "region" character varying(100) NOT NULL DEFAULT '',
+  "tax_class" character varying(32) NOT NULL,
+  "name" character varying(100) NOT NULL,
+  "rate" numeric(7,6) NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_tax_rates_deleted_at" to table: "tax_rates"
+CREATE INDEX "idx_tax_rates_deleted_at" ON "tax_rates" ("deleted_at");
+-- Create index "idx_tax_rates_scope" to table: "tax_rates"
+CREATE UNIQUE INDEX "idx_tax_rates_scope" ON "tax_rates" ("country","region","tax_class");
+-- Modify "products" table
+ALTER TABLE "products" ADD COLUMN "tax_class" character varying(32) NOT NULL DEFAULT 'standard';
+-- Modify "orders" table
+ALTER TABLE "orders" ADD COLUMN "subtotal" bigint NOT NULL DEFAULT 0, ADD COLUMN "tax" bigint NOT NULL DEFAULT 0, ADD COLUMN "tax_inclusive" boolean NOT NULL DEFAULT false, ADD COLUMN "shipping_name" character varying(255) NULL, ADD COLUMN "shipping_line1" character varying(255) NULL, ADD COLUMN "shipping_line2" character varying(255) NULL, ADD COLUMN "shipping_city" character varying(100) NULL, ADD COLUMN "shipping_region" character varying(100) NULL, ADD COLUMN "shipping_postal_code" character varying(20) NULL, ADD COLUMN "shipping_country" character varying(2) NULL, ADD COLUMN "shipping_phone" character varying(32) NULL;
+-- Modify "order_lines" table
+ALTER TABLE "order_lines" ADD COLUMN "tax" bigint NOT NULL DEFAULT 0, ADD COLUMN "tax_rate" numeric(7,4) NOT NULL DEFAULT '0';
+-- Backfill the subtotal of existing orders, which were untaxed
+UPDATE "orders" SET "subtotal" = "total" + "discount";
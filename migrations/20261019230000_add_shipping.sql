@@ -0,0 +1,55 @@
+-- Create "shipping_zones" table
+CREATE TABLE "shipping_zones" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "name" character varying(255) NOT NULL,
+  "countries" jsonb NOT NULL DEFAULT '[]',
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_shipping_zones_deleted_at" to table: "shipping_zones"
+CREATE INDEX "idx_shipping_zones_deleted_at" ON "shipping_zones" ("deleted_at");
+-- Create "shipping_methods" table
+CREATE TABLE "shipping_methods" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "zone_id" uuid NOT NULL,
+  "name" character varying(255) NOT NULL,
+  "carrier" character varying(100) NULL,
+  "type" character varying(32) NOT NULL,
+  "price" bigint NOT NULL DEFAULT 0,
+  "price_per_kg" bigint NULL,
+  "free_over" bigint NULL,
+  "currency" character varying(3) NOT NULL,
+  "active" boolean NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_shipping_zones_methods" FOREIGN KEY ("zone_id") REFERENCES "shipping_zones" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_shipping_methods_deleted_at" to table: "shipping_methods"
+CREATE INDEX "idx_shipping_methods_deleted_at" ON "shipping_methods" ("deleted_at");
+-- Create index "idx_shipping_methods_zone_id" to table: "shipping_methods"
+CREATE INDEX "idx_shipping_methods_zone_id" ON "shipping_methods" ("zone_id");
+-- Create "shipments" table
+CREATE TABLE "shipments" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "order_id" uuid NOT NULL,
+  "carrier" character varying(100) NOT NULL,
+  "tracking_number" character varying(255) NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "shipped_at" timestamptz NOT NULL,
+  "delivered_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_shipments_order" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_shipments_deleted_at" to table: "shipments"
+CREATE INDEX "idx_shipments_deleted_at" ON "shipments" ("deleted_at");
+-- Create index "idx_shipments_order_id" to table: "shipments"
+CREATE INDEX "idx_shipments_order_id" ON "shipments" ("order_id");
+-- Modify "orders" table
+ALTER TABLE "orders" ADD COLUMN "shipping" bigint NOT NULL DEFAULT 0, ADD COLUMN "shipping_method" character varying(255) NULL;
@@ -0,0 +1,26 @@
+-- Create "addresses" table
+CREATE TABLE "addresses" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "user_id" uuid NOT NULL,
+  "name" character varying(255) NULL,
+  "line1" character varying(255) NULL,
+  "line2" character varying(255) NULL,
+  "city" character varying(100) NULL,
+  "region" character varying(100) NULL,
+  "postal_code" character varying(20) NULL,
+  "country" character varying(2) NULL,
+  "phone" character varying(32) NULL,
+  "default_shipping" boolean NOT NULL,
+  "default_billing" boolean NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_addresses_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_addresses_deleted_at" to table: "addresses"
+CREATE INDEX "idx_addresses_deleted_at" ON "addresses" ("deleted_at");
+-- Create index "idx_addresses_user_id" to table: "addresses"
+CREATE INDEX "idx_addresses_user_id" ON "addresses" ("user_id");
+-- Modify "orders" table
+ALTER TABLE "orders" ADD COLUMN "billing_name" character varying(255) NULL, ADD COLUMN "billing_line1" character varying(255) NULL, ADD COLUMN "billing_line2" character varying(255) NULL, ADD COLUMN "billing_city" character varying(100) NULL, ADD COLUMN "billing_region" character varying(100) NULL, ADD COLUMN "billing_postal_code" character varying(20) NULL, ADD COLUMN "billing_country" character varying(2) NULL, ADD COLUMN "billing_phone" character varying(32) NULL;
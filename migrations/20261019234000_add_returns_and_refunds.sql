@@ -0,0 +1,67 @@
+-- Create "returns" table
+CREATE TABLE "returns" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "order_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "status" character varying(20) NOT NULL,
+  "comment" text NULL,
+  "resolution" text NULL,
+  "refund_id" uuid NULL,
+  "received_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_returns_order" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_returns_deleted_at" to table: "returns"
+CREATE INDEX "idx_returns_deleted_at" ON "returns" ("deleted_at");
+-- Create index "idx_returns_order_id" to table: "returns"
+CREATE INDEX "idx_returns_order_id" ON "returns" ("order_id");
+-- Create index "idx_returns_status" to table: "returns"
+CREATE INDEX "idx_returns_status" ON "returns" ("status");
+-- Create index "idx_returns_user_id" to table: "returns"
+CREATE INDEX "idx_returns_user_id" ON "returns" ("user_id");
+-- Create "return_lines" table
+CREATE TABLE "return_lines" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "return_id" uuid NOT NULL,
+  "order_line_id" uuid NOT NULL,
+  "product_id" uuid NOT NULL,
+  "variant_id" uuid NULL,
+  "quantity" bigint NOT NULL,
+  "reason" character varying(32) NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_return_lines_order_line" FOREIGN KEY ("order_line_id") REFERENCES "order_lines" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_returns_lines" FOREIGN KEY ("return_id") REFERENCES "returns" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_return_lines_order_line_id" to table: "return_lines"
+CREATE INDEX "idx_return_lines_order_line_id" ON "return_lines" ("order_line_id");
+-- Create index "idx_return_lines_return_id" to table: "return_lines"
+CREATE INDEX "idx_return_lines_return_id" ON "return_lines" ("return_id");
+-- Create "refunds" table
+CREATE TABLE "refunds" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "order_id" uuid NOT NULL,
+  "return_id" uuid NULL,
+  "amount" bigint NOT NULL,
+  "currency" character varying(3) NOT NULL,
+  "reason" character varying(255) NULL,
+  "status" character varying(20) NOT NULL,
+  "provider_id" character varying(255) NULL,
+  "actor_id" uuid NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_refunds_order" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_refunds_return" FOREIGN KEY ("return_id") REFERENCES "returns" ("id") ON UPDATE CASCADE ON DELETE SET NULL
+);
+-- Create index "idx_refunds_deleted_at" to table: "refunds"
+CREATE INDEX "idx_refunds_deleted_at" ON "refunds" ("deleted_at");
+-- Create index "idx_refunds_order_id" to table: "refunds"
+CREATE INDEX "idx_refunds_order_id" ON "refunds" ("order_id");
+-- Create index "idx_refunds_return_id" to table: "refunds"
+CREATE INDEX "idx_refunds_return_id" ON "refunds" ("return_id");
+-- Modify "orders" table
+ALTER TABLE "orders" ADD COLUMN "refunded" bigint NOT NULL DEFAULT 0;
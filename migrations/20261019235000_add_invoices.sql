@@ -0,0 +1,32 @@
+-- Create "invoices" table
+CREATE TABLE "invoices" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "type" character varying(20) NOT NULL,
+  "number" character varying(32) NOT NULL,
+  "order_id" uuid NOT NULL,
+  "refund_id" uuid NULL,
+  "amount" bigint NOT NULL,
+  "currency" character varying(3) NOT NULL,
+  "blob_key" character varying(255) NULL,
+  "issued_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_invoices_order" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE CASCADE ON DELETE RESTRICT,
+  CONSTRAINT "fk_invoices_refund" FOREIGN KEY ("refund_id") REFERENCES "refunds" ("id") ON UPDATE CASCADE ON DELETE RESTRICT
+);
+-- Create index "idx_invoices_deleted_at" to table: "invoices"
+CREATE INDEX "idx_invoices_deleted_at" ON "invoices" ("deleted_at");
+-- Create index "idx_invoices_number" to table: "invoices"
+CREATE UNIQUE INDEX "idx_invoices_number" ON "invoices" ("number");
+-- Create index "idx_invoices_order_type" to table: "invoices"
+CREATE INDEX "idx_invoices_order_type" ON "invoices" ("order_id","type");
+-- Create index "idx_invoices_refund_id" to table: "invoices"
+CREATE UNIQUE INDEX "idx_invoices_refund_id" ON "invoices" ("refund_id");
+-- Create "invoice_sequences" table
+CREATE TABLE "invoice_sequences" (
+  "series" character varying(32) NOT NULL,
+  "last_value" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("series")
+);
@@ -0,0 +1,6 @@
+-- Modify "carts" table
+ALTER TABLE "carts" ALTER COLUMN "user_id" DROP NOT NULL;
+-- Modify "orders" table
+ALTER TABLE "orders" ALTER COLUMN "user_id" DROP NOT NULL, ADD COLUMN "email" character varying(255) NULL;
+-- Modify "promotion_redemptions" table
+ALTER TABLE "promotion_redemptions" ALTER COLUMN "user_id" DROP NOT NULL;
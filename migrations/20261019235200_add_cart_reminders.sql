@@ -0,0 +1,23 @@
+-- Create "cart_reminders" table
+CREATE TABLE "cart_reminders" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "cart_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "email" character varying(255) NOT NULL,
+  "sequence" bigint NOT NULL,
+  "value" bigint NOT NULL,
+  "currency" character varying(3) NOT NULL,
+  "order_id" uuid NULL,
+  "sent_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_cart_reminders_cart" FOREIGN KEY ("cart_id") REFERENCES "carts" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_cart_reminders_order" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE CASCADE ON DELETE SET NULL
+);
+-- Create index "idx_cart_reminders_cart_id" to table: "cart_reminders"
+CREATE INDEX "idx_cart_reminders_cart_id" ON "cart_reminders" ("cart_id");
+-- Create index "idx_cart_reminders_order_id" to table: "cart_reminders"
+CREATE INDEX "idx_cart_reminders_order_id" ON "cart_reminders" ("order_id");
+-- Create index "idx_cart_reminders_sent_at" to table: "cart_reminders"
+CREATE INDEX "idx_cart_reminders_sent_at" ON "cart_reminders" ("sent_at");
+-- Create index "idx_cart_reminders_user_id" to table: "cart_reminders"
+CREATE INDEX "idx_cart_reminders_user_id" ON "cart_reminders" ("user_id");
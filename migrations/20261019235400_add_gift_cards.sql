@@ -0,0 +1,77 @@
+-- Create "gift_cards" table
+CREATE TABLE "gift_cards" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "code" character varying(32) NOT NULL,
+  "initial" bigint NOT NULL,
+  "balance" bigint NOT NULL,
+  "currency" character varying(3) NOT NULL,
+  "order_id" uuid NULL,
+  "order_line_id" uuid NULL,
+  "email" character varying(255) NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_gift_cards_order" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE CASCADE ON DELETE RESTRICT
+);
+-- Create index "idx_gift_cards_code" to table: "gift_cards"
+CREATE UNIQUE INDEX "idx_gift_cards_code" ON "gift_cards" ("code");
+-- Create index "idx_gift_cards_deleted_at" to table: "gift_cards"
+CREATE INDEX "idx_gift_cards_deleted_at" ON "gift_cards" ("deleted_at");
+-- Create index "idx_gift_cards_order_id" to table: "gift_cards"
+CREATE INDEX "idx_gift_cards_order_id" ON "gift_cards" ("order_id");
+-- Create index "idx_gift_cards_order_line_id" to table: "gift_cards"
+CREATE INDEX "idx_gift_cards_order_line_id" ON "gift_cards" ("order_line_id");
+-- Create "store_credits" table
+CREATE TABLE "store_credits" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "user_id" uuid NOT NULL,
+  "currency" character varying(3) NOT NULL,
+  "balance" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_store_credits_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_store_credits_deleted_at" to table: "store_credits"
+CREATE INDEX "idx_store_credits_deleted_at" ON "store_credits" ("deleted_at");
+-- Create index "idx_store_credits_user_currency" to table: "store_credits"
+CREATE UNIQUE INDEX "idx_store_credits_user_currency" ON "store_credits" ("user_id","currency");
+-- Create "balance_entries" table
+CREATE TABLE "balance_entries" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "gift_card_id" uuid NULL,
+  "store_credit_id" uuid NULL,
+  "type" character varying(20) NOT NULL,
+  "amount" bigint NOT NULL,
+  "balance_after" bigint NOT NULL,
+  "currency" character varying(3) NOT NULL,
+  "order_id" uuid NULL,
+  "refund_id" uuid NULL,
+  "actor_id" uuid NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_balance_entries_gift_card" FOREIGN KEY ("gift_card_id") REFERENCES "gift_cards" ("id") ON UPDATE CASCADE ON DELETE RESTRICT,
+  CONSTRAINT "fk_balance_entries_order" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE CASCADE ON DELETE RESTRICT,
+  CONSTRAINT "fk_balance_entries_refund" FOREIGN KEY ("refund_id") REFERENCES "refunds" ("id") ON UPDATE CASCADE ON DELETE RESTRICT,
+  CONSTRAINT "fk_balance_entries_store_credit" FOREIGN KEY ("store_credit_id") REFERENCES "store_credits" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "idx_balance_entries_created_at" to table: "balance_entries"
+CREATE INDEX "idx_balance_entries_created_at" ON "balance_entries" ("created_at");
+-- Create index "idx_balance_entries_gift_card_id" to table: "balance_entries"
+CREATE INDEX "idx_balance_entries_gift_card_id" ON "balance_entries" ("gift_card_id");
+-- Create index "idx_balance_entries_order_id" to table: "balance_entries"
+CREATE INDEX "idx_balance_entries_order_id" ON "balance_entries" ("order_id");
+-- Create index "idx_balance_entries_refund_id" to table: "balance_entries"
+CREATE UNIQUE INDEX "idx_balance_entries_refund_id" ON "balance_entries" ("refund_id");
+-- Create index "idx_balance_entries_store_credit_id" to table: "balance_entries"
+CREATE INDEX "idx_balance_entries_store_credit_id" ON "balance_entries" ("store_credit_id");
+-- Modify "products" table
+ALTER TABLE "products" ADD COLUMN "gift_card" boolean NOT NULL DEFAULT false;
+-- Modify "orders" table
+ALTER TABLE "orders" ADD COLUMN "redeemed" bigint NOT NULL DEFAULT 0;
+-- Modify "order_lines" table
+ALTER TABLE "order_lines" ADD COLUMN "gift_card" boolean NOT NULL DEFAULT false;
+-- Modify "refunds" table
+ALTER TABLE "refunds" ADD COLUMN "store_credit" bigint NOT NULL DEFAULT 0;
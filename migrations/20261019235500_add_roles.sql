@@ -0,0 +1,24 @@
+-- Create "roles" table
+CREATE TABLE "roles" (
+  "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "name" text NOT NULL,
+  "description" text NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "uni_roles_name" UNIQUE ("name")
+);
+-- Create index "idx_roles_deleted_at" to table: "roles"
+CREATE INDEX "idx_roles_deleted_at" ON "roles" ("deleted_at");
+-- Create "user_roles" table
+CREATE TABLE "user_roles" (
+  "user_model_id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "role_model_id" uuid NOT NULL DEFAULT gen_random_uuid(),
+  PRIMARY KEY ("user_model_id", "role_model_id"),
+  CONSTRAINT "fk_user_roles_role_model" FOREIGN KEY ("role_model_id") REFERENCES "roles" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fk_user_roles_user_model" FOREIGN KEY ("user_model_id") REFERENCES "users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Seed the role required by the manager API; grant it with
+-- INSERT INTO user_roles (user_model_id, role_model_id) SELECT '<user id>', id FROM roles WHERE name = 'manager';
+INSERT INTO "roles" ("name", "description", "created_at", "updated_at") VALUES ('manager', 'Access to the manager API', now(), now());
@@ -0,0 +1,5 @@
+-- Modify "orders" table
+ALTER TABLE "orders" ADD COLUMN "fulfilment" character varying(20) NOT NULL DEFAULT 'unfulfilled';
+-- Backfill from shipments: shipped when an order has any, delivered once all are
+UPDATE "orders" SET "fulfilment" = 'shipped' WHERE "id" IN (SELECT "order_id" FROM "shipments");
+UPDATE "orders" SET "fulfilment" = 'delivered' WHERE "fulfilment" = 'shipped' AND NOT EXISTS (SELECT 1 FROM "shipments" WHERE "shipments"."order_id" = "orders"."id" AND "shipments"."status" <> 'delivered');
@@ -0,0 +1,8 @@
+-- Modify "gift_cards" table
+ALTER TABLE "gift_cards" ADD COLUMN "voided_at" timestamptz NULL;
+-- Create index "idx_balance_entries_refund_credit" to table: "balance_entries"
+CREATE UNIQUE INDEX "idx_balance_entries_refund_credit" ON "balance_entries" ("refund_id") WHERE store_credit_id IS NOT NULL;
+-- Drop index "idx_balance_entries_refund_id" from table: "balance_entries"
+DROP INDEX "idx_balance_entries_refund_id";
+-- Create index "idx_balance_entries_refund_id" to table: "balance_entries"
+CREATE INDEX "idx_balance_entries_refund_id" ON "balance_entries" ("refund_id");
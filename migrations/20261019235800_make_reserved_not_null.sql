@@ -0,0 +1,7 @@
+-- Backfill reserved stock left NULL, which no availability guard can pass
+UPDATE "products" SET "reserved" = 0 WHERE "reserved" IS NULL;
+UPDATE "product_variants" SET "reserved" = 0 WHERE "reserved" IS NULL;
+-- Modify "products" table
+ALTER TABLE "products" ALTER COLUMN "reserved" SET NOT NULL;
+-- Modify "product_variants" table
+ALTER TABLE "product_variants" ALTER COLUMN "reserved" SET NOT NULL;
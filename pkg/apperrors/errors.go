@@ -28,6 +28,7 @@ func New(code, message string, status int) *AppError {
 var (
 	ErrDatabaseError  = New("DATABASE_ERROR", "A database error occurred", http.StatusInternalServerError)
 	ErrDuplicateEntry = New("DUPLICATE_ENTRY", "Duplicated entry", http.StatusConflict)
+	ErrStorageError   = New("STORAGE_ERROR", "A file storage error occurred", http.StatusInternalServerError)
 )
 
 // User-related errors
@@ -51,6 +52,158 @@ var (
 // Request errors
 var (
 	ErrRequestInvalidBody = New("INVALID_REQUEST_BODY", "Invalid request body", http.StatusBadRequest)
+	ErrRequestTooLarge    = New("REQUEST_TOO_LARGE", "Request body exceeds the maximum size", http.StatusRequestEntityTooLarge)
+	ErrInvalidCursor      = New("INVALID_CURSOR", "Invalid pagination cursor", http.StatusBadRequest)
+	ErrUnsupportedSort    = New("UNSUPPORTED_SORT", "This sort order only supports page-based pagination", http.StatusBadRequest)
+)
+
+// Product errors
+var (
+	ErrVariantRequired       = New("VARIANT_REQUIRED", "A variant must be selected for this product", http.StatusBadRequest)
+	ErrInvalidVariantOptions = New("INVALID_VARIANT_OPTIONS", "A variant must select exactly one value for each product option", http.StatusBadRequest)
+	ErrDuplicateVariant      = New("DUPLICATE_VARIANT", "A variant with these options already exists", http.StatusConflict)
+	ErrProductUnavailable    = New("PRODUCT_UNAVAILABLE", "Product is not available for purchase", http.StatusBadRequest)
+	ErrInsufficientStock     = New("INSUFFICIENT_STOCK", "Not enough stock for the requested quantity", http.StatusConflict)
+	ErrSlugTaken             = New("SLUG_TAKEN", "Slug is already used by another product", http.StatusConflict)
+	ErrInvalidMeasurement    = New("INVALID_MEASUREMENT", "Weight and dimensions cannot be negative", http.StatusBadRequest)
+	ErrInvalidSale           = New("INVALID_SALE", "Sale price must be below the regular price and the sale must end after it starts", http.StatusBadRequest)
+	ErrInvalidPriceSchedule  = New("INVALID_PRICE_SCHEDULE", "Scheduled price changes must take effect in the future", http.StatusBadRequest)
+)
+
+// Recommendation errors
+var (
+	ErrInvalidProductLink = New("INVALID_PRODUCT_LINK", "Links need a type of related, upsell or cross_sell and a different target product", http.StatusBadRequest)
+)
+
+// Import errors
+var (
+	ErrInvalidImportFormat = New("INVALID_IMPORT_FORMAT", "Format must be csv or ndjson", http.StatusBadRequest)
+	ErrInvalidImportFile   = New("INVALID_IMPORT_FILE", "Import file is empty or cannot be read in the given format", http.StatusBadRequest)
+	ErrImportTooLarge      = New("IMPORT_TOO_LARGE", "Import file exceeds the maximum upload size", http.StatusRequestEntityTooLarge)
+)
+
+// Inventory errors
+var (
+	ErrInvalidMovementType      = New("INVALID_MOVEMENT_TYPE", "Stock movement type cannot be recorded manually", http.StatusBadRequest)
+	ErrAdjustmentReasonRequired = New("ADJUSTMENT_REASON_REQUIRED", "A reason is required for stock adjustments", http.StatusBadRequest)
+	ErrStockReserved            = New("STOCK_RESERVED", "Stock cannot drop below the quantity reserved by pending checkouts", http.StatusConflict)
+)
+
+// Money errors
+var (
+	ErrInvalidAmount    = New("INVALID_AMOUNT", "Amount must be a decimal string with no more decimal places than the currency allows", http.StatusBadRequest)
+	ErrInvalidCurrency  = New("INVALID_CURRENCY", "Currency must be a three-letter ISO 4217 code", http.StatusBadRequest)
+	ErrCurrencyMismatch = New("CURRENCY_MISMATCH", "Amounts in different currencies cannot be combined", http.StatusBadRequest)
+)
+
+// Pricing errors
+var (
+	ErrUnsupportedCurrency   = New("UNSUPPORTED_CURRENCY", "Prices are not available in the requested currency", http.StatusBadRequest)
+	ErrInvalidExchangeRate   = New("INVALID_EXCHANGE_RATE", "Exchange rate must be a positive decimal", http.StatusBadRequest)
+	ErrExchangeRatesReadOnly = New("EXCHANGE_RATES_READ_ONLY", "Exchange rates are read from a rates file and cannot be changed here", http.StatusConflict)
+)
+
+// Tax errors
+var (
+	ErrInvalidTaxRate = New("INVALID_TAX_RATE", "Tax rate needs a two-letter country, a tax class and a percentage between 0 and 100", http.StatusBadRequest)
+)
+
+// Shipping errors
+var (
+	ErrInvalidShippingZone       = New("INVALID_SHIPPING_ZONE", "Shipping zone needs a name and two-letter country codes", http.StatusBadRequest)
+	ErrShippingZoneOverlap       = New("SHIPPING_ZONE_OVERLAP", "A country can only belong to one shipping zone, and only one zone can cover the rest of the world", http.StatusConflict)
+	ErrInvalidShippingMethod     = New("INVALID_SHIPPING_METHOD", "Shipping method needs a name, a known type and the prices its type uses", http.StatusBadRequest)
+	ErrShippingMethodUnavailable = New("SHIPPING_METHOD_UNAVAILABLE", "Choose a shipping method available for this address", http.StatusBadRequest)
+	ErrInvalidShipment           = New("INVALID_SHIPMENT", "Shipment needs a carrier and a tracking number", http.StatusBadRequest)
+	ErrOrderNotShippable         = New("ORDER_NOT_SHIPPABLE", "Only paid, shipped or partially refunded orders can receive shipments", http.StatusConflict)
+)
+
+// Return and refund errors
+var (
+	ErrInvalidReturn           = New("INVALID_RETURN", "Return needs at least one line of the order with a quantity and a known reason", http.StatusBadRequest)
+	ErrReturnQuantityExceeded  = New("RETURN_QUANTITY_EXCEEDED", "Return quantity exceeds what was bought and not already returned", http.StatusConflict)
+	ErrInvalidReturnTransition = New("INVALID_RETURN_TRANSITION", "Return cannot be moved to this status from its current status", http.StatusConflict)
+	ErrOrderNotReturnable      = New("ORDER_NOT_RETURNABLE", "Only paid orders can be returned", http.StatusConflict)
+	ErrOrderNotRefundable      = New("ORDER_NOT_REFUNDABLE", "Only paid orders that are not fully refunded can be refunded", http.StatusConflict)
+	ErrRefundExceedsTotal      = New("REFUND_EXCEEDS_TOTAL", "Refunds cannot exceed the order total", http.StatusConflict)
+)
+
+// Invoice errors
+var (
+	ErrOrderNotInvoiceable  = New("ORDER_NOT_INVOICEABLE", "Invoices are only issued for paid orders", http.StatusConflict)
+	ErrDocumentRenderFailed = New("DOCUMENT_RENDER_FAILED", "Document could not be generated", http.StatusInternalServerError)
+)
+
+// Promotion errors
+var (
+	ErrInvalidPromotion      = New("INVALID_PROMOTION", "Promotion rules are incomplete or inconsistent with its type", http.StatusBadRequest)
+	ErrInvalidDiscountCode   = New("INVALID_DISCOUNT_CODE", "Discount code does not exist or is not active", http.StatusBadRequest)
+	ErrDiscountNotApplicable = New("DISCOUNT_NOT_APPLICABLE", "Discount code does not apply to the items or value of this cart", http.StatusBadRequest)
+	ErrDiscountUsageLimit    = New("DISCOUNT_USAGE_LIMIT_REACHED", "Discount code has reached its usage limit", http.StatusConflict)
+)
+
+// Review errors
+var (
+	ErrInvalidRating           = New("INVALID_RATING", "Rating must be between 1 and 5", http.StatusBadRequest)
+	ErrReviewNotVerified       = New("REVIEW_NOT_VERIFIED", "Only customers with a paid order for this product can review it", http.StatusForbidden)
+	ErrAlreadyReviewed         = New("ALREADY_REVIEWED", "You have already reviewed this product", http.StatusConflict)
+	ErrInvalidReviewTransition = New("INVALID_REVIEW_TRANSITION", "Review cannot be moved to this status from its current status", http.StatusConflict)
+)
+
+// SEO errors
+var (
+	ErrInvalidSlug         = New("INVALID_SLUG", "Slug may only contain lowercase letters, digits and single hyphens", http.StatusBadRequest)
+	ErrInvalidCanonicalURL = New("INVALID_CANONICAL_URL", "Canonical URL must be an absolute http or https URL", http.StatusBadRequest)
+)
+
+// Category errors
+var (
+	ErrCategoryCycle         = New("CATEGORY_CYCLE", "A category cannot be moved under itself or one of its descendants", http.StatusConflict)
+	ErrCategoryTooDeep       = New("CATEGORY_TOO_DEEP", "Category tree would exceed the maximum depth", http.StatusConflict)
+	ErrCategoryNotEmpty      = New("CATEGORY_NOT_EMPTY", "Category has products; pass reassign_to to move them before deleting", http.StatusConflict)
+	ErrInvalidReassignTarget = New("INVALID_REASSIGN_TARGET", "Products can only be reassigned to another existing category", http.StatusBadRequest)
+	ErrInvalidAttribute      = New("INVALID_ATTRIBUTE", "Attribute needs a slug key and a known type; only enum attributes take options", http.StatusBadRequest)
+	ErrInvalidAttributeValue = New("INVALID_ATTRIBUTE_VALUE", "Specification value does not match the attribute type", http.StatusBadRequest)
+	ErrAttributeRequired     = New("ATTRIBUTE_REQUIRED", "A value is required for every required attribute of the category", http.StatusBadRequest)
+	ErrUnknownAttribute      = New("UNKNOWN_ATTRIBUTE", "Specification key is not an attribute of the product's category", http.StatusBadRequest)
+)
+
+// Cart and order errors
+var (
+	ErrInvalidQuantity     = New("INVALID_QUANTITY", "Quantity must be greater than zero", http.StatusBadRequest)
+	ErrCartEmpty           = New("CART_EMPTY", "Cart is empty", http.StatusBadRequest)
+	ErrInvalidCartToken    = New("INVALID_CART_TOKEN", "Cart token is malformed or was not issued by this store", http.StatusBadRequest)
+	ErrInvalidRestoreLink  = New("INVALID_RESTORE_LINK", "Cart link is malformed, has expired or was not issued by this store", http.StatusBadRequest)
+	ErrGuestEmailRequired  = New("GUEST_EMAIL_REQUIRED", "An email address is required to check out as a guest", http.StatusBadRequest)
+	ErrCheckoutExpired     = New("CHECKOUT_EXPIRED", "Order was cancelled before it was paid; please check out again", http.StatusConflict)
+	ErrInvalidReportPeriod = New("INVALID_REPORT_PERIOD", "Report needs dates as YYYY-MM-DD with from before to, and an interval of day, week or month", http.StatusBadRequest)
+	ErrInvalidAddress      = New("INVALID_ADDRESS", "Address needs a name, street, city and two-letter country code", http.StatusBadRequest)
+	ErrInvalidPostalCode   = New("INVALID_POSTAL_CODE", "Postal code is missing or not valid for the country", http.StatusBadRequest)
+)
+
+// Idempotency errors
+var (
+	ErrInvalidIdempotencyKey  = New("INVALID_IDEMPOTENCY_KEY", "Idempotency key must be between 1 and 255 characters", http.StatusBadRequest)
+	ErrIdempotencyKeyInFlight = New("IDEMPOTENCY_KEY_IN_FLIGHT", "A request with this idempotency key is still being processed", http.StatusConflict)
+	ErrIdempotencyKeyReused   = New("IDEMPOTENCY_KEY_REUSED", "Idempotency key was already used for a different request", http.StatusUnprocessableEntity)
+)
+
+// Gift card and store credit errors
+var (
+	ErrInvalidGiftCard            = New("INVALID_GIFT_CARD", "Gift card code does not exist", http.StatusBadRequest)
+	ErrGiftCardEmpty              = New("GIFT_CARD_EMPTY", "Gift card has no balance left", http.StatusConflict)
+	ErrGiftCardCurrencyMismatch   = New("GIFT_CARD_CURRENCY_MISMATCH", "Gift card is in a different currency than the cart", http.StatusBadRequest)
+	ErrGiftCardPurchaseNotAllowed = New("GIFT_CARD_PURCHASE_NOT_ALLOWED", "Gift cards can only be bought with a card payment", http.StatusBadRequest)
+	ErrGiftCardOrderNotPaid       = New("GIFT_CARD_ORDER_NOT_PAID", "Gift cards are only issued for paid orders", http.StatusConflict)
+	ErrGiftCardNotRefundable      = New("GIFT_CARD_NOT_REFUNDABLE", "Gift cards are only refunded by returning them unspent", http.StatusConflict)
+	ErrStoreCreditUnavailable     = New("STORE_CREDIT_UNAVAILABLE", "Store credit can only be issued to customers with an account", http.StatusConflict)
+)
+
+// Payment errors
+var (
+	ErrPaymentFailed           = New("PAYMENT_FAILED", "Payment could not be started", http.StatusBadGateway)
+	ErrRefundFailed            = New("REFUND_FAILED", "Refund was not accepted by the payment provider", http.StatusBadGateway)
+	ErrInvalidWebhookSignature = New("INVALID_WEBHOOK_SIGNATURE", "Invalid webhook signature", http.StatusBadRequest)
 )
 
 // Resource errors
@@ -0,0 +1,109 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/RubenRodrigo/go-tiny-store/pkg/apperrors"
+)
+
+// Cursor identifies a row in a keyset ordered by (created_at, id)
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+type cursorToken struct {
+	Cursor
+	Backward bool `json:"b,omitempty"`
+}
+
+// signingKey has no default: cursors signed with a well-known key could be forged
+var signingKey []byte
+
+// SetSigningKey sets the HMAC key used to sign cursors. Call it once at
+// startup; an empty key panics.
+func SetSigningKey(key string) {
+	if key == "" {
+		panic("pagination: empty cursor signing key")
+	}
+	signingKey = []byte(key)
+}
+
+// EncodeCursor returns an opaque, signed token for the cursor
+func EncodeCursor(c Cursor, backward bool) string {
+	payload, _ := json.Marshal(cursorToken{Cursor: c, Backward: backward})
+	body := base64.RawURLEncoding.EncodeToString(payload)
+
+	return body + "." + sign(body)
+}
+
+// DecodeCursor verifies and decodes a token produced by EncodeCursor
+func DecodeCursor(token string) (*Cursor, bool, error) {
+	body, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(sign(body))) {
+		return nil, false, apperrors.ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, false, apperrors.ErrInvalidCursor
+	}
+
+	var t cursorToken
+	if err := json.Unmarshal(payload, &t); err != nil || t.ID == "" {
+		return nil, false, apperrors.ErrInvalidCursor
+	}
+
+	return &t.Cursor, t.Backward, nil
+}
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// BuildCursorResult builds a keyset page. Repositories fetch Limit()+1 rows so
+// the extra row tells whether another page exists; rows fetched backwards are
+// returned in reverse order and are flipped back here.
+func BuildCursorResult[T any](params Params, totalItems int64, data []T, key func(T) Cursor) Result[T] {
+	hasMore := len(data) > params.Limit()
+	if hasMore {
+		data = data[:params.Limit()]
+	}
+	if params.Backward {
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+
+	meta := Meta{PageSize: params.PageSize}
+	if params.IncludeTotal {
+		meta.TotalItems = &totalItems
+	}
+
+	if len(data) > 0 {
+		first, last := key(data[0]), key(data[len(data)-1])
+
+		if (!params.Backward && hasMore) || (params.Backward && params.Cursor != nil) {
+			meta.Next = params.cursorLink(EncodeCursor(last, false))
+		}
+		if (params.Backward && hasMore) || (!params.Backward && params.Cursor != nil) {
+			meta.Prev = params.cursorLink(EncodeCursor(first, true))
+		}
+	}
+
+	return Result[T]{
+		Meta: meta,
+		Data: data,
+	}
+}
+
+func (p *Params) cursorLink(token string) string {
+	return p.link(map[string]string{"cursor": token}, "page", "mode")
+}
@@ -3,13 +3,34 @@ package pagination
 import (
 	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 )
 
+// Mode selects how a listing is paginated
+type Mode string
+
+const (
+	// ModeOffset paginates with page/page_size (default, backwards compatible)
+	ModeOffset Mode = "offset"
+	// ModeCursor paginates with opaque keyset cursors
+	ModeCursor Mode = "cursor"
+)
+
 // Params
 type Params struct {
-	Page     int `json:"page"`
-	PageSize int `json:"page_size"`
+	Page     int  `json:"page"`
+	PageSize int  `json:"page_size"`
+	Mode     Mode `json:"mode"`
+
+	// Cursor is the boundary row of the previous page in cursor mode (nil for the first page)
+	Cursor *Cursor `json:"-"`
+	// Backward is true when paging towards newer rows (a "prev" link was followed)
+	Backward bool `json:"-"`
+	// IncludeTotal requests a total count in cursor mode; offset mode always counts
+	IncludeTotal bool `json:"-"`
+
+	baseURL url.URL
 }
 
 func (p *Params) Offset() int {
@@ -20,12 +41,24 @@ func (p *Params) Limit() int {
 	return p.PageSize
 }
 
+// IsCursor reports whether keyset pagination was requested
+func (p *Params) IsCursor() bool {
+	return p.Mode == ModeCursor
+}
+
+// NeedsTotal reports whether the repository has to run a count query
+func (p *Params) NeedsTotal() bool {
+	return !p.IsCursor() || p.IncludeTotal
+}
+
 // Info
 type Meta struct {
-	Page       int   `json:"page"`
-	PageSize   int   `json:"page_size"`
-	TotalItems int64 `json:"total_items"`
-	TotalPages int   `json:"total_pages"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	TotalItems *int64 `json:"total_items,omitempty"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
 }
 
 type Result[T any] struct {
@@ -33,9 +66,13 @@ type Result[T any] struct {
 	Data []T  `json:"data"`
 }
 
-func ParseParams(r *http.Request) Params {
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+// ParseParams reads pagination query parameters. Passing `cursor` (or
+// `mode=cursor` for the first page) switches to keyset pagination.
+func ParseParams(r *http.Request) (Params, error) {
+	query := r.URL.Query()
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
 	if page < 1 {
 		page = 1
 	}
@@ -43,22 +80,52 @@ func ParseParams(r *http.Request) Params {
 		pageSize = 10
 	}
 
-	return Params{
+	params := Params{
 		Page:     page,
 		PageSize: pageSize,
+		Mode:     ModeOffset,
+		baseURL:  *r.URL,
+	}
+
+	includeTotal, _ := strconv.ParseBool(query.Get("include_total"))
+
+	if token := query.Get("cursor"); token != "" {
+		cursor, backward, err := DecodeCursor(token)
+		if err != nil {
+			return Params{}, err
+		}
+		params.Mode = ModeCursor
+		params.Page = 0
+		params.Cursor = cursor
+		params.Backward = backward
+		params.IncludeTotal = includeTotal
+	} else if Mode(query.Get("mode")) == ModeCursor {
+		params.Mode = ModeCursor
+		params.Page = 0
+		params.IncludeTotal = includeTotal
 	}
 
+	return params, nil
 }
 
 func BuildMeta(params Params, totalItems int64) Meta {
 	totalPages := int(math.Ceil(float64(totalItems) / float64(params.PageSize)))
 
-	return Meta{
+	meta := Meta{
 		Page:       params.Page,
 		PageSize:   params.PageSize,
-		TotalItems: totalItems,
-		TotalPages: totalPages,
+		TotalItems: &totalItems,
+		TotalPages: &totalPages,
 	}
+
+	if params.Page < totalPages {
+		meta.Next = params.link(map[string]string{"page": strconv.Itoa(params.Page + 1)})
+	}
+	if params.Page > 1 {
+		meta.Prev = params.link(map[string]string{"page": strconv.Itoa(params.Page - 1)})
+	}
+
+	return meta
 }
 
 func BuildResult[T any](params Params, totalItems int64, data []T) Result[T] {
@@ -67,3 +134,28 @@ func BuildResult[T any](params Params, totalItems int64, data []T) Result[T] {
 		Data: data,
 	}
 }
+
+// BuildPagedResult builds the result for either mode. In cursor mode data is
+// expected to hold up to Limit()+1 rows in query order (see BuildCursorResult).
+func BuildPagedResult[T any](params Params, totalItems int64, data []T, key func(T) Cursor) Result[T] {
+	if params.IsCursor() {
+		return BuildCursorResult(params, totalItems, data, key)
+	}
+	return BuildResult(params, totalItems, data)
+}
+
+// link returns the request URL (path and query) with the given query values
+// replaced and the drop keys removed
+func (p *Params) link(values map[string]string, drop ...string) string {
+	u := p.baseURL
+	query := u.Query()
+	for _, k := range drop {
+		query.Del(k)
+	}
+	for k, v := range values {
+		query.Set(k, v)
+	}
+	u.RawQuery = query.Encode()
+
+	return (&url.URL{Path: u.Path, RawQuery: u.RawQuery}).String()
+}
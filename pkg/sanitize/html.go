@@ -0,0 +1,194 @@
+package sanitize
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// allowedTags are the formatting tags kept by HTML; all attributes are dropped
+// except href on links
+var allowedTags = map[string]bool{
+	"p": true, "br": true, "strong": true, "b": true, "em": true, "i": true, "u": true,
+	"ul": true, "ol": true, "li": true, "h2": true, "h3": true, "h4": true,
+	"blockquote": true, "a": true,
+}
+
+// voidTags never have content or a closing tag
+var voidTags = map[string]bool{"br": true}
+
+// droppedTags are removed together with their content
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"template": true, "noscript": true, "textarea": true, "title": true,
+}
+
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// HTML reduces rich text to an allowlist of formatting tags. Text is
+// re-escaped, unknown tags are removed keeping their text, tags that carry
+// code are removed with their content, and unclosed tags are closed.
+func HTML(input string) string {
+	var out strings.Builder
+	var open []string
+	skipUntil := ""
+
+	for len(input) > 0 {
+		lt := strings.IndexByte(input, '<')
+		if lt < 0 {
+			if skipUntil == "" {
+				out.WriteString(escapeText(input))
+			}
+			break
+		}
+		if lt > 0 && skipUntil == "" {
+			out.WriteString(escapeText(input[:lt]))
+		}
+		input = input[lt:]
+
+		if strings.HasPrefix(input, "<!--") {
+			end := strings.Index(input, "-->")
+			if end < 0 {
+				break
+			}
+			input = input[end+3:]
+			continue
+		}
+
+		// A "<" not followed by a tag name is text
+		if len(input) < 2 || !isTagStart(input[1]) {
+			if skipUntil == "" {
+				out.WriteString("&lt;")
+			}
+			input = input[1:]
+			continue
+		}
+
+		gt := strings.IndexByte(input, '>')
+		if gt < 0 {
+			// An unterminated tag is text
+			if skipUntil == "" {
+				out.WriteString(escapeText(input))
+			}
+			break
+		}
+		raw := input[1:gt]
+		input = input[gt+1:]
+
+		name, closing, attrs := parseTag(raw)
+		if name == "" {
+			if skipUntil == "" {
+				out.WriteString(escapeText("<" + raw + ">"))
+			}
+			continue
+		}
+
+		if skipUntil != "" {
+			if closing && name == skipUntil {
+				skipUntil = ""
+			}
+			continue
+		}
+		if droppedTags[name] {
+			if !closing && !strings.HasSuffix(raw, "/") {
+				skipUntil = name
+			}
+			continue
+		}
+		if !allowedTags[name] {
+			continue
+		}
+
+		switch {
+		case voidTags[name]:
+			out.WriteString("<" + name + ">")
+		case closing:
+			// Close back to the matching tag; stray closing tags are dropped
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == name {
+					for j := len(open) - 1; j >= i; j-- {
+						out.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+		default:
+			out.WriteString("<" + name)
+			if name == "a" {
+				if href, ok := safeHref(attrs); ok {
+					out.WriteString(` href="` + html.EscapeString(href) + `" rel="nofollow noopener"`)
+				}
+			}
+			out.WriteString(">")
+			open = append(open, name)
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+
+	return strings.TrimSpace(out.String())
+}
+
+func isTagStart(c byte) bool {
+	return c == '/' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// escapeText normalises entities so text is escaped exactly once
+func escapeText(s string) string {
+	return html.EscapeString(html.UnescapeString(s))
+}
+
+// parseTag splits the inside of a tag into its lowercase name, whether it is a
+// closing tag, and the remaining attribute text
+func parseTag(raw string) (name string, closing bool, attrs string) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "/") {
+		closing = true
+		raw = strings.TrimSpace(raw[1:])
+	}
+
+	end := strings.IndexFunc(raw, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	if end < 0 {
+		end = len(raw)
+	}
+	return strings.ToLower(raw[:end]), closing, raw[end:]
+}
+
+// safeHref extracts the href attribute when it is an absolute URL with an
+// allowed scheme
+func safeHref(attrs string) (string, bool) {
+	lower := strings.ToLower(attrs)
+	idx := strings.Index(lower, "href")
+	if idx < 0 {
+		return "", false
+	}
+
+	rest := strings.TrimSpace(attrs[idx+len("href"):])
+	if !strings.HasPrefix(rest, "=") {
+		return "", false
+	}
+	rest = strings.TrimSpace(rest[1:])
+
+	var value string
+	if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+		end := strings.IndexByte(rest[1:], rest[0])
+		if end < 0 {
+			return "", false
+		}
+		value = rest[1 : end+1]
+	} else {
+		value = strings.Fields(rest + " ")[0]
+	}
+
+	value = strings.TrimSpace(html.UnescapeString(value))
+	u, err := url.Parse(value)
+	if err != nil || !allowedSchemes[strings.ToLower(u.Scheme)] {
+		return "", false
+	}
+	return u.String(), true
+}